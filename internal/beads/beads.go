@@ -18,6 +18,7 @@ var (
 	ErrNotARepo     = errors.New("not a beads repository (no .beads directory found)")
 	ErrSyncConflict = errors.New("beads sync conflict")
 	ErrNotFound     = errors.New("issue not found")
+	ErrUnavailable  = errors.New("beads database unavailable")
 )
 
 // ResolveBeadsDir returns the actual beads directory, following any redirect.
@@ -186,7 +187,25 @@ func SetupRedirect(townRoot, worktreePath string) error {
 		return fmt.Errorf("cannot create redirect in canonical beads location (mayor/rig)")
 	}
 
-	rigRoot := filepath.Join(townRoot, parts[0])
+	return SetupRigRedirect(filepath.Join(townRoot, parts[0]), worktreePath)
+}
+
+// SetupRigRedirect is SetupRedirect for a worktree of the rig at rigRoot,
+// which may live outside the rig (a rig's worker_root on another disk):
+// the redirect is relative to wherever the worktree is.
+func SetupRigRedirect(rigRoot, worktreePath string) error {
+	// The path from the worktree up to the rig root, e.g. ../../ for
+	// crew/<name> or refinery/rig, or ../../../gt/<rig>/ from outside
+	upPath, err := filepath.Rel(worktreePath, rigRoot)
+	if err != nil {
+		return fmt.Errorf("computing relative path: %w", err)
+	}
+	upPath = filepath.ToSlash(upPath) + "/"
+
+	// Safety check: prevent creating redirect in canonical beads location (mayor/rig)
+	if rel, err := filepath.Rel(rigRoot, worktreePath); err == nil && strings.HasPrefix(filepath.ToSlash(rel)+"/", "mayor/") {
+		return fmt.Errorf("cannot create redirect in canonical beads location (mayor/rig)")
+	}
 
 	// Find the canonical beads location. In order of preference:
 	// 1. rig/.beads (if it exists and has content or a redirect)
@@ -197,12 +216,6 @@ func SetupRedirect(townRoot, worktreePath string) error {
 	rigBeadsPath := filepath.Join(rigRoot, ".beads")
 	mayorBeadsPath := filepath.Join(rigRoot, "mayor", "rig", ".beads")
 
-	// Compute depth for relative paths
-	// e.g., crew/<name> (depth 2) -> ../../
-	//       refinery/rig (depth 2) -> ../../
-	depth := len(parts) - 1 // subtract 1 for rig name itself
-	upPath := strings.Repeat("../", depth)
-
 	var redirectPath string
 
 	// Check if rig-level .beads exists
@@ -281,6 +294,16 @@ type Issue struct {
 	// Detailed dependency info from show output
 	Dependencies []IssueDep `json:"dependencies,omitempty"`
 	Dependents   []IssueDep `json:"dependents,omitempty"`
+
+	// Comments from show output, oldest first
+	Comments []IssueComment `json:"comments,omitempty"`
+}
+
+// IssueComment is a comment on an issue, as returned by show.
+type IssueComment struct {
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
 }
 
 // IssueDep represents a dependency or dependent issue with its relation.
@@ -341,8 +364,20 @@ type ListOptions struct {
 	Parent     string // filter by parent ID
 	Assignee   string // filter by assignee (e.g., "gastown/Toast")
 	NoAssignee bool   // filter for issues with no assignee
+
+	// ReadyOnly lists only ready issues (open, no open blockers) via
+	// bd ready, with the other filters applied in the same query. Status
+	// is ignored: ready issues are open.
+	ReadyOnly bool
+
+	// Sort orders the results in the query, e.g. SortPriority. Empty keeps
+	// bd's default order.
+	Sort string
 }
 
+// SortPriority orders issues by priority, P0 first (ListOptions.Sort).
+const SortPriority = "priority"
+
 // CreateOptions specifies options for creating an issue.
 type CreateOptions struct {
 	Title       string
@@ -350,7 +385,8 @@ type CreateOptions struct {
 	Priority    int    // 0-4
 	Description string
 	Parent      string
-	Actor       string // Who is creating this issue (populates created_by)
+	Actor       string   // Who is creating this issue (populates created_by)
+	Labels      []string // Labels to attach (e.g. "team:payments")
 }
 
 // UpdateOptions specifies options for updating an issue.
@@ -440,6 +476,9 @@ func (b *Beads) wrapError(err error, stderr string, args []string) error {
 	if strings.Contains(stderr, "sync conflict") || strings.Contains(stderr, "CONFLICT") {
 		return ErrSyncConflict
 	}
+	if isUnavailableStderr(stderr) {
+		return fmt.Errorf("%w: %s", ErrUnavailable, stderr)
+	}
 	if strings.Contains(stderr, "not found") || strings.Contains(stderr, "Issue not found") {
 		return ErrNotFound
 	}
@@ -450,11 +489,66 @@ func (b *Beads) wrapError(err error, stderr string, args []string) error {
 	return fmt.Errorf("bd %s: %w", strings.Join(args, " "), err)
 }
 
+// isUnavailableStderr reports whether bd stderr indicates the backing
+// database could not be opened or queried at all.
+func isUnavailableStderr(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, marker := range []string{
+		"failed to open database",
+		"database is locked",
+		"unable to open database",
+		"no such table",
+		"database disk image is malformed",
+		"connection refused",
+	} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnavailable reports whether err means beads cannot be used at all:
+// bd is not installed, there is no beads repository, or the database
+// cannot be opened. Callers use this to print setup guidance instead of
+// a low-level bd error.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrNotInstalled) ||
+		errors.Is(err, ErrNotARepo) ||
+		errors.Is(err, ErrUnavailable)
+}
+
 // List returns issues matching the given options.
 func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
-	args := []string{"list", "--json"}
+	out, err := b.run(opts.args()...)
+	if err != nil {
+		return nil, err
+	}
 
-	if opts.Status != "" {
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd %s output: %w", opts.command(), err)
+	}
+
+	return issues, nil
+}
+
+// command returns the bd command List runs for opts.
+func (opts ListOptions) command() string {
+	if opts.ReadyOnly {
+		return "ready"
+	}
+	return "list"
+}
+
+// args returns the bd arguments List runs for opts. A ready query is
+// unlimited (bd ready shows 10 by default), so no ready issue is dropped.
+func (opts ListOptions) args() []string {
+	args := []string{opts.command(), "--json"}
+
+	if opts.ReadyOnly {
+		args = append(args, "--limit=0")
+	} else if opts.Status != "" {
 		args = append(args, "--status="+opts.Status)
 	}
 	if opts.Type != "" {
@@ -472,18 +566,10 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 	if opts.NoAssignee {
 		args = append(args, "--no-assignee")
 	}
-
-	out, err := b.run(args...)
-	if err != nil {
-		return nil, err
-	}
-
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	if opts.Sort != "" {
+		args = append(args, "--sort="+opts.Sort)
 	}
-
-	return issues, nil
+	return args
 }
 
 // ListByAssignee returns all issues assigned to a specific assignee.
@@ -663,6 +749,9 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	if opts.Parent != "" {
 		args = append(args, "--parent="+opts.Parent)
 	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--labels="+strings.Join(opts.Labels, ","))
+	}
 	// Default Actor from BD_ACTOR env var if not specified
 	actor := opts.Actor
 	if actor == "" {
@@ -706,6 +795,9 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 	if opts.Parent != "" {
 		args = append(args, "--parent="+opts.Parent)
 	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--labels="+strings.Join(opts.Labels, ","))
+	}
 	// Default Actor from BD_ACTOR env var if not specified
 	actor := opts.Actor
 	if actor == "" {
@@ -804,6 +896,12 @@ func (b *Beads) CloseWithReason(reason string, ids ...string) error {
 	return err
 }
 
+// Comment adds a comment to an issue.
+func (b *Beads) Comment(id, text string) error {
+	_, err := b.run("comment", id, text)
+	return err
+}
+
 // Release moves an in_progress issue back to open status.
 // This is used to recover stuck steps when a worker dies mid-task.
 // It clears the assignee so the step can be claimed by another worker.
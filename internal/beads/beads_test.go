@@ -2,6 +2,7 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -32,6 +33,29 @@ func TestListOptions(t *testing.T) {
 	}
 }
 
+// TestListOptionsArgs verifies the bd query List runs.
+func TestListOptionsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListOptions
+		want string
+	}{
+		{"list", ListOptions{Status: "open", Type: "merge-request", Priority: -1},
+			"list --json --status=open --type=merge-request"},
+		{"list sorted", ListOptions{Status: "open", Priority: 1, Sort: SortPriority},
+			"list --json --status=open --priority=1 --sort=priority"},
+		{"ready", ListOptions{ReadyOnly: true, Status: "open", Type: "merge-request", Priority: -1, Sort: SortPriority},
+			"ready --json --limit=0 --type=merge-request --sort=priority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(tt.opts.args(), " "); got != tt.want {
+				t.Errorf("args() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestCreateOptions verifies CreateOptions fields.
 func TestCreateOptions(t *testing.T) {
 	opts := CreateOptions{
@@ -115,6 +139,32 @@ func TestWrapError(t *testing.T) {
 	}
 }
 
+// TestWrapError_Unavailable tests detection of an unusable beads database.
+func TestWrapError_Unavailable(t *testing.T) {
+	b := New("/test")
+
+	for _, stderr := range []string{
+		"Error: failed to open database: permission denied",
+		"database is locked",
+		"no such table: issues",
+	} {
+		err := b.wrapError(nil, stderr, []string{"list"})
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("wrapError(%q) = %v, want ErrUnavailable", stderr, err)
+		}
+		if !IsUnavailable(err) {
+			t.Errorf("IsUnavailable(wrapError(%q)) = false, want true", stderr)
+		}
+	}
+
+	if IsUnavailable(ErrNotFound) {
+		t.Error("IsUnavailable(ErrNotFound) = true, want false")
+	}
+	if !IsUnavailable(ErrNotInstalled) || !IsUnavailable(ErrNotARepo) {
+		t.Error("IsUnavailable() should be true for ErrNotInstalled and ErrNotARepo")
+	}
+}
+
 // Integration test that runs against real bd if available
 func TestIntegration(t *testing.T) {
 	if testing.Short() {
@@ -435,6 +485,15 @@ worker: Toast`,
 			want: `merge_commit: deadbeef
 close_reason: rejected`,
 		},
+		{
+			name: "draft",
+			fields: &MRFields{
+				Branch: "polecat/Toast/gt-abc",
+				Draft:  true,
+			},
+			want: `branch: polecat/Toast/gt-abc
+draft: true`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -582,6 +641,37 @@ func TestMRFieldsRoundTrip(t *testing.T) {
 	}
 }
 
+func TestMRFieldsRoundTrip_Rejection(t *testing.T) {
+	original := &MRFields{
+		Branch:         "polecat/Nux/gt-xyz",
+		Target:         "main",
+		CloseReason:    "rejected",
+		RejectCategory: "duplicate",
+		RejectReason:   "Same fix landed in gt-abc",
+		RejectThread:   "thread-0123abcd",
+		SupersededBy:   "gt-mr-def",
+	}
+
+	parsed := ParseMRFields(&Issue{Description: FormatMRFields(original)})
+	if parsed == nil || *parsed != *original {
+		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
+	}
+}
+
+func TestMRFieldsRoundTrip_Attempts(t *testing.T) {
+	original := &MRFields{
+		Branch:   "polecat/Nux/gt-xyz",
+		Target:   "main",
+		FailedAt: "2026-01-02T03:04:05Z",
+		Attempts: 4,
+	}
+
+	parsed := ParseMRFields(&Issue{Description: FormatMRFields(original)})
+	if parsed == nil || *parsed != *original {
+		t.Errorf("round-trip mismatch:\ngot  %+v\nwant %+v", parsed, original)
+	}
+}
+
 // TestParseMRFieldsFromDesignDoc tests the example from the design doc.
 func TestParseMRFieldsFromDesignDoc(t *testing.T) {
 	// Example from docs/merge-queue-design.md
@@ -1796,3 +1886,47 @@ func TestSetupRedirect(t *testing.T) {
 		}
 	})
 }
+
+func TestSetupRigRedirect_OutsideRig(t *testing.T) {
+	// Setup: town/rig/.beads, polecat worktree on another disk
+	townRoot := t.TempDir()
+	rigRoot := filepath.Join(townRoot, "testrig")
+	if err := os.MkdirAll(filepath.Join(rigRoot, ".beads"), 0755); err != nil {
+		t.Fatalf("mkdir rig beads: %v", err)
+	}
+	polecatPath := filepath.Join(t.TempDir(), "fast", "Nux")
+	if err := os.MkdirAll(polecatPath, 0755); err != nil {
+		t.Fatalf("mkdir polecat: %v", err)
+	}
+
+	if err := SetupRigRedirect(rigRoot, polecatPath); err != nil {
+		t.Fatalf("SetupRigRedirect failed: %v", err)
+	}
+	if got, want := ResolveBeadsDir(polecatPath), filepath.Join(rigRoot, ".beads"); got != want {
+		t.Errorf("ResolveBeadsDir(polecat) = %q, want the rig's beads %q", got, want)
+	}
+}
+
+func TestIssueComments_FromShowJSON(t *testing.T) {
+	data := `{"id":"gt-mr-abc","title":"Merge: gt-xyz","comments":[
+		{"id":1,"issue_id":"gt-mr-abc","author":"gastown/crew/max","text":"waiting on infra fix","created_at":"2026-01-02T10:00:00Z"},
+		{"id":2,"issue_id":"gt-mr-abc","author":"gastown/crew/joe","text":"infra fixed","created_at":"2026-01-02T11:00:00Z"}]}`
+
+	var issue Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	want := []IssueComment{
+		{Author: "gastown/crew/max", Text: "waiting on infra fix", CreatedAt: "2026-01-02T10:00:00Z"},
+		{Author: "gastown/crew/joe", Text: "infra fixed", CreatedAt: "2026-01-02T11:00:00Z"},
+	}
+	if len(issue.Comments) != len(want) {
+		t.Fatalf("got %d comments, want %d", len(issue.Comments), len(want))
+	}
+	for i := range want {
+		if issue.Comments[i] != want[i] {
+			t.Errorf("comment %d = %+v, want %+v", i, issue.Comments[i], want[i])
+		}
+	}
+}
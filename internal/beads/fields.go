@@ -3,6 +3,7 @@ package beads
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -187,6 +188,24 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// Rejection details (set when close_reason is rejected)
+	RejectCategory string // Why it was rejected: duplicate, quality, superseded, obsolete, other
+	RejectReason   string // Free-text rejection reason
+	RejectThread   string // Mail thread of the rejection notice, for replies
+	SupersededBy   string // MR that replaces this one (gt mq reject --supersede)
+	RejectForcedBy string // Who rejected it past the state checks (gt mq reject --force)
+
+	// Outcome timestamps (RFC 3339), recorded by the refinery for reporting
+	MergedAt   string // When the MR merged
+	RejectedAt string // When the MR was rejected
+	FailedAt   string // When the last merge attempt failed
+
+	// Attempts is how many times the refinery has tried to merge the MR
+	Attempts int
+
+	// Draft marks an MR as queued but not ready; the refinery skips it
+	Draft bool
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -261,6 +280,40 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "reject_category", "reject-category", "rejectcategory":
+			fields.RejectCategory = value
+			hasFields = true
+		case "reject_reason", "reject-reason", "rejectreason":
+			fields.RejectReason = value
+			hasFields = true
+		case "reject_thread", "reject-thread", "rejectthread":
+			fields.RejectThread = value
+			hasFields = true
+		case "superseded_by", "superseded-by", "supersededby":
+			fields.SupersededBy = value
+			hasFields = true
+		case "reject_forced_by", "reject-forced-by", "rejectforcedby":
+			fields.RejectForcedBy = value
+			hasFields = true
+		case "merged_at", "merged-at", "mergedat":
+			fields.MergedAt = value
+			hasFields = true
+		case "rejected_at", "rejected-at", "rejectedat":
+			fields.RejectedAt = value
+			hasFields = true
+		case "failed_at", "failed-at", "failedat":
+			fields.FailedAt = value
+			hasFields = true
+		case "attempts":
+			if n, err := parseIntField(value); err == nil {
+				fields.Attempts = n
+				hasFields = true
+			}
+		case "draft":
+			if draft, err := strconv.ParseBool(value); err == nil {
+				fields.Draft = draft
+				hasFields = true
+			}
 		}
 	}
 
@@ -325,6 +378,36 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if fields.RejectCategory != "" {
+		lines = append(lines, "reject_category: "+fields.RejectCategory)
+	}
+	if fields.RejectReason != "" {
+		lines = append(lines, "reject_reason: "+fields.RejectReason)
+	}
+	if fields.RejectThread != "" {
+		lines = append(lines, "reject_thread: "+fields.RejectThread)
+	}
+	if fields.SupersededBy != "" {
+		lines = append(lines, "superseded_by: "+fields.SupersededBy)
+	}
+	if fields.RejectForcedBy != "" {
+		lines = append(lines, "reject_forced_by: "+fields.RejectForcedBy)
+	}
+	if fields.MergedAt != "" {
+		lines = append(lines, "merged_at: "+fields.MergedAt)
+	}
+	if fields.RejectedAt != "" {
+		lines = append(lines, "rejected_at: "+fields.RejectedAt)
+	}
+	if fields.FailedAt != "" {
+		lines = append(lines, "failed_at: "+fields.FailedAt)
+	}
+	if fields.Attempts > 0 {
+		lines = append(lines, fmt.Sprintf("attempts: %d", fields.Attempts))
+	}
+	if fields.Draft {
+		lines = append(lines, "draft: true")
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -371,6 +454,32 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"reject_category":    true,
+		"reject-category":    true,
+		"rejectcategory":     true,
+		"reject_reason":      true,
+		"reject-reason":      true,
+		"rejectreason":       true,
+		"reject_thread":      true,
+		"reject-thread":      true,
+		"rejectthread":       true,
+		"superseded_by":      true,
+		"superseded-by":      true,
+		"supersededby":       true,
+		"reject_forced_by":   true,
+		"reject-forced-by":   true,
+		"rejectforcedby":     true,
+		"merged_at":          true,
+		"merged-at":          true,
+		"mergedat":           true,
+		"rejected_at":        true,
+		"rejected-at":        true,
+		"rejectedat":         true,
+		"failed_at":          true,
+		"failed-at":          true,
+		"failedat":           true,
+		"attempts":           true,
+		"draft":              true,
 	}
 
 	// Collect non-MR lines from existing description
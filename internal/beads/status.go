@@ -0,0 +1,26 @@
+package beads
+
+// Built-in issue statuses, besides StatusPinned and StatusHooked.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusDeferred   = "deferred"
+	StatusClosed     = "closed"
+)
+
+// Statuses lists every issue status bd accepts.
+var Statuses = []string{
+	StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred,
+	StatusClosed, StatusPinned, StatusHooked,
+}
+
+// ValidStatus reports whether bd accepts status for an issue.
+func ValidStatus(status string) bool {
+	for _, s := range Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"golang.org/x/term"
+)
+
+// stdinIsTerminal reports whether destructive commands should ask before
+// acting. Tests replace it.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirmDestructive asks before a destructive command acts: it prints
+// action and each of changes to stderr, then waits for y/yes on stdin.
+// It only prompts on a terminal; with --yes (yes) or when stdin is not a
+// terminal (scripts, pipes) it returns true without asking. EOF and Ctrl-C
+// while waiting count as "no".
+func confirmDestructive(action string, changes []string, yes bool) bool {
+	if yes || !stdinIsTerminal() {
+		return true
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	return promptConfirm(os.Stderr, os.Stdin, interrupt, action, changes)
+}
+
+// promptConfirm prints the prompt to w and reads the answer from r. An
+// answer other than y/yes, EOF, a read error, or a value on interrupt all
+// decline.
+func promptConfirm(w io.Writer, r io.Reader, interrupt <-chan os.Signal, action string, changes []string) bool {
+	fmt.Fprintf(w, "%s %s:\n", style.Warning.Render("⚠"), action)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s\n", c)
+	}
+	fmt.Fprint(w, "Proceed? [y/N] ")
+
+	answer := make(chan string, 1)
+	go func() {
+		// On interrupt this read is abandoned; the command exits soon after.
+		line, err := bufio.NewReader(r).ReadString('\n')
+		if err != nil && line == "" {
+			close(answer)
+			return
+		}
+		answer <- line
+	}()
+
+	select {
+	case line, ok := <-answer:
+		if !ok {
+			fmt.Fprintln(w)
+			return false
+		}
+		line = strings.TrimSpace(strings.ToLower(line))
+		return line == "y" || line == "yes"
+	case <-interrupt:
+		fmt.Fprintln(w)
+		return false
+	}
+}
+
+// errCanceled is returned when the user declines a confirmation prompt.
+// Nothing was changed, so the command exits quietly with status 1.
+func errCanceled() error {
+	fmt.Fprintln(os.Stderr, "Canceled; nothing was changed.")
+	return NewSilentExit(1)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPromptConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"yes", "y\n", true},
+		{"yes word", " YES \n", true},
+		{"no", "n\n", false},
+		{"empty line", "\n", false},
+		{"other", "sure\n", false},
+		{"EOF", "", false},
+		{"yes without newline", "y", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got := promptConfirm(&out, strings.NewReader(tt.input), nil, "Rejecting a merge request",
+				[]string{"MR: gp-mr-abc", "Reason: obsolete"})
+			if got != tt.want {
+				t.Errorf("promptConfirm(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for _, want := range []string{"Rejecting a merge request:", "  MR: gp-mr-abc\n", "  Reason: obsolete\n", "Proceed? [y/N]"} {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("prompt missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPromptConfirm_Interrupt(t *testing.T) {
+	// A reader that never answers: only the interrupt can end the prompt
+	r, w := io.Pipe()
+	defer w.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	interrupt <- os.Interrupt
+	if promptConfirm(io.Discard, r, interrupt, "Purging crew workspace(s)", nil) {
+		t.Error("promptConfirm after Ctrl-C = true, want false")
+	}
+}
+
+func TestConfirmDestructive_SkipsPrompt(t *testing.T) {
+	old := stdinIsTerminal
+	defer func() { stdinIsTerminal = old }()
+
+	// --yes never prompts, even on a terminal
+	stdinIsTerminal = func() bool { return true }
+	if !confirmDestructive("Rejecting a merge request", nil, true) {
+		t.Error("confirmDestructive with --yes = false, want true")
+	}
+
+	// Without a terminal (scripts, pipes) there is no one to ask
+	stdinIsTerminal = func() bool { return false }
+	if !confirmDestructive("Rejecting a merge request", nil, false) {
+		t.Error("confirmDestructive without a terminal = false, want true")
+	}
+}
@@ -13,6 +13,7 @@ var (
 	crewJSON          bool
 	crewForce         bool
 	crewPurge         bool
+	crewYes           bool
 	crewNoTmux        bool
 	crewDetached      bool
 	crewMessage       string
@@ -131,12 +132,16 @@ leave no trace in the ledger).
   - Clears mail in the agent's inbox
   - Properly handles git worktrees (not just regular clones)
 
+Because --purge can't be undone, on a terminal it lists what will be
+deleted and asks for confirmation first; --yes skips the prompt.
+
 Examples:
   gt crew remove dave                       # Remove with safety checks
   gt crew remove dave emma fred             # Remove multiple
   gt crew remove beads/grip beads/fang      # Remove from specific rig
   gt crew remove dave --force               # Force remove (closes bead)
-  gt crew remove test-crew --purge          # Obliterate (deletes bead)`,
+  gt crew remove test-crew --purge          # Obliterate (deletes bead)
+  gt crew remove test-crew --purge --yes    # Obliterate without asking`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runCrewRemove,
 }
@@ -337,6 +342,7 @@ func init() {
 	crewRemoveCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 	crewRemoveCmd.Flags().BoolVar(&crewForce, "force", false, "Force remove (skip safety checks)")
 	crewRemoveCmd.Flags().BoolVar(&crewPurge, "purge", false, "Obliterate: delete agent bead, unassign work, clear mail")
+	crewRemoveCmd.Flags().BoolVarP(&crewYes, "yes", "y", false, "Don't ask for confirmation before --purge")
 
 	crewRefreshCmd.Flags().StringVar(&crewRig, "rig", "", "Rig to use")
 	crewRefreshCmd.Flags().StringVarP(&crewMessage, "message", "m", "", "Custom handoff message")
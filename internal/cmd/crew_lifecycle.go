@@ -25,6 +25,17 @@ func runCrewRemove(cmd *cobra.Command, args []string) error {
 	// --purge implies --force
 	forceRemove := crewForce || crewPurge
 
+	if crewPurge {
+		changes := make([]string, 0, len(args)+1)
+		for _, arg := range args {
+			changes = append(changes, "crew "+arg+": workspace removed (uncommitted changes lost), agent bead deleted")
+		}
+		changes = append(changes, "Their assigned beads are unassigned and their mail cleared")
+		if !confirmDestructive("Purging crew workspace(s)", changes, crewYes) {
+			return errCanceled()
+		}
+	}
+
 	for _, arg := range args {
 		name := arg
 		rigOverride := crewRig
@@ -102,7 +102,7 @@ func runDone(cmd *cobra.Command, args []string) error {
 	}
 
 	// Find current rig
-	rigName, _, err := findCurrentRig(townRoot)
+	rigName, r, err := findCurrentRig(townRoot)
 	if err != nil {
 		return err
 	}
@@ -229,7 +229,7 @@ func runDone(cmd *cobra.Command, args []string) error {
 			description += "\nconflict_task_id: null"
 
 			// Create MR bead (ephemeral wisp - will be cleaned up after merge)
-			mrIssue, err := bd.Create(beads.CreateOptions{
+			mrIssue, err := createMRBead(bd, r, branch, beads.CreateOptions{
 				Title:       title,
 				Type:        "merge-request",
 				Priority:    priority,
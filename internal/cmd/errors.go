@@ -1,6 +1,44 @@
 package cmd
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// Exit codes for well-known merge queue failures, so scripts can tell them
+// apart without parsing messages. Any other error exits with 1.
+const (
+	ExitMRNotFound = 3
+	ExitMRBlocked  = 4
+	ExitMRClosed   = 5
+	ExitRigPaused  = 6
+	ExitConflict   = 7
+
+	// ExitInterrupted is used when a long-running command is stopped with
+	// Ctrl-C (128 + SIGINT, as shells report it).
+	ExitInterrupted = 130
+)
+
+// exitCodeForError maps an error to a process exit code.
+// Errors must wrap the refinery sentinels (checked with errors.Is).
+func exitCodeForError(err error) int {
+	switch {
+	case errors.Is(err, refinery.ErrMRNotFound):
+		return ExitMRNotFound
+	case errors.Is(err, refinery.ErrMRBlocked):
+		return ExitMRBlocked
+	case errors.Is(err, refinery.ErrMRClosed), errors.Is(err, refinery.ErrMRAlreadyClosed):
+		return ExitMRClosed
+	case errors.Is(err, refinery.ErrRigPaused):
+		return ExitRigPaused
+	case errors.Is(err, refinery.ErrConflict):
+		return ExitConflict
+	default:
+		return 1
+	}
+}
 
 // SilentExitError signals that the command should exit with a specific code
 // without printing an error message. This is used for scripting purposes
@@ -24,7 +62,8 @@ func IsSilentExit(err error) (int, bool) {
 	if err == nil {
 		return 0, false
 	}
-	if se, ok := err.(*SilentExitError); ok {
+	var se *SilentExitError
+	if errors.As(err, &se) {
 		return se.Code, true
 	}
 	return 0, false
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", fmt.Errorf("%w: 'gt-mr1' in rig 'gastown'", refinery.ErrMRNotFound), ExitMRNotFound},
+		{"blocked", fmt.Errorf("%w: waiting on gt-1", refinery.ErrMRBlocked), ExitMRBlocked},
+		{"closed", fmt.Errorf("rejecting MR: %w", refinery.ErrMRClosed), ExitMRClosed},
+		{"already closed", fmt.Errorf("%w: gt-mr-1 (merged)", refinery.ErrMRAlreadyClosed), ExitMRClosed},
+		{"paused", fmt.Errorf("cannot retry while %w", refinery.ErrRigPaused), ExitRigPaused},
+		{"conflict", fmt.Errorf("%w: a.go", refinery.ErrConflict), ExitConflict},
+		{"other", errors.New("boom"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeForError(tt.err); got != tt.want {
+				t.Errorf("exitCodeForError(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSilentExit_Wrapped(t *testing.T) {
+	code, ok := IsSilentExit(fmt.Errorf("checking mail: %w", NewSilentExit(2)))
+	if !ok || code != 2 {
+		t.Errorf("IsSilentExit(wrapped) = (%d, %v), want (2, true)", code, ok)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// interruptContext returns a context cancelled on SIGINT/SIGTERM. Long-running
+// commands (watch modes, wait and until-success loops) pass it down to the
+// work they do, including git subprocesses, so Ctrl-C stops everything.
+// Call stop when done to restore default signal handling.
+func interruptContext() (ctx context.Context, stop context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runInterruptible runs fn under interruptContext. If fn stops because of an
+// interrupt, the terminal is reset, summary (if non-nil) prints what was done,
+// and the command exits quietly with ExitInterrupted instead of reporting
+// fn's (usually context) error.
+func runInterruptible(fn func(ctx context.Context) error, summary func()) error {
+	ctx, stop := interruptContext()
+	defer stop()
+
+	err := fn(ctx)
+	if ctx.Err() == nil {
+		return err
+	}
+
+	resetTerminal(os.Stdout)
+	if summary != nil {
+		summary()
+	}
+	return NewSilentExit(ExitInterrupted)
+}
+
+// resetTerminal undoes display state a loop may have left behind when cut
+// short: it leaves the alternate screen, shows the cursor and clears styling.
+// No-op when w is not a terminal.
+func resetTerminal(w io.Writer) {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return
+	}
+	// ANSI: exit alt screen, show cursor, reset attributes
+	_, _ = fmt.Fprint(w, "\033[?1049l\033[?25h\033[0m\n")
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunInterruptible(t *testing.T) {
+	t.Run("passes through result", func(t *testing.T) {
+		want := errors.New("boom")
+		err := runInterruptible(func(context.Context) error { return want }, func() {
+			t.Error("summary should not run without an interrupt")
+		})
+		if err != want {
+			t.Errorf("runInterruptible() = %v, want %v", err, want)
+		}
+	})
+
+	t.Run("interrupt cancels and summarizes", func(t *testing.T) {
+		summarized := false
+		err := runInterruptible(func(ctx context.Context) error {
+			_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				t.Fatal("context not cancelled by SIGINT")
+				return nil
+			}
+		}, func() { summarized = true })
+
+		if code, ok := IsSilentExit(err); !ok || code != ExitInterrupted {
+			t.Errorf("runInterruptible() = %v, want silent exit %d", err, ExitInterrupted)
+		}
+		if !summarized {
+			t.Error("summary was not printed after interrupt")
+		}
+	})
+}
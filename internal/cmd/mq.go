@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/refinery"
@@ -20,22 +23,56 @@ var (
 	mqSubmitBranch    string
 	mqSubmitIssue     string
 	mqSubmitEpic      string
+	mqSubmitTarget    string
+	mqSubmitWorker    string
 	mqSubmitPriority  int
 	mqSubmitNoCleanup bool
+	mqSubmitLabels    []string
 
 	// Retry flags
-	mqRetryNow bool
+	mqRetryNow          bool
+	mqRetryDeprioritize bool
+	mqRetryUntilSuccess bool
+	mqRetryMaxAttempts  int
+	mqRetryInterval     time.Duration
+	mqRetryForce        bool
+	mqRetryRecreate     bool
+	mqRetryJSON         bool
+	mqRetryEpic         string
+	mqRetryAllFailed    bool
+	mqRetryRevalidate   bool
+	mqRetryDryRun       bool
+	mqRetryYes          bool
 
 	// Reject flags
-	mqRejectReason string
-	mqRejectNotify bool
+	mqRejectReason    string
+	mqRejectCategory  string
+	mqRejectNotify    bool
+	mqRejectJSON      bool
+	mqRejectSupersede string
+	mqRejectForce     bool
+	mqRejectYes       bool
 
 	// List command flags
-	mqListReady  bool
-	mqListStatus string
-	mqListWorker string
-	mqListEpic   string
-	mqListJSON   bool
+	mqListReady        bool
+	mqListStatus       string
+	mqListWorker       string
+	mqListMe           bool
+	mqListEpic         string
+	mqListJSON         bool
+	mqListNoHeader     bool
+	mqListHasNotes     bool
+	mqListClaimed      bool
+	mqListUnclaimed    bool
+	mqListUpdatedSince string
+	mqListBlockedBy    string
+	mqListWide         bool
+	mqListIDWidth      int
+	mqListBranchWidth  int
+	mqListLimit        int
+	mqListLabels       []string
+	mqListFormat       string
+	mqListAllRigs      bool
 
 	// Status command flags
 	mqStatusJSON bool
@@ -60,67 +97,159 @@ var mqCmd = &cobra.Command{
 Alias: 'gt mr' is equivalent to 'gt mq' (merge request vs merge queue).
 
 The merge queue tracks work branches from polecats waiting to be merged.
-Use these commands to view, submit, retry, and manage merge requests.`,
+Use these commands to view, submit, retry, and manage merge requests.
+
+The rig argument is a registered rig name or a path to a rig directory
+(anything containing a '/', or '.' or '..'), e.g. for a rig outside the
+default rig root: gt mq list ../other-town/gastown
+
+JSON output (--json) carries a top-level schema_version field (currently 1)
+that is bumped whenever the output shape changes. Outputs that are lists
+(e.g. 'gt refinery queue --json') stay lists: each object in the list
+carries schema_version instead.`,
 }
 
 var mqSubmitCmd = &cobra.Command{
-	Use:   "submit",
+	Use:   "submit [rig]",
 	Short: "Submit current branch to the merge queue",
 	Long: `Submit the current branch to the merge queue.
 
 Creates a merge-request bead that will be processed by the Refinery.
 
+Run from within a worker's worktree, or pass the rig and --worker to submit
+a worker's branch from anywhere in the town. --worker finds the worktree in
+the rig's worker roots: polecats/ then crew/, or the directories listed in
+the rig config's worker_roots (e.g. ["polecats", "crew", "bots"]).
+
 Auto-detection:
-  - Branch: current git branch
+  - Branch: current git branch (of the worker's worktree with --worker)
   - Issue: parsed from branch name (e.g., polecat/Nux/gp-xyz → gt-xyz)
-  - Worker: parsed from branch name
-  - Rig: detected from current directory
+  - Worker: parsed from branch name (or --worker)
+  - Rig: detected from current directory (or the rig argument)
   - Target: automatically determined (see below)
   - Priority: inherited from source issue
 
 Target branch auto-detection:
-  1. If --epic is specified: target integration/<epic>
-  2. If source issue has a parent epic with integration/<epic> branch: target it
-  3. Otherwise: target main
+  1. If --target is specified: target that branch
+  2. If --epic is specified: target integration/<epic>
+  3. If source issue has a parent epic with integration/<epic> branch: target it
+  4. Otherwise: target main
 
 This ensures batch work on epics automatically flows to integration branches.
 
+Protected branches:
+  Targets matching the rig's protected_branches patterns in config.json
+  (e.g. ["main", "release/*"]) are refused: the refinery pushes merges
+  directly, which the host rejects for protected branches. Changes to them
+  go through the host's pull request flow instead.
+
+Labels:
+  --label (repeatable) attaches labels to the MR bead, e.g. team:payments
+  or component:api, to slice the queue by team or component with
+  'gt mq list --label'.
+
+MR IDs:
+  By default beads assigns the MR bead's ID with the rig's bead prefix.
+  Set merge_queue.mr_id_prefix in the rig's config.json (e.g. "acme") to
+  name new MRs <prefix>-mr-<hash> instead (acme-mr-1a2b3c), so towns that
+  share IDs don't collide. Existing MRs keep their IDs: every command looks
+  MRs up by full ID whatever its prefix, so old and new IDs work side by
+  side while the old ones drain from the queue.
+
 Polecat auto-cleanup:
   When run from a polecat work branch (polecat/<worker>/<issue>), this command
   automatically triggers polecat shutdown after submitting the MR. The polecat
   sends a lifecycle request to its Witness and waits for termination.
 
   Use --no-cleanup to disable this behavior (e.g., if you want to submit
-  multiple MRs or continue working).
+  multiple MRs or continue working). There is no auto-cleanup with
+  --worker: that submits on the worker's behalf from another shell.
 
 Examples:
   gt mq submit                           # Auto-detect everything + auto-cleanup
   gt mq submit --issue gp-abc            # Explicit issue
   gt mq submit --epic gt-xyz             # Target integration branch explicitly
+  gt mq submit --target integration/gt-xyz
+  gt mq submit gastown --worker Nux      # Submit Nux's branch from anywhere
   gt mq submit --priority 0              # Override priority (P0)
+  gt mq submit --label team:payments     # Label the MR (repeatable)
   gt mq submit --no-cleanup              # Submit without auto-cleanup`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runMqSubmit,
 }
 
 var mqRetryCmd = &cobra.Command{
-	Use:   "retry <rig> <mr-id>",
+	Use:   "retry <rig> [mr-id]",
 	Short: "Retry a failed merge request",
 	Long: `Retry a failed merge request.
 
 Resets a failed MR so it can be processed again by the refinery.
 The MR must be in a failed state (open with an error).
 
+With --until-success, the merge is run in the foreground right away and
+retried up to --max-attempts times, waiting --interval between attempts.
+It stops on the first success. Use this for known-flaky merges; press
+Ctrl-C to stop cleanly between or during attempts. Each attempt takes the
+rig's processing lock, like a refinery cycle; if a cycle is running, the
+retry stops at once with an error. Like a cycle, it won't merge while the
+rig's merge window is closed; add --now to merge anyway.
+
+A retried MR keeps its priority, so it goes back to its original place in
+the queue (less a small per-retry score penalty). Use --deprioritize to
+drop it one priority level (e.g. P1 -> P2, never below P4) so a
+persistently failing MR stops holding up healthy ones.
+
+Retry refuses an MR whose worker no longer exists (e.g. the polecat was
+removed): a failed merge is sent back to the worker to fix, and nobody would
+get it. Use --recreate to recreate the polecat with the same name first,
+checked out on the MR's existing branch, or --force to retry anyway.
+
+With --revalidate, the refinery's pre-merge gates are run against the
+branch's current head before the MR is requeued: the branch exists, it
+merges cleanly into the target, and the rig's test command passes (if
+merge_queue.run_tests is on). If the branch changed since it failed, this
+catches a regression before it merges. The retry is refused, naming the
+gate that failed, if any gate doesn't pass.
+
+With --dry-run, nothing is retried: instead the retry is previewed. It
+shows whether retry would accept the MR, its target and priority after the
+retry, whether the branch still merges cleanly or needs a rebase first
+(and how far behind the target it is), the readiness checks that would
+still hold it back, and its retry backoff: how many times it has been
+retried and the score that costs it in the queue. Exits 1 if the retry
+would be refused.
+
+The MR is normally picked up on the next refinery cycle. If the rig's
+merge window (merge_queue.merge_window) is closed, retry says when it
+opens instead, and the JSON result carries it as eligible_at.
+
+With --json, the result (MR, branch, worker, new status, priority) is
+printed as JSON for scripts instead of the human summary.
+
+With --epic and --all-failed, every failed MR targeting the epic's
+integration branch (integration/<epic>, globs as in 'gt mq list --epic') is
+retried in turn, e.g. after fixing the epic's base. Each MR's result is
+printed, then a summary; exits 1 if any retry failed. On a terminal the
+MRs are listed and you are asked to confirm first; --yes skips the prompt.
+
 Examples:
   gt mq retry greenplace gp-mr-abc123
-  gt mq retry greenplace gp-mr-abc123 --now`,
-	Args: cobra.ExactArgs(2),
+  gt mq retry greenplace gp-mr-abc123 --now
+  gt mq retry greenplace gp-mr-abc123 --deprioritize
+  gt mq retry greenplace gp-mr-abc123 --recreate
+  gt mq retry greenplace gp-mr-abc123 --revalidate
+  gt mq retry greenplace gp-mr-abc123 --dry-run
+  gt mq retry greenplace --epic=gp-auth --all-failed
+  gt mq retry greenplace --epic=gp-auth --all-failed --yes
+  gt mq retry greenplace gp-mr-abc123 --until-success --max-attempts=3 --interval=1m`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runMQRetry,
 }
 
 var mqListCmd = &cobra.Command{
-	Use:   "list <rig>",
+	Use:   "list [rig]",
 	Short: "Show the merge queue",
-	Long: `Show the merge queue for a rig.
+	Long: `Show the merge queue for a rig, or for every rig with --all-rigs.
 
 Lists all pending merge requests waiting to be processed.
 
@@ -131,12 +260,90 @@ Output format:
   gt-mr-003   blocked      P1        polecat/Capable/gt-def    Capable 8m
               (waiting on gt-mr-001)
 
+--worker and --epic accept glob patterns (*, ?, [...]); worker matching
+is case-insensitive.
+
+--has-notes, --claimed and --unclaimed help a team share the queue: an MR
+is claimed when its bead is assigned or a refinery worker holds a live
+claim on it. All filters combine.
+
+--blocked-by lists the MRs waiting on a given MR (the ones whose
+blocked_by includes it): what merging it would unblock.
+
+--label shows only MRs carrying that label (set with 'gt mq submit
+--label'); repeat it to require several labels at once.
+
+--updated-since shows MRs touched recently (retried, re-pushed, noted),
+going by the bead's last update rather than its creation age.
+
+If the rig has a merge window (merge_queue.merge_window) and it is closed,
+the header says "(window closed)": ready MRs stay ready but won't merge
+until it opens.
+
+Drafts (gt mq draft) show as "draft": the refinery skips them until they
+are marked ready with 'gt mq ready'. --ready leaves them out.
+
+MRs matched by a hold (gt mq hold) show as "held" and are likewise left
+out of --ready until the hold is removed with 'gt mq unhold'.
+
+The MR the refinery is merging right now (or every MR in the batch it is
+merging) shows as "▶ merging"; an MR left in_progress by a refinery that
+stopped shows as "active".
+
+IDs and branches are truncated to 12 and 24 characters. Set other widths
+with merge_queue.list_id_width and list_branch_width in the rig's
+config.json, or per run with --id-width and --branch-width; 0 never
+truncates, sizing the column to its longest value. A truncated ID is
+lengthened where needed to stay unique among the rig's MRs, so it can be
+pasted as-is into other mq commands, which accept any unique ID prefix.
+
+--wide adds an ATTEMPTS column: how many times the refinery has tried to
+merge each MR (recorded on the MR bead). Chronically failing MRs stand out.
+
+--limit shows only the top N MRs by score. With --json the MRs are under
+"items", alongside "total" (every MR matching the filters), "limit" (0 if
+none) and "has_more", so clients can tell when results were cut off.
+
+--format=table draws the table with box-drawing borders around every
+cell; the default (--format=plain) separates columns with spaces only.
+
+--format=ndjson writes JSON Lines instead: one MR object per line, in the
+same shape as an --json item, written and flushed as each is encoded so a
+large queue is never held as one JSON document. There is no page summary;
+count the lines.
+
+MRs into or from a hot branch (merge_queue.hot_branches, e.g. "main" or
+"release/*") are scored as P0 whatever their own priority, and marked with
+↑ after the priority.
+
+--all-rigs lists the queue of every rig under the rig root (--rig-root,
+$GASTOWN_RIG_ROOT or the current town) in one table, with a RIG column
+first, grouped by rig and by score within each. All filters apply to every
+rig; --limit applies to each rig's queue, and --me to the rigs where the
+current user has a worker. With --json the output is grouped by rig under
+"rigs", each with its own "items", "total" and "has_more"; ndjson MRs carry
+a "rig" field. A rig that can't be listed is skipped with a warning, and
+the command exits 1.
+
 Examples:
   gt mq list greenplace
   gt mq list greenplace --ready
   gt mq list greenplace --status=open
-  gt mq list greenplace --worker=Nux`,
-	Args: cobra.ExactArgs(1),
+  gt mq list greenplace --worker=Nux
+  gt mq list greenplace --worker='F*'
+  gt mq list greenplace --epic='release-*'
+  gt mq list greenplace --me
+  gt mq list greenplace --unclaimed --ready
+  gt mq list greenplace --has-notes
+  gt mq list greenplace --label team:payments --label component:api
+  gt mq list greenplace --updated-since=1h
+  gt mq list greenplace --blocked-by=gp-mr-abc123
+  gt mq list greenplace --wide
+  gt mq list greenplace --limit=20 --json
+  gt mq list greenplace --no-header | awk '{print $1}'
+  gt mq list --all-rigs --ready
+  gt mq list --all-rigs --json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runMQList,
 }
 
@@ -148,9 +355,38 @@ var mqRejectCmd = &cobra.Command{
 This closes the MR with a 'rejected' status without merging.
 The source issue is NOT closed (work is not done).
 
+The reason, and optional --category (duplicate, quality, superseded,
+obsolete or other), are recorded on the MR bead so rejections can be
+broken down by cause.
+
+With --notify the worker is mailed the reason. The mail thread is recorded
+on the MR (shown by 'gt mq status'), so the worker can reply with
+'gt mail reply' and the discussion stays linked to the rejection.
+
+With --supersede, the MR replacing this one is recorded on the rejected MR
+(shown by 'gt mq status' and 'gt mq tail'), and the category defaults to
+superseded. The replacing MR must exist.
+
+An MR that has already merged or been closed can't be rejected. In the
+rare case its record must be overwritten anyway, --force skips that check
+and records the rejection regardless, with a warning. Who forced it is
+recorded on the MR (reject_forced_by) and in the town's audit log. Forcing
+changes only the record: a merged MR's commits stay merged.
+
+On a terminal, the MR and what the rejection will record are shown and
+you are asked to confirm first; answering anything but y, or Ctrl-C,
+cancels without changing anything. --yes skips the prompt, and so does
+running without a terminal (scripts, pipes).
+
+With --json, the result (MR, branch, worker, new status, issue and where
+the worker was notified) is printed as JSON for scripts.
+
 Examples:
   gt mq reject greenplace polecat/Nux/gp-xyz --reason "Does not meet requirements"
-  gt mq reject greenplace mr-Nux-12345 --reason "Superseded by other work" --notify`,
+  gt mq reject greenplace mr-Nux-12345 --reason "Superseded by other work" --category superseded --notify
+  gt mq reject greenplace gp-mr-abc --reason "Split into smaller MRs" --supersede gp-mr-def
+  gt mq reject greenplace gp-mr-abc --reason "Reverted: broke the release build" --force
+  gt mq reject greenplace gp-mr-abc --reason "Obsolete" --yes`,
 	Args: cobra.ExactArgs(2),
 	RunE: runMQReject,
 }
@@ -163,6 +399,32 @@ var mqStatusCmd = &cobra.Command{
 Shows all MR fields, current status with timestamps, dependencies,
 blockers, and processing history.
 
+For open MRs, a Readiness checklist shows each condition the refinery
+checks before merging (open, not a draft, not in progress, no failure
+pending, unblocked, queue running, target unprotected) and whether it is
+met, so you can see every reason an MR isn't merging, not just the first.
+
+A Signature section shows whether the branch's head commit is signed by a
+trusted key. If the rig sets merge_queue.signature_policy, the refinery
+only merges signed MRs: "block" leaves an unsigned MR queued until a
+signed commit is pushed (and adds a "signed" readiness check), "reject"
+fails it. merge_queue.trusted_signing_keys lists the accepted GPG long
+key IDs (16 hex digits) or fingerprints and SSH key fingerprints
+(SHA256:...); shorter key IDs are ignored. If empty, any
+signature git fully trusts is accepted. Verification uses the refinery
+clone's git config (gpg keyring, gpg.ssh.allowedSignersFile).
+
+If the rig's config.json has a "hosting" section, a Pull Request section
+shows the branch's live PR from GitHub or GitLab: its state, CI status,
+approvals and whether the provider considers it mergeable. The API token
+is read from the environment variable named by hosting.token_env
+(default $GITHUB_TOKEN or $GITLAB_TOKEN), never from the config file:
+
+  "hosting": {"provider": "github", "repo": "acme/widgets"}
+
+Without a hosting section only the local record is shown. If the provider
+can't be reached, the local record is still shown, with the reason.
+
 Example:
   gt mq status gp-mr-abc123`,
 	Args: cobra.ExactArgs(1),
@@ -258,22 +520,56 @@ func init() {
 	mqSubmitCmd.Flags().StringVar(&mqSubmitBranch, "branch", "", "Source branch (default: current branch)")
 	mqSubmitCmd.Flags().StringVar(&mqSubmitIssue, "issue", "", "Source issue ID (default: parse from branch name)")
 	mqSubmitCmd.Flags().StringVar(&mqSubmitEpic, "epic", "", "Target epic's integration branch instead of main")
+	mqSubmitCmd.Flags().StringVar(&mqSubmitTarget, "target", "", "Target branch (e.g., integration/<epic>; default: auto-detect)")
+	mqSubmitCmd.Flags().StringVar(&mqSubmitWorker, "worker", "", "Submit the branch of this worker's worktree (requires rig)")
 	mqSubmitCmd.Flags().IntVarP(&mqSubmitPriority, "priority", "p", -1, "Override priority (0-4, default: inherit from issue)")
 	mqSubmitCmd.Flags().BoolVar(&mqSubmitNoCleanup, "no-cleanup", false, "Don't auto-cleanup after submit (for polecats)")
+	mqSubmitCmd.Flags().StringArrayVar(&mqSubmitLabels, "label", nil, "Label to attach to the MR, e.g. team:payments (repeatable)")
 
 	// Retry flags
-	mqRetryCmd.Flags().BoolVar(&mqRetryNow, "now", false, "Immediately process instead of waiting for refinery loop")
+	mqRetryCmd.Flags().BoolVar(&mqRetryNow, "now", false, "With --until-success, merge even if the rig's merge window is closed")
+	mqRetryCmd.Flags().BoolVar(&mqRetryDeprioritize, "deprioritize", false, "Drop the MR one priority level instead of keeping its place")
+	mqRetryCmd.Flags().BoolVar(&mqRetryUntilSuccess, "until-success", false, "Merge now, retrying until it succeeds or attempts run out")
+	mqRetryCmd.Flags().IntVar(&mqRetryMaxAttempts, "max-attempts", 3, "Maximum attempts with --until-success")
+	mqRetryCmd.Flags().DurationVar(&mqRetryInterval, "interval", time.Minute, "Wait between attempts with --until-success")
+	mqRetryCmd.Flags().BoolVar(&mqRetryForce, "force", false, "Retry even if the MR's worker no longer exists")
+	mqRetryCmd.Flags().BoolVar(&mqRetryRecreate, "recreate", false, "Recreate the MR's polecat if it no longer exists")
+	mqRetryCmd.Flags().BoolVar(&mqRetryJSON, "json", false, "Output the result as JSON")
+	mqRetryCmd.Flags().StringVar(&mqRetryEpic, "epic", "", "With --all-failed, only MRs targeting integration/<epic>")
+	mqRetryCmd.Flags().BoolVar(&mqRetryAllFailed, "all-failed", false, "Retry every failed MR targeting --epic")
+	mqRetryCmd.Flags().BoolVar(&mqRetryRevalidate, "revalidate", false, "Re-run the pre-merge gates on the branch's current head first; refuse the retry if one fails")
+	mqRetryCmd.Flags().BoolVar(&mqRetryDryRun, "dry-run", false, "Show what the retry would do without retrying")
+	mqRetryCmd.Flags().BoolVarP(&mqRetryYes, "yes", "y", false, "Don't ask for confirmation before --all-failed")
 
 	// List flags
 	mqListCmd.Flags().BoolVar(&mqListReady, "ready", false, "Show only ready-to-merge (no blockers)")
 	mqListCmd.Flags().StringVar(&mqListStatus, "status", "", "Filter by status (open, in_progress, closed)")
-	mqListCmd.Flags().StringVar(&mqListWorker, "worker", "", "Filter by worker name")
-	mqListCmd.Flags().StringVar(&mqListEpic, "epic", "", "Show MRs targeting integration/<epic>")
+	mqListCmd.Flags().StringVar(&mqListWorker, "worker", "", "Filter by worker name (glob, e.g. 'F*')")
+	mqListCmd.Flags().BoolVar(&mqListMe, "me", false, "Filter to the current user's worker ($GT_CREW/$GT_POLECAT, config operators mapping, or $USER)")
+	mqListCmd.Flags().StringVar(&mqListEpic, "epic", "", "Show MRs targeting integration/<epic> (glob, e.g. 'release-*')")
 	mqListCmd.Flags().BoolVar(&mqListJSON, "json", false, "Output as JSON")
+	mqListCmd.Flags().StringVar(&mqListFormat, "format", "", "Output format: plain (default), table (with borders), json (same as --json) or ndjson (one MR per line)")
+	mqListCmd.Flags().BoolVar(&mqListNoHeader, "no-header", false, "Print only data rows (no title, column header or separator)")
+	mqListCmd.Flags().BoolVar(&mqListHasNotes, "has-notes", false, "Show only MRs with notes (gt mq note)")
+	mqListCmd.Flags().BoolVar(&mqListClaimed, "claimed", false, "Show only MRs someone is handling (assigned or claimed)")
+	mqListCmd.Flags().BoolVar(&mqListUnclaimed, "unclaimed", false, "Show only MRs nobody is handling")
+	mqListCmd.Flags().StringVar(&mqListUpdatedSince, "updated-since", "", "Show only MRs updated within a duration (e.g. 1h, 2d)")
+	mqListCmd.Flags().StringVar(&mqListBlockedBy, "blocked-by", "", "Show only MRs blocked by this MR (what merging it unblocks)")
+	mqListCmd.Flags().BoolVar(&mqListWide, "wide", false, "Show extra columns (ATTEMPTS: merge attempts so far)")
+	mqListCmd.Flags().IntVar(&mqListIDWidth, "id-width", 0, "Truncate IDs to this many characters; 0 never truncates (default from merge_queue.list_id_width, else 12)")
+	mqListCmd.Flags().IntVar(&mqListBranchWidth, "branch-width", 0, "Truncate branches to this many characters; 0 never truncates (default from merge_queue.list_branch_width, else 24)")
+	mqListCmd.Flags().IntVarP(&mqListLimit, "limit", "n", 0, "Show at most this many MRs, highest score first (0 for all)")
+	mqListCmd.Flags().StringArrayVar(&mqListLabels, "label", nil, "Show only MRs with this label (repeatable; all must match)")
+	mqListCmd.Flags().BoolVar(&mqListAllRigs, "all-rigs", false, "List every rig's queue, with a RIG column (no rig argument)")
 
 	// Reject flags
 	mqRejectCmd.Flags().StringVarP(&mqRejectReason, "reason", "r", "", "Reason for rejection (required)")
+	mqRejectCmd.Flags().StringVar(&mqRejectCategory, "category", "", "Rejection category: "+strings.Join(refinery.RejectCategories, ", "))
 	mqRejectCmd.Flags().BoolVar(&mqRejectNotify, "notify", false, "Send mail notification to worker")
+	mqRejectCmd.Flags().BoolVar(&mqRejectJSON, "json", false, "Output the result as JSON")
+	mqRejectCmd.Flags().StringVar(&mqRejectSupersede, "supersede", "", "ID of the MR replacing this one (category defaults to superseded)")
+	mqRejectCmd.Flags().BoolVar(&mqRejectForce, "force", false, "Reject even an MR that has already merged or closed, overwriting its record")
+	mqRejectCmd.Flags().BoolVarP(&mqRejectYes, "yes", "y", false, "Don't ask for confirmation")
 	_ = mqRejectCmd.MarkFlagRequired("reason") // cobra flags: error only at runtime if missing
 
 	// Status flags
@@ -304,6 +600,18 @@ func init() {
 	rootCmd.AddCommand(mqCmd)
 }
 
+// beadsQueryError wraps a failed beads query with context. If beads itself is
+// unavailable (bd missing, no database, or a database that cannot be opened),
+// the error tells the user how to check their beads setup instead of
+// surfacing only the low-level bd failure.
+func beadsQueryError(action string, err error, beadsPath string) error {
+	if beads.IsUnavailable(err) {
+		return fmt.Errorf("%s: beads is unavailable at %s: %w\n"+
+			"  Check the beads setup: run 'bd doctor' in that directory, or 'gt doctor'", action, beadsPath, err)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
 // findCurrentRig determines the current rig from the working directory.
 // Returns the rig name and rig object, or an error if not in a rig.
 func findCurrentRig(townRoot string) (string, *rig.Rig, error) {
@@ -343,45 +651,165 @@ func findCurrentRig(townRoot string) (string, *rig.Rig, error) {
 	return rigName, r, nil
 }
 
+// MQActionResult is the outcome of a mutating mq command, for --json output.
+type MQActionResult struct {
+	Action   string `json:"action"`
+	MRID     string `json:"mr_id"`
+	Branch   string `json:"branch"`
+	Worker   string `json:"worker"`
+	Status   string `json:"status"`
+	IssueID  string `json:"issue_id,omitempty"`
+	Priority int    `json:"priority"`
+
+	Reason       string `json:"reason,omitempty"`
+	Category     string `json:"category,omitempty"`
+	SupersededBy string `json:"superseded_by,omitempty"`
+
+	// NotifiedVia is the channel the worker was notified on ("mail"), and
+	// ThreadID the mail thread, if the worker was notified.
+	NotifiedVia string `json:"notified_via,omitempty"`
+	ThreadID    string `json:"thread_id,omitempty"`
+
+	// EligibleAt is when a retried MR can next merge, if not on the next
+	// refinery cycle (the rig's merge window is closed).
+	EligibleAt *time.Time `json:"eligible_at,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 func runMQRetry(cmd *cobra.Command, args []string) error {
 	rigName := args[0]
+
+	if mqRetryAllFailed || mqRetryEpic != "" {
+		switch {
+		case len(args) > 1:
+			return fmt.Errorf("--all-failed retries by epic; don't also give an MR ID")
+		case !mqRetryAllFailed || mqRetryEpic == "":
+			return fmt.Errorf("--epic and --all-failed must be used together")
+		case mqRetryUntilSuccess:
+			return fmt.Errorf("--until-success cannot be combined with --all-failed")
+		case mqRetryDryRun:
+			return fmt.Errorf("--dry-run previews one MR; it cannot be combined with --all-failed")
+		}
+		return runMQRetryEpic(rigName, mqRetryEpic)
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("requires an MR ID (or --epic with --all-failed)")
+	}
 	mrID := args[1]
 
-	mgr, _, _, err := getRefineryManager(rigName)
+	mgr, r, _, err := getRefineryManager(rigName)
 	if err != nil {
 		return err
 	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	if mqRetryDryRun {
+		if mqRetryUntilSuccess || mqRetryRevalidate {
+			return fmt.Errorf("--dry-run cannot be combined with --until-success or --revalidate")
+		}
+		return runMQRetryDryRun(mgr, r, mrID)
+	}
+	if mqRetryUntilSuccess {
+		if mqRetryJSON {
+			return fmt.Errorf("--json cannot be combined with --until-success")
+		}
+		if mqRetryRevalidate {
+			return fmt.Errorf("--revalidate cannot be combined with --until-success; every merge attempt runs the gates")
+		}
+		return runMQRetryUntilSuccess(r, mrID)
+	}
+	if mqRetryJSON {
+		// Keep processing logs off stdout so it carries only the JSON.
+		mgr.SetOutput(os.Stderr)
+	}
 
 	// Get the MR first to show info
 	mr, err := mgr.GetMR(mrID)
 	if err != nil {
-		if err == refinery.ErrMRNotFound {
-			return fmt.Errorf("merge request '%s' not found in rig '%s'", mrID, rigName)
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", err, mrID, rigName)
 		}
 		return fmt.Errorf("getting merge request: %w", err)
 	}
 
-	// Show what we're retrying
-	fmt.Printf("Retrying merge request: %s\n", mrID)
-	fmt.Printf("  Branch: %s\n", mr.Branch)
-	fmt.Printf("  Worker: %s\n", mr.Worker)
-	if mr.Error != "" {
-		fmt.Printf("  Previous error: %s\n", style.Dim.Render(mr.Error))
+	workerNote, err := checkRetryWorker(r, mr.Worker, mr.Branch)
+	if err != nil {
+		return err
+	}
+
+	if mqRetryRevalidate {
+		if !mqRetryJSON {
+			fmt.Printf("Revalidating %s (%s)...\n", mrID, mr.Branch)
+		}
+		if err := revalidateMR(r, mr, mqRetryJSON); err != nil {
+			return err
+		}
+	}
+
+	if !mqRetryJSON {
+		if workerNote != "" {
+			style.PrintWarning("%s", workerNote)
+		}
+
+		// Show what we're retrying
+		fmt.Printf("Retrying merge request: %s\n", mrID)
+		fmt.Printf("  Branch: %s\n", mr.Branch)
+		fmt.Printf("  Worker: %s\n", mr.Worker)
+		if mr.Error != "" {
+			fmt.Printf("  Previous error: %s\n", style.Dim.Render(mr.Error))
+		}
 	}
 
 	// Perform the retry
-	if err := mgr.Retry(mrID, mqRetryNow); err != nil {
-		if err == refinery.ErrMRNotFailed {
+	eligible, err := mgr.Retry(mrID, refinery.RetryOptions{ProcessNow: mqRetryNow, Deprioritize: mqRetryDeprioritize})
+	if err != nil {
+		if errors.Is(err, refinery.ErrMRNotFailed) {
 			return fmt.Errorf("merge request '%s' has not failed (status: %s)", mrID, mr.Status)
 		}
+		if errors.Is(err, refinery.ErrMRClosed) {
+			return fmt.Errorf("%w: '%s' (%s); submit a new MR instead", refinery.ErrMRClosed, mrID, mr.CloseReason)
+		}
+		if errors.Is(err, refinery.ErrRigPaused) {
+			return fmt.Errorf("cannot retry while %w; unpark the rig first", err)
+		}
 		return fmt.Errorf("retrying merge request: %w", err)
 	}
 
+	updated, err := mgr.GetMR(mrID)
+	if err != nil {
+		updated = mr
+	}
+
+	if mqRetryJSON {
+		result := MQActionResult{
+			Action:   "retry",
+			MRID:     mrID,
+			Branch:   updated.Branch,
+			Worker:   updated.Worker,
+			Status:   string(updated.Status),
+			IssueID:  updated.IssueID,
+			Priority: updated.Priority,
+		}
+		if !eligible.IsZero() {
+			result.EligibleAt = &eligible
+		}
+		if workerNote != "" {
+			result.Warnings = []string{workerNote}
+		}
+		return outputJSON(result)
+	}
+
+	if updated.Priority != mr.Priority {
+		fmt.Printf("  Priority: P%d -> P%d\n", mr.Priority, updated.Priority)
+	}
 	if mqRetryNow {
 		fmt.Printf("%s Merge request processed\n", style.Bold.Render("✓"))
 	} else {
 		fmt.Printf("%s Merge request queued for retry\n", style.Bold.Render("✓"))
-		fmt.Printf("  %s\n", style.Dim.Render("Will be processed on next refinery cycle"))
+		fmt.Printf("  %s\n", style.Dim.Render(retryEligibleNote(eligible, time.Now())))
 	}
 
 	return nil
@@ -395,23 +823,127 @@ func runMQReject(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if mrIDOrBranch, err = mgr.ResolveMRID(mrIDOrBranch); err != nil {
+		return err
+	}
+	if mqRejectJSON {
+		mgr.SetOutput(os.Stderr)
+	}
+
+	if !confirmDestructive("Rejecting a merge request", rejectChanges(mgr, rigName, mrIDOrBranch), mqRejectYes) {
+		return errCanceled()
+	}
 
-	result, err := mgr.RejectMR(mrIDOrBranch, mqRejectReason, mqRejectNotify)
+	rejected, err := mgr.RejectMR(mrIDOrBranch, refinery.RejectOptions{
+		Reason:   mqRejectReason,
+		Category: mqRejectCategory,
+		Notify:   mqRejectNotify,
+
+		SupersededBy: mqRejectSupersede,
+
+		Force: mqRejectForce,
+		Actor: detectSender(),
+	})
 	if err != nil {
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", err, mrIDOrBranch, rigName)
+		}
+		if errors.Is(err, refinery.ErrMRAlreadyClosed) {
+			return fmt.Errorf("cannot reject: %w; nothing to do (--force overwrites the record)", err)
+		}
+		if errors.Is(err, refinery.ErrSupersederNotFound) {
+			return fmt.Errorf("cannot reject: %w in rig '%s'", err, rigName)
+		}
 		return fmt.Errorf("rejecting MR: %w", err)
 	}
+	result := rejected.MR
+	category := strings.ToLower(mqRejectCategory)
+	if category == "" && mqRejectSupersede != "" {
+		category = "superseded"
+	}
+	var warnings []string
+	if rejected.Overridden != "" {
+		warnings = append(warnings, "forced past state check: "+rejected.Overridden)
+		fmt.Fprintf(os.Stderr, "%s FORCED: %s; the rejection overwrites its record\n", style.Warning.Render("⚠"), rejected.Overridden)
+	}
+
+	if mqRejectJSON {
+		out := MQActionResult{
+			Action:   "reject",
+			MRID:     result.ID,
+			Branch:   result.Branch,
+			Worker:   result.Worker,
+			Status:   string(result.Status),
+			IssueID:  result.IssueID,
+			Priority: result.Priority,
+			Reason:   mqRejectReason,
+			Category: category,
+			ThreadID: rejected.ThreadID,
+
+			SupersededBy: mqRejectSupersede,
+			Warnings:     warnings,
+		}
+		if rejected.ThreadID != "" {
+			out.NotifiedVia = "mail"
+		}
+		return outputJSON(out)
+	}
 
 	fmt.Printf("%s Rejected: %s\n", style.Bold.Render("✗"), result.Branch)
 	fmt.Printf("  Worker: %s\n", result.Worker)
 	fmt.Printf("  Reason: %s\n", mqRejectReason)
+	if category != "" {
+		fmt.Printf("  Category: %s\n", category)
+	}
+	if mqRejectSupersede != "" {
+		fmt.Printf("  Superseded by: %s\n", mqRejectSupersede)
+	}
+	if rejected.Overridden != "" {
+		fmt.Printf("  Forced by: %s\n", detectSender())
+	}
 
 	if result.IssueID != "" {
 		fmt.Printf("  Issue:  %s %s\n", result.IssueID, style.Dim.Render("(not closed - work not done)"))
 	}
 
-	if mqRejectNotify {
-		fmt.Printf("  %s\n", style.Dim.Render("Worker notified via mail"))
+	if rejected.ThreadID != "" {
+		fmt.Printf("  %s\n", style.Dim.Render("Worker notified via mail (thread: "+rejected.ThreadID+")"))
 	}
 
 	return nil
 }
+
+// rejectChanges describes what 'gt mq reject' is about to do, for the
+// confirmation prompt. An MR that can't be found (e.g. already closed) is
+// shown as given; RejectMR reports why it can't be rejected.
+func rejectChanges(mgr *refinery.Manager, rigName, idOrBranch string) []string {
+	changes := []string{fmt.Sprintf("MR:     %s (rig %s)", idOrBranch, rigName)}
+	if mr, err := mgr.FindMR(idOrBranch); err == nil {
+		changes[0] = fmt.Sprintf("MR:     %s %s (rig %s)", mr.ID, mr.Branch, rigName)
+		changes = append(changes, fmt.Sprintf("Worker: %s", mr.Worker))
+	}
+	changes = append(changes, "Status: -> rejected (closed without merging)", "Reason: "+mqRejectReason)
+	if mqRejectCategory != "" {
+		changes = append(changes, "Category: "+strings.ToLower(mqRejectCategory))
+	}
+	if mqRejectSupersede != "" {
+		changes = append(changes, "Superseded by: "+mqRejectSupersede)
+	}
+	if mqRejectNotify {
+		changes = append(changes, "Worker will be mailed the reason")
+	}
+	if mqRejectForce {
+		changes = append(changes, "Forced: an already merged or closed MR's record is overwritten")
+	}
+	return changes
+}
+
+// retryEligibleNote tells when a retried MR will be merged, given the next
+// eligible time from Manager.Retry (zero for the next refinery cycle).
+func retryEligibleNote(eligible, now time.Time) string {
+	if eligible.IsZero() {
+		return "Will be processed on next refinery cycle"
+	}
+	return fmt.Sprintf("Eligible %s (in %s), when the merge window opens",
+		eligible.Format("Mon 15:04"), formatDuration(eligible.Sub(now)))
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ check command flags
+var (
+	mqCheckJSON    bool
+	mqCheckVerbose bool
+)
+
+var mqCheckCmd = &cobra.Command{
+	Use:   "check <rig> <mr-id>",
+	Short: "Check whether an MR would merge cleanly, without merging",
+	Long: `Trial-merge one MR's branch into its target and report the outcome.
+
+The merge is tried in a throwaway worktree of the rig's repo, detached at
+origin's tip of the target, and the worktree is removed afterwards:
+nothing is committed, pushed or claimed, and the refinery's checkout is
+left alone, so it is safe to run while the refinery is working.
+
+It answers "will this actually merge?" for a single MR: either it would
+merge cleanly (or is already merged), or the conflicting files are listed.
+For the whole queue's schedule see 'gt mq plan'; tests are not run (see
+'gt mq retry --revalidate').
+
+Exits 1 if the MR would conflict.
+
+Examples:
+  gt mq check gastown gt-mr-abc
+  gt mq check gastown gt-mr-abc --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQCheck,
+}
+
+func init() {
+	mqCheckCmd.Flags().BoolVar(&mqCheckJSON, "json", false, "Output as JSON")
+	mqCheckCmd.Flags().BoolVarP(&mqCheckVerbose, "verbose", "v", false, "Show the git commands of the trial merge")
+
+	mqCmd.AddCommand(mqCheckCmd)
+}
+
+// MQCheckResult is the JSON output of gt mq check.
+type MQCheckResult struct {
+	MRID string `json:"mr_id"`
+	*refinery.MergeCheck
+}
+
+func runMQCheck(cmd *cobra.Command, args []string) error {
+	mrID := args[1]
+	mgr, r, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	mr, _, err := mgr.ShowMR(mrID)
+	if err != nil {
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", refinery.ErrMRNotFound, mrID, rigName)
+		}
+		return err
+	}
+	if mr.IsClosed() {
+		return fmt.Errorf("%w: '%s' (%s); nothing to check", refinery.ErrMRClosed, mrID, mr.CloseReason)
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		style.PrintWarning("could not load merge queue config, using defaults: %v", err)
+	}
+	var log bytes.Buffer
+	eng.SetOutput(&log)
+
+	var check *refinery.MergeCheck
+	err = runInterruptible(func(ctx context.Context) error {
+		check, err = eng.CheckMerge(ctx, mr.Branch, mr.TargetBranch, &log)
+		return err
+	}, nil)
+	if mqCheckVerbose && !mqCheckJSON {
+		_, _ = os.Stdout.Write(log.Bytes())
+	}
+	var conflict *git.MergeConflictError
+	if err != nil && !errors.As(err, &conflict) {
+		return fmt.Errorf("checking %s: %w", mrID, err)
+	}
+
+	if mqCheckJSON {
+		if err := outputJSON(MQCheckResult{MRID: mrID, MergeCheck: check}); err != nil {
+			return err
+		}
+	} else {
+		target := fmt.Sprintf("%s (at %s)", check.Target, shortSHA(check.TargetHead))
+		switch {
+		case check.AlreadyMerged:
+			fmt.Printf("%s %s is already merged into %s\n", style.Dim.Render("○"), mrID, target)
+		case check.Mergeable:
+			fmt.Printf("%s %s would merge cleanly into %s\n", style.Success.Render("✓"), mrID, target)
+		default:
+			fmt.Printf("%s %s would conflict with %s in:\n", style.Error.Render("✗"), mrID, target)
+			for _, f := range check.Conflicts {
+				fmt.Printf("    %s\n", f)
+			}
+		}
+	}
+	if conflict != nil {
+		return NewSilentExit(1)
+	}
+	return nil
+}
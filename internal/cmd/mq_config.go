@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ config command flags
+var (
+	mqConfigJSON bool
+)
+
+var mqConfigCmd = &cobra.Command{
+	Use:   "config <rig>",
+	Short: "Show the refinery's effective merge queue config",
+	Long: `Show the merge queue configuration the refinery actually uses for a rig.
+
+Reads the merge_queue section of the rig's config.json exactly as the
+refinery does and fills in the defaults for anything not set, so what you
+see is what is in effect. Values left at their default are marked.
+
+Examples:
+  gt mq config gastown
+  gt mq config gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQConfig,
+}
+
+func init() {
+	mqConfigCmd.Flags().BoolVar(&mqConfigJSON, "json", false, "Output as JSON")
+
+	mqCmd.AddCommand(mqConfigCmd)
+}
+
+// MQConfigOutput is the effective merge queue config, with durations as
+// strings (e.g. "30s") for tooling.
+type MQConfigOutput struct {
+	Rig                  string   `json:"rig"`
+	Enabled              bool     `json:"enabled"`
+	TargetBranch         string   `json:"target_branch"`
+	IntegrationBranches  bool     `json:"integration_branches"`
+	OnConflict           string   `json:"on_conflict"`
+	RunTests             bool     `json:"run_tests"`
+	TestCommand          string   `json:"test_command"`
+	DeleteMergedBranches bool     `json:"delete_merged_branches"`
+	RetryFlakyTests      int      `json:"retry_flaky_tests"`
+	PollInterval         string   `json:"poll_interval"`
+	LoopInterval         string   `json:"loop_interval"` // Effective wait between 'gt mq process --loop' cycles
+	MaxConcurrent        int      `json:"max_concurrent"`
+	BatchSize            int      `json:"batch_size"`
+	MergeWindow          []string `json:"merge_window"`
+	HotBranches          []string `json:"hot_branches"`
+	NotifyOnMerge        bool     `json:"notify_on_merge"`
+	ListIDWidth          int      `json:"list_id_width"`
+	ListBranchWidth      int      `json:"list_branch_width"`
+	MRIDPrefix           string   `json:"mr_id_prefix"`
+	MergeMessageTemplate string   `json:"merge_message_template"`
+	SignaturePolicy      string   `json:"signature_policy"`
+	TrustedSigningKeys   []string `json:"trusted_signing_keys"`
+	IsolateMerges        bool     `json:"isolate_merges"`
+	MRTimeout            string   `json:"mr_timeout"`
+	MergedIssueStatus    string   `json:"merged_issue_status"`
+	MergedIssueLabels    []string `json:"merged_issue_labels"`
+	PushRetries          int      `json:"push_retries"`
+}
+
+// newMQConfigOutput flattens a merge queue config for display.
+func newMQConfigOutput(rigName string, c *refinery.MergeQueueConfig) MQConfigOutput {
+	return MQConfigOutput{
+		Rig:                  rigName,
+		Enabled:              c.Enabled,
+		TargetBranch:         c.TargetBranch,
+		IntegrationBranches:  c.IntegrationBranches,
+		OnConflict:           c.OnConflict,
+		RunTests:             c.RunTests,
+		TestCommand:          c.TestCommand,
+		DeleteMergedBranches: c.DeleteMergedBranches,
+		RetryFlakyTests:      c.RetryFlakyTests,
+		PollInterval:         c.PollInterval.String(),
+		LoopInterval:         c.EffectiveLoopInterval().String(),
+		MaxConcurrent:        c.MaxConcurrent,
+		BatchSize:            c.BatchSize,
+		MergeWindow:          c.MergeWindow.Strings(),
+		HotBranches:          append([]string{}, c.HotBranches...),
+		NotifyOnMerge:        c.NotifyOnMerge,
+		ListIDWidth:          c.ListIDWidth,
+		ListBranchWidth:      c.ListBranchWidth,
+		MRIDPrefix:           c.MRIDPrefix,
+		MergeMessageTemplate: c.MergeMessageTemplate,
+		SignaturePolicy:      c.SignaturePolicy,
+		TrustedSigningKeys:   append([]string{}, c.TrustedSigningKeys...),
+		IsolateMerges:        c.IsolateMerges,
+		MRTimeout:            c.MRTimeout.String(),
+		MergedIssueStatus:    c.MergedIssueStatus,
+		MergedIssueLabels:    append([]string{}, c.MergedIssueLabels...),
+		PushRetries:          c.PushRetries,
+	}
+}
+
+func runMQConfig(cmd *cobra.Command, args []string) error {
+	r, err := getRigArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	defaults := newMQConfigOutput(r.Name, eng.Config())
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config for rig '%s': %w", r.Name, err)
+	}
+	out := newMQConfigOutput(r.Name, eng.Config())
+
+	if mqConfigJSON {
+		return outputJSON(out)
+	}
+
+	fmt.Printf("%s Merge queue config for '%s':\n\n", style.Bold.Render("⚙"), r.Name)
+	rows := []struct {
+		key, value, def string
+	}{
+		{"enabled", strconv.FormatBool(out.Enabled), strconv.FormatBool(defaults.Enabled)},
+		{"target_branch", out.TargetBranch, defaults.TargetBranch},
+		{"integration_branches", strconv.FormatBool(out.IntegrationBranches), strconv.FormatBool(defaults.IntegrationBranches)},
+		{"on_conflict", out.OnConflict, defaults.OnConflict},
+		{"run_tests", strconv.FormatBool(out.RunTests), strconv.FormatBool(defaults.RunTests)},
+		{"test_command", out.TestCommand, defaults.TestCommand},
+		{"delete_merged_branches", strconv.FormatBool(out.DeleteMergedBranches), strconv.FormatBool(defaults.DeleteMergedBranches)},
+		{"retry_flaky_tests", strconv.Itoa(out.RetryFlakyTests), strconv.Itoa(defaults.RetryFlakyTests)},
+		{"poll_interval", out.PollInterval, defaults.PollInterval},
+		{"loop_interval", out.LoopInterval, defaults.LoopInterval},
+		{"max_concurrent", strconv.Itoa(out.MaxConcurrent), strconv.Itoa(defaults.MaxConcurrent)},
+		{"batch_size", strconv.Itoa(out.BatchSize), strconv.Itoa(defaults.BatchSize)},
+		{"merge_window", strings.Join(out.MergeWindow, "; "), strings.Join(defaults.MergeWindow, "; ")},
+		{"hot_branches", strings.Join(out.HotBranches, ", "), strings.Join(defaults.HotBranches, ", ")},
+		{"notify_on_merge", strconv.FormatBool(out.NotifyOnMerge), strconv.FormatBool(defaults.NotifyOnMerge)},
+		{"list_id_width", strconv.Itoa(out.ListIDWidth), strconv.Itoa(defaults.ListIDWidth)},
+		{"list_branch_width", strconv.Itoa(out.ListBranchWidth), strconv.Itoa(defaults.ListBranchWidth)},
+		{"mr_id_prefix", out.MRIDPrefix, defaults.MRIDPrefix},
+		{"merge_message_template", out.MergeMessageTemplate, defaults.MergeMessageTemplate},
+		{"signature_policy", out.SignaturePolicy, defaults.SignaturePolicy},
+		{"trusted_signing_keys", strings.Join(out.TrustedSigningKeys, ", "), strings.Join(defaults.TrustedSigningKeys, ", ")},
+		{"isolate_merges", strconv.FormatBool(out.IsolateMerges), strconv.FormatBool(defaults.IsolateMerges)},
+		{"mr_timeout", out.MRTimeout, defaults.MRTimeout},
+		{"merged_issue_status", out.MergedIssueStatus, defaults.MergedIssueStatus},
+		{"merged_issue_labels", strings.Join(out.MergedIssueLabels, ", "), strings.Join(defaults.MergedIssueLabels, ", ")},
+		{"push_retries", strconv.Itoa(out.PushRetries), strconv.Itoa(defaults.PushRetries)},
+	}
+	for _, row := range rows {
+		value := row.value
+		if value == "" {
+			value = style.Dim.Render("(none)")
+		}
+		if row.value == row.def {
+			value += " " + style.Dim.Render("(default)")
+		}
+		fmt.Printf("  %-23s %s\n", row.key+":", value)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqDraftCmd = &cobra.Command{
+	Use:   "draft <rig> <mr-id>",
+	Short: "Mark a merge request as a draft",
+	Long: `Mark a merge request as a draft (work in progress).
+
+A draft MR stays in the queue but the refinery skips it, even if it is
+otherwise ready to merge. Use this to stage work in the queue before it
+is finished. 'gt mq list' shows drafts as "draft", and they are left out
+of 'gt mq list --ready'.
+
+Mark it ready again with 'gt mq ready'.
+
+Example:
+  gt mq draft greenplace gp-mr-abc123`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQDraft,
+}
+
+var mqReadyCmd = &cobra.Command{
+	Use:   "ready <rig> <mr-id>",
+	Short: "Mark a draft merge request as ready to merge",
+	Long: `Mark a draft merge request as ready to merge.
+
+Clears the draft flag set by 'gt mq draft', so the refinery merges the MR
+on a coming cycle once it is otherwise ready.
+
+Example:
+  gt mq ready greenplace gp-mr-abc123`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQReady,
+}
+
+func init() {
+	mqCmd.AddCommand(mqDraftCmd)
+	mqCmd.AddCommand(mqReadyCmd)
+}
+
+func runMQDraft(cmd *cobra.Command, args []string) error {
+	return setMQDraft(args[0], args[1], true)
+}
+
+func runMQReady(cmd *cobra.Command, args []string) error {
+	return setMQDraft(args[0], args[1], false)
+}
+
+// setMQDraft sets or clears an MR's draft flag and reports the result.
+func setMQDraft(rigName, mrID string, draft bool) error {
+	mgr, _, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	changed, err := mgr.SetDraft(mrID, draft)
+	if err != nil {
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", refinery.ErrMRNotFound, mrID, rigName)
+		}
+		if errors.Is(err, refinery.ErrMRAlreadyClosed) {
+			return fmt.Errorf("cannot change draft state: %w", err)
+		}
+		return err
+	}
+
+	switch {
+	case !changed && draft:
+		fmt.Printf("%s %s is already a draft\n", style.Dim.Render("○"), mrID)
+	case !changed:
+		fmt.Printf("%s %s is not a draft\n", style.Dim.Render("○"), mrID)
+	case draft:
+		fmt.Printf("%s Marked %s as a draft\n", style.Bold.Render("✓"), mrID)
+		fmt.Printf("  %s\n", style.Dim.Render("The refinery will skip it until 'gt mq ready'"))
+	default:
+		fmt.Printf("%s Marked %s as ready to merge\n", style.Bold.Render("✓"), mrID)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// MQ healthcheck command flags
+var (
+	mqHealthcheckJSON   bool
+	mqHealthcheckMaxAge time.Duration
+)
+
+var mqHealthcheckCmd = &cobra.Command{
+	Use:   "healthcheck <rig>",
+	Short: "Probe merge queue health for monitoring",
+	Long: `Probe the health of a rig's merge queue.
+
+Checks that:
+  - refinery: the refinery session is running
+  - cycle:    the refinery's last cycle did not error
+  - beads:    the beads database responds
+  - remote:   the rig repo's origin remote is reachable
+  - queue:    the oldest ready MR is younger than --max-age (queue not stuck);
+              drafts and held MRs don't count, and neither does anything
+              while the merge window is closed
+
+Prints a one-line status and exits 0 if every check passes, 1 otherwise.
+Use --json for structured probe results.
+
+Examples:
+  gt mq healthcheck gastown
+  gt mq healthcheck gastown --max-age=30m
+  gt mq healthcheck gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQHealthcheck,
+}
+
+func init() {
+	mqHealthcheckCmd.Flags().BoolVar(&mqHealthcheckJSON, "json", false, "Output probe results as JSON")
+	mqHealthcheckCmd.Flags().DurationVar(&mqHealthcheckMaxAge, "max-age", 2*time.Hour, "Oldest allowed ready MR age before the queue counts as stuck")
+
+	mqCmd.AddCommand(mqHealthcheckCmd)
+}
+
+// HealthCheck is the result of a single health probe.
+type HealthCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// HealthReport is the combined result of all merge queue probes.
+type HealthReport struct {
+	Rig     string        `json:"rig"`
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
+func runMQHealthcheck(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	mgr, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	report := HealthReport{Rig: r.Name, Healthy: true}
+	add := func(c HealthCheck) {
+		report.Checks = append(report.Checks, c)
+		if !c.OK {
+			report.Healthy = false
+		}
+	}
+
+	add(checkRefineryHealth(mgr))
+	add(checkRefineryLastError(mgr))
+
+	issues, err := beads.New(r.BeadsPath()).List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "open",
+		Priority: -1,
+	})
+	if err != nil {
+		add(HealthCheck{Name: "beads", OK: false, Detail: err.Error()})
+	} else {
+		add(HealthCheck{Name: "beads", OK: true, Detail: fmt.Sprintf("%d open MR(s)", len(issues))})
+	}
+
+	add(checkRemoteHealth(r))
+
+	if err == nil {
+		holds, _ := mgr.Holds()
+		window := loadMQConfig(r).MergeWindow
+		add(checkQueueAge(issues, holds, window, time.Now(), mqHealthcheckMaxAge))
+	} else {
+		add(HealthCheck{Name: "queue", OK: false, Detail: "unknown (beads unavailable)"})
+	}
+
+	if mqHealthcheckJSON {
+		if err := outputJSON(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println(formatHealthLine(report))
+	}
+
+	if !report.Healthy {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// checkRefineryHealth reports whether the refinery is running, either as its
+// tmux session or as a live foreground process recorded in state.
+func checkRefineryHealth(mgr *refinery.Manager) HealthCheck {
+	if running, err := tmux.NewTmux().HasSession(mgr.SessionName()); err == nil && running {
+		return HealthCheck{Name: "refinery", OK: true, Detail: "session " + mgr.SessionName()}
+	}
+	ref, err := mgr.Status()
+	if err != nil {
+		return HealthCheck{Name: "refinery", OK: false, Detail: err.Error()}
+	}
+	if ref.State == refinery.StateRunning && ref.PID > 0 && util.ProcessExists(ref.PID) {
+		return HealthCheck{Name: "refinery", OK: true, Detail: fmt.Sprintf("pid %d", ref.PID)}
+	}
+	return HealthCheck{Name: "refinery", OK: false, Detail: "not running"}
+}
+
+// checkRefineryLastError fails if the refinery's last cycle errored.
+func checkRefineryLastError(mgr *refinery.Manager) HealthCheck {
+	ref, err := mgr.Status()
+	if err != nil {
+		return HealthCheck{Name: "cycle", OK: false, Detail: err.Error()}
+	}
+	if ref.LastError != "" {
+		return HealthCheck{Name: "cycle", OK: false, Detail: formatRefineryError(ref)}
+	}
+	return HealthCheck{Name: "cycle", OK: true, Detail: "no errors"}
+}
+
+// formatRefineryError describes the refinery's last cycle error and its age.
+func formatRefineryError(ref *refinery.Refinery) string {
+	if ref.LastErrorAt == nil {
+		return ref.LastError
+	}
+	return fmt.Sprintf("%s (%s)", ref.LastError, formatAge(*ref.LastErrorAt))
+}
+
+// remoteHealthTimeout bounds the origin probe, so a hung remote (or ssh
+// waiting on the network) fails the check instead of hanging the monitor.
+var remoteHealthTimeout = 10 * time.Second
+
+// checkRemoteHealth reports whether the rig repo's origin remote answers
+// within remoteHealthTimeout.
+func checkRemoteHealth(r *rig.Rig) HealthCheck {
+	g := rigRepoGit(r)
+	env := map[string]string{"GIT_TERMINAL_PROMPT": "0"}
+	for k, v := range r.GitEnv() {
+		env[k] = v
+	}
+	g.SetEnv(env)
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteHealthTimeout)
+	defer cancel()
+	if err := g.WithContext(ctx).RemoteReachable("origin"); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return HealthCheck{Name: "remote", OK: false, Detail: fmt.Sprintf("origin did not answer within %s", remoteHealthTimeout)}
+		}
+		return HealthCheck{Name: "remote", OK: false, Detail: err.Error()}
+	}
+	return HealthCheck{Name: "remote", OK: true, Detail: "origin reachable"}
+}
+
+// checkQueueAge reports the queue as stuck if its oldest ready MR is older
+// than maxAge. Ready means what the refinery would merge: not blocked, not
+// a draft and not held. While the merge window is closed nothing merges,
+// so the queue can't be stuck.
+func checkQueueAge(issues []*beads.Issue, holds refinery.Holds, window config.MergeWindow, now time.Time, maxAge time.Duration) HealthCheck {
+	if !window.Open(now) {
+		return HealthCheck{Name: "queue", OK: true, Detail: "merge window closed"}
+	}
+
+	var oldest time.Time
+	ready := 0
+	for _, issue := range issues {
+		if len(issue.BlockedBy) > 0 || issue.BlockedByCount > 0 {
+			continue
+		}
+		branch := ""
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			if fields.Draft {
+				continue
+			}
+			branch = fields.Branch
+		}
+		if holds.Match(issue.ID, branch) != "" {
+			continue
+		}
+		ready++
+		created, err := time.Parse(time.RFC3339, issue.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+	}
+
+	if ready == 0 || oldest.IsZero() {
+		return HealthCheck{Name: "queue", OK: true, Detail: fmt.Sprintf("%d ready", ready)}
+	}
+	age := now.Sub(oldest).Round(time.Second)
+	detail := fmt.Sprintf("%d ready, oldest %s", ready, age)
+	if age > maxAge {
+		return HealthCheck{Name: "queue", OK: false, Detail: detail + fmt.Sprintf(" (over %s)", maxAge)}
+	}
+	return HealthCheck{Name: "queue", OK: true, Detail: detail}
+}
+
+// formatHealthLine renders a report as a single status line.
+func formatHealthLine(report HealthReport) string {
+	status := "OK"
+	if !report.Healthy {
+		status = "FAIL"
+	}
+	parts := make([]string, 0, len(report.Checks))
+	for _, c := range report.Checks {
+		mark := "ok"
+		if !c.OK {
+			mark = "FAIL"
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s (%s)", c.Name, mark, c.Detail))
+	}
+	return fmt.Sprintf("%s %s: %s", status, report.Rig, strings.Join(parts, ", "))
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ history command flags
+var (
+	mqHistorySince string
+	mqHistoryJSON  bool
+)
+
+var mqHistoryCmd = &cobra.Command{
+	Use:   "history <rig>",
+	Short: "Show recently merged and rejected MRs",
+	Long: `Show the merge requests completed in a time window, newest first.
+
+Where 'gt mq list' shows what is pending, history shows what happened:
+each MR merged or rejected in the window (--since, default 24h), with its
+outcome, worker, target branch and when it completed. Merged MRs show
+their merge commit; rejected ones the reason.
+
+Completions are read from the refinery's event log (the one 'gt mq tail'
+follows), which records when each merge and rejection happened. MRs
+completed before the log existed are not shown. An MR rejected with
+--force after merging appears twice, once per outcome.
+
+Examples:
+  gt mq history gastown
+  gt mq history gastown --since=7d
+  gt mq history gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQHistory,
+}
+
+func init() {
+	mqHistoryCmd.Flags().StringVar(&mqHistorySince, "since", "24h", "Time window (e.g. 24h, 7d)")
+	mqHistoryCmd.Flags().BoolVar(&mqHistoryJSON, "json", false, "Output as JSON")
+
+	mqCmd.AddCommand(mqHistoryCmd)
+}
+
+// MQHistory is the MRs completed in a window, newest first.
+type MQHistory struct {
+	Rig       string           `json:"rig"`
+	Since     time.Time        `json:"since"`
+	Merged    int              `json:"merged"`
+	Rejected  int              `json:"rejected"`
+	Completed []MQHistoryEntry `json:"items"`
+}
+
+// MQHistoryEntry is one MR completion: a merge or a rejection.
+type MQHistoryEntry struct {
+	CompletedAt time.Time `json:"completed_at"`
+	Outcome     string    `json:"outcome"` // merged or rejected
+	MRID        string    `json:"mr_id"`
+	Branch      string    `json:"branch"`
+	Worker      string    `json:"worker,omitempty"`
+	Target      string    `json:"target"`
+	MergeCommit string    `json:"merge_commit,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+func runMQHistory(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	window, err := parseDuration(mqHistorySince)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --since %q: use a duration like 24h or 7d", mqHistorySince)
+	}
+
+	r, err := getRigArg(rigName)
+	if err != nil {
+		return err
+	}
+	events, _, err := mrqueue.NewEventLoggerFromRig(r.Path).ReadEvents(0)
+	if err != nil {
+		return fmt.Errorf("reading merge queue events: %w", err)
+	}
+
+	now := time.Now()
+	history := computeMQCompletions(events, now.Add(-window), now)
+	history.Rig = rigName
+
+	if mqHistoryJSON {
+		return outputJSON(history)
+	}
+	printMQHistory(history)
+	return nil
+}
+
+// computeMQCompletions collects the merged and rejected events logged
+// between since and now, newest first.
+func computeMQCompletions(events []mrqueue.Event, since, now time.Time) MQHistory {
+	history := MQHistory{Since: since, Completed: []MQHistoryEntry{}}
+	for _, e := range events {
+		if e.Timestamp.Before(since) || e.Timestamp.After(now) {
+			continue
+		}
+		switch e.Type {
+		case mrqueue.EventMerged:
+			history.Merged++
+		case mrqueue.EventRejected:
+			history.Rejected++
+		default:
+			continue
+		}
+		history.Completed = append(history.Completed, MQHistoryEntry{
+			CompletedAt:  e.Timestamp,
+			Outcome:      string(e.Type),
+			MRID:         e.MRID,
+			Branch:       e.Branch,
+			Worker:       e.Worker,
+			Target:       e.Target,
+			MergeCommit:  e.MergeCommit,
+			Reason:       e.Reason,
+			SupersededBy: e.SupersededBy,
+		})
+	}
+	sort.SliceStable(history.Completed, func(i, j int) bool {
+		return history.Completed[i].CompletedAt.After(history.Completed[j].CompletedAt)
+	})
+	return history
+}
+
+func printMQHistory(history MQHistory) {
+	fmt.Printf("%s Completed merge requests for '%s' since %s:\n\n",
+		style.Bold.Render("📜"), history.Rig, history.Since.Format("2006-01-02 15:04"))
+	if len(history.Completed) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		return
+	}
+
+	table := style.NewTable(
+		style.Column{Name: "COMPLETED", Width: 16},
+		style.Column{Name: "OUTCOME", Width: 8},
+		style.Column{Name: "MR", Width: 14},
+		style.Column{Name: "WORKER", Width: 12},
+		style.Column{Name: "TARGET", Width: 24},
+		style.Column{Name: "DETAIL", Width: 48},
+	)
+	for _, c := range history.Completed {
+		outcome := style.Success.Render(c.Outcome)
+		detail := shortSHA(c.MergeCommit)
+		if c.Outcome == string(mrqueue.EventRejected) {
+			outcome = style.Error.Render(c.Outcome)
+			detail = c.Reason
+			if c.SupersededBy != "" {
+				detail += " (superseded by " + c.SupersededBy + ")"
+			}
+		}
+		worker := c.Worker
+		if worker == "" {
+			worker = style.Dim.Render("-")
+		}
+		table.AddRow(c.CompletedAt.Local().Format("2006-01-02 15:04"), outcome, c.MRID, worker, c.Target, detail)
+	}
+	fmt.Print(table.Render())
+	fmt.Printf("\n  %d merged, %d rejected\n", history.Merged, history.Rejected)
+}
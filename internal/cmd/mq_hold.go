@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ hold command flags
+var mqHoldJSON bool
+
+var mqHoldCmd = &cobra.Command{
+	Use:   "hold <rig> [pattern]",
+	Short: "Keep the refinery off matching MRs",
+	Long: `Hold MRs so the refinery skips them, without claiming each one.
+
+A hold is an MR ID or a glob pattern (*, ?, [...]) matched against MR IDs
+and source branches, e.g. "gp-mr-abc123", "polecat/Nux/*" or "gp-mr-*".
+Held MRs stay in the queue, show as "held" in 'gt mq list' and are left
+out of --ready, but are never merged until the hold is removed with
+'gt mq unhold'. Holds are kept in the refinery's state, so they persist
+across restarts and cover MRs submitted later that match.
+
+Use this during an investigation to park a worker's branches or a single
+MR; to stop the whole queue, park the rig instead.
+
+With no pattern, lists the rig's holds and the open MRs each matches now.
+
+Examples:
+  gt mq hold greenplace gp-mr-abc123
+  gt mq hold greenplace 'polecat/Nux/*'
+  gt mq hold greenplace
+  gt mq hold greenplace --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runMQHold,
+}
+
+var mqUnholdCmd = &cobra.Command{
+	Use:   "unhold <rig> <pattern>",
+	Short: "Remove a hold set by 'gt mq hold'",
+	Long: `Remove a hold set by 'gt mq hold'.
+
+The pattern must be given exactly as it was held (see 'gt mq hold <rig>'
+for the list). MRs it matched are merged on a coming cycle once they are
+otherwise ready and no other hold matches them.
+
+Example:
+  gt mq unhold greenplace 'polecat/Nux/*'`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQUnhold,
+}
+
+func init() {
+	mqHoldCmd.Flags().BoolVar(&mqHoldJSON, "json", false, "Output holds as JSON (when listing)")
+
+	mqCmd.AddCommand(mqHoldCmd)
+	mqCmd.AddCommand(mqUnholdCmd)
+}
+
+func runMQHold(cmd *cobra.Command, args []string) error {
+	mgr, _, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		return listMQHolds(mgr, rigName)
+	}
+	if mqHoldJSON {
+		return fmt.Errorf("--json only applies when listing holds")
+	}
+
+	pattern := args[1]
+	added, err := mgr.Hold(pattern)
+	if err != nil {
+		return err
+	}
+	if !added {
+		fmt.Printf("%s %q is already held\n", style.Dim.Render("○"), pattern)
+	} else {
+		fmt.Printf("%s Holding %q in '%s'\n", style.Bold.Render("✓"), pattern, rigName)
+	}
+
+	held, err := mgr.HeldPatterns(refinery.Holds{pattern})
+	if err != nil {
+		style.PrintWarning("could not list the MRs it matches: %v", err)
+		return nil
+	}
+	fmt.Printf("  %s\n", style.Dim.Render(holdMatchesSummary(held[0])))
+	return nil
+}
+
+func runMQUnhold(cmd *cobra.Command, args []string) error {
+	mgr, _, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	pattern := args[1]
+	removed, err := mgr.Unhold(pattern)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%q is not held in rig '%s' (see 'gt mq hold %s')", pattern, rigName, rigName)
+	}
+	fmt.Printf("%s Released %q in '%s'\n", style.Bold.Render("✓"), pattern, rigName)
+	return nil
+}
+
+// listMQHolds prints a rig's holds and what each matches.
+func listMQHolds(mgr *refinery.Manager, rigName string) error {
+	holds, err := mgr.Holds()
+	if err != nil {
+		return fmt.Errorf("reading holds: %w", err)
+	}
+	held, err := mgr.HeldPatterns(holds)
+	if err != nil {
+		return err
+	}
+
+	if mqHoldJSON {
+		return outputJSON(held)
+	}
+	if len(held) == 0 {
+		fmt.Printf("%s No holds in '%s'\n", style.Dim.Render("○"), rigName)
+		return nil
+	}
+	fmt.Printf("%s Holds in '%s':\n\n", style.Bold.Render("⏸"), rigName)
+	for _, h := range held {
+		fmt.Printf("  %s  %s\n", h.Pattern, style.Dim.Render(holdMatchesSummary(h)))
+	}
+	return nil
+}
+
+// holdMatchesSummary describes the open MRs a hold matches.
+func holdMatchesSummary(h refinery.HeldPattern) string {
+	if len(h.Matches) == 0 {
+		return "matches no open MR (it will hold any that match later)"
+	}
+	return fmt.Sprintf("matches %d open MR(s): %s", len(h.Matches), strings.Join(h.Matches, ", "))
+}
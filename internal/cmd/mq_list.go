@@ -1,27 +1,177 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/mq"
 	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
 func runMQList(cmd *cobra.Command, args []string) error {
-	rigName := args[0]
+	if mqListAllRigs != (len(args) == 0) {
+		return fmt.Errorf("give a rig, or --all-rigs to list every rig's queue")
+	}
+	if mqListClaimed && mqListUnclaimed {
+		return fmt.Errorf("--claimed and --unclaimed are mutually exclusive")
+	}
+	if mqListMe && mqListWorker != "" {
+		return fmt.Errorf("--me and --worker are mutually exclusive")
+	}
+	if err := validateGlob("--worker", mqListWorker); err != nil {
+		return err
+	}
+	if err := validateGlob("--epic", mqListEpic); err != nil {
+		return err
+	}
+	if mqListLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	if err := validateMRLabels(mqListLabels); err != nil {
+		return err
+	}
+	format, err := mqListOutputFormat(mqListFormat, mqListJSON)
+	if err != nil {
+		return err
+	}
+	var updatedCutoff time.Time
+	if mqListUpdatedSince != "" {
+		window, err := parseDuration(mqListUpdatedSince)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("invalid --updated-since %q: use a duration like 1h or 2d", mqListUpdatedSince)
+		}
+		updatedCutoff = time.Now().Add(-window)
+	}
+	if mqListAllRigs {
+		return runMQListAllRigs(cmd, format, updatedCutoff)
+	}
+
+	mgr, r, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	workerFilter, err := mqListWorkerFilter(r)
+	if err != nil {
+		return err
+	}
+	l, err := queryMQList(mgr, r, workerFilter, updatedCutoff)
+	if err != nil {
+		return err
+	}
 
-	_, r, _, err := getRefineryManager(rigName)
+	idWidth, branchWidth, err := mqListWidths(cmd, l.config)
 	if err != nil {
 		return err
 	}
 
+	// JSON output
+	switch format {
+	case "json":
+		return outputJSON(l.page())
+	case "ndjson":
+		w := structuredOutput()
+		for _, s := range l.scored {
+			if err := outputJSONLine(w, l.item(s)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Human-readable output
+	// Warn first if the refinery itself is failing - nothing below will merge
+	l.warnRefineryFailing()
+	if !mqListNoHeader {
+		fmt.Printf("%s Merge queue for '%s':%s\n\n", style.Bold.Render("📋"), rigName, mergeWindowNote(l.config.MergeWindow))
+	}
+
+	if len(l.scored) == 0 {
+		if !mqListNoHeader {
+			fmt.Printf("  %s\n", style.Dim.Render("(empty)"))
+		}
+		return nil
+	}
+
+	// Create styled table with SCORE column
+	table := style.NewTable(mqListColumns(l.shortenIDs(idWidth), branchWidth, mqListWide)...)
+	if mqListNoHeader {
+		table.SetHeader(false).SetIndent("")
+	}
+	table.SetBorder(format == "table")
+
+	// Add rows using scored items (already sorted by score)
+	anyHot := false
+	for _, item := range l.scored {
+		row, hot := l.row(item, idWidth)
+		anyHot = anyHot || hot
+		table.AddRow(row...)
+	}
+
+	fmt.Print(table.Render())
+	if mqListNoHeader {
+		return nil
+	}
+	if anyHot {
+		fmt.Printf("  %s\n", style.Dim.Render("↑ hot branch: scored as P0 (merge_queue.hot_branches)"))
+	}
+	if l.hasMore {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("showing %d of %d (raise --limit to see more)", len(l.scored), l.total)))
+	}
+
+	// Show blocking details below table
+	l.printBlocked(idWidth, "")
+	return nil
+}
+
+// scoredMR is an MR bead matching gt mq list's filters, with its score.
+type scoredMR struct {
+	issue  *beads.Issue
+	fields *beads.MRFields
+	score  float64
+}
+
+// mqListRig is one rig's merge queue as gt mq list shows it: the MRs
+// matching the filters, best score first and cut to --limit, and the
+// refinery state they are displayed against.
+type mqListRig struct {
+	rig     *rig.Rig
+	config  *refinery.MergeQueueConfig
+	scored  []scoredMR
+	total   int // MRs matching the filters, before --limit
+	hasMore bool
+
+	ref     *refinery.Refinery // nil if the refinery state can't be read
+	merging map[string]bool
+	holds   refinery.Holds
+
+	shortIDs map[string]string // displayed ID by MR ID; see shortenIDs
+}
+
+// mqListWorkerFilter returns the worker glob for gt mq list in a rig:
+// --worker, or the current user's worker with --me.
+func mqListWorkerFilter(r *rig.Rig) (string, error) {
+	if mqListMe {
+		return resolveCurrentWorker(r)
+	}
+	return mqListWorker, nil
+}
+
+// queryMQList queries a rig's MRs and applies gt mq list's filters.
+func queryMQList(mgr *refinery.Manager, r *rig.Rig, workerFilter string, updatedCutoff time.Time) (*mqListRig, error) {
 	// Create beads wrapper for the rig - use BeadsPath() to get the git-synced location
 	b := beads.New(r.BeadsPath())
 
@@ -40,47 +190,54 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		opts.Status = "open"
 	}
 
-	var issues []*beads.Issue
+	issues, err := queryMRs(b, opts, mqListReady)
+	if err != nil {
+		return nil, beadsQueryError("querying merge queue", err, r.BeadsPath())
+	}
 
-	if mqListReady {
-		// Use ready query which filters by no blockers
-		allReady, err := b.Ready()
+	// Claims and notes live outside the list output; fetch them only if filtering
+	var claims map[string]string
+	if mqListClaimed || mqListUnclaimed {
+		claims, err = queueClaims(mrqueue.New(r.Path))
 		if err != nil {
-			return fmt.Errorf("querying ready MRs: %w", err)
+			return nil, fmt.Errorf("reading queue claims: %w", err)
 		}
-		// Filter to only merge-request type
-		for _, issue := range allReady {
-			if issue.Type == "merge-request" {
-				issues = append(issues, issue)
-			}
+	}
+	var detailed map[string]*beads.Issue
+	if mqListHasNotes {
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
 		}
-	} else {
-		issues, err = b.List(opts)
+		detailed, err = b.ShowMultiple(ids)
 		if err != nil {
-			return fmt.Errorf("querying merge queue: %w", err)
+			return nil, beadsQueryError("fetching MR notes", err, r.BeadsPath())
 		}
 	}
 
-	// Apply additional filters and calculate scores
-	now := time.Now()
-	type scoredIssue struct {
-		issue  *beads.Issue
-		fields *beads.MRFields
-		score  float64
+	// The MR(s) the refinery is working on right now, if any, and its holds
+	l := &mqListRig{rig: r, config: loadMQConfig(r), merging: make(map[string]bool)}
+	if ref, err := mgr.Status(); err == nil {
+		l.ref = ref
+		for _, id := range ref.Merging() {
+			l.merging[id] = true
+		}
+		l.holds = ref.Holds
 	}
-	var scored []scoredIssue
 
+	// Apply additional filters and calculate scores
+	now := time.Now()
 	for _, issue := range issues {
 		// Parse MR fields
 		fields := beads.ParseMRFields(issue)
 
 		// Filter by worker
-		if mqListWorker != "" {
+		if workerFilter != "" {
 			worker := ""
 			if fields != nil {
 				worker = fields.Worker
 			}
-			if !strings.EqualFold(worker, mqListWorker) {
+			if !globMatch(strings.ToLower(workerFilter), strings.ToLower(worker)) {
 				continue
 			}
 		}
@@ -91,155 +248,553 @@ func runMQList(cmd *cobra.Command, args []string) error {
 			if fields != nil {
 				target = fields.Target
 			}
-			expectedTarget := "integration/" + mqListEpic
-			if target != expectedTarget {
+			if !epicTargetMatch(mqListEpic, target) {
+				continue
+			}
+		}
+
+		// Drafts and held MRs are never ready to merge
+		if mqListReady && ((fields != nil && fields.Draft) || l.heldBy(issue, fields) != "") {
+			continue
+		}
+
+		if mqListClaimed || mqListUnclaimed {
+			if claimed := mrClaimant(issue, fields, claims) != ""; claimed != mqListClaimed {
+				continue
+			}
+		}
+
+		if mqListUpdatedSince != "" && !updatedSince(issue, updatedCutoff) {
+			continue
+		}
+
+		if mqListBlockedBy != "" && !blockedBy(issue, mqListBlockedBy) {
+			continue
+		}
+
+		if !hasLabels(issue, mqListLabels) {
+			continue
+		}
+
+		if mqListHasNotes {
+			if d := detailed[issue.ID]; d == nil || len(d.Comments) == 0 {
 				continue
 			}
 		}
 
 		// Calculate priority score
-		score := calculateMRScore(issue, fields, now)
-		scored = append(scored, scoredIssue{issue: issue, fields: fields, score: score})
+		score := refinery.IssueScore(issue, now, l.config.HotBranches)
+		l.scored = append(l.scored, scoredMR{issue: issue, fields: fields, score: score})
 	}
 
 	// Sort by score descending (highest priority first)
-	sort.Slice(scored, func(i, j int) bool {
-		return scored[i].score > scored[j].score
+	sort.Slice(l.scored, func(i, j int) bool {
+		return l.scored[i].score > l.scored[j].score
 	})
 
-	// Extract filtered issues for JSON output compatibility
-	var filtered []*beads.Issue
-	for _, s := range scored {
-		filtered = append(filtered, s.issue)
-	}
+	// Keep the top --limit MRs; total counts every match
+	l.total = len(l.scored)
+	var shown int
+	shown, l.hasMore = pageLimit(l.total, mqListLimit)
+	l.scored = l.scored[:shown]
+	return l, nil
+}
 
-	// JSON output
-	if mqListJSON {
-		return outputJSON(filtered)
+// heldBy returns the hold pattern matching an MR, or "".
+func (l *mqListRig) heldBy(issue *beads.Issue, fields *beads.MRFields) string {
+	branch := ""
+	if fields != nil {
+		branch = fields.Branch
 	}
+	return l.holds.Match(issue.ID, branch)
+}
 
-	// Human-readable output
-	fmt.Printf("%s Merge queue for '%s':\n\n", style.Bold.Render("📋"), rigName)
+// item returns an MR's JSON output.
+func (l *mqListRig) item(s scoredMR) MRListItem {
+	item := newMRListItem(s.issue)
+	item.Merging = l.merging[s.issue.ID]
+	item.Draft = s.fields != nil && s.fields.Draft
+	item.Held = l.heldBy(s.issue, s.fields)
+	return item
+}
 
-	if len(filtered) == 0 {
-		fmt.Printf("  %s\n", style.Dim.Render("(empty)"))
-		return nil
+// page returns the rig's MRs as gt mq list --json outputs them.
+func (l *mqListRig) page() MRListPage {
+	items := make([]MRListItem, 0, len(l.scored))
+	for _, s := range l.scored {
+		items = append(items, l.item(s))
 	}
+	return MRListPage{Items: items, Total: l.total, Limit: mqListLimit, HasMore: l.hasMore}
+}
 
-	// Create styled table with SCORE column
-	table := style.NewTable(
-		style.Column{Name: "ID", Width: 12},
-		style.Column{Name: "SCORE", Width: 7, Align: style.AlignRight},
-		style.Column{Name: "PRI", Width: 4},
-		style.Column{Name: "CONVOY", Width: 12},
-		style.Column{Name: "BRANCH", Width: 24},
-		style.Column{Name: "STATUS", Width: 10},
-		style.Column{Name: "AGE", Width: 6, Align: style.AlignRight},
-	)
+// warnRefineryFailing warns if the rig's refinery is failing, so nothing
+// listed will merge.
+func (l *mqListRig) warnRefineryFailing() {
+	if l.ref == nil || l.ref.LastError == "" {
+		return
+	}
+	if mqListNoHeader {
+		fmt.Fprintf(os.Stderr, "warning: refinery for '%s' is failing: %s\n", l.rig.Name, formatRefineryError(l.ref))
+	} else {
+		fmt.Printf("%s refinery for '%s' is failing: %s\n\n", style.Error.Render("⚠"), l.rig.Name, formatRefineryError(l.ref))
+	}
+}
 
-	// Add rows using scored items (already sorted by score)
-	for _, item := range scored {
-		issue := item.issue
-		fields := item.fields
+// row returns an MR's table cells (see mqListColumns), and whether it is
+// on a hot branch.
+func (l *mqListRig) row(item scoredMR, idWidth int) ([]string, bool) {
+	issue := item.issue
+	fields := item.fields
 
-		// Determine display status
-		displayStatus := issue.Status
-		if issue.Status == "open" {
-			if len(issue.BlockedBy) > 0 || issue.BlockedByCount > 0 {
-				displayStatus = "blocked"
-			} else {
-				displayStatus = "ready"
-			}
-		}
+	styledStatus := mqListStatusCell(issue, fields, l.merging[issue.ID], l.heldBy(issue, fields) != "")
 
-		// Format status with styling
-		styledStatus := displayStatus
-		switch displayStatus {
-		case "ready":
-			styledStatus = style.Success.Render("ready")
-		case "in_progress":
-			styledStatus = style.Warning.Render("active")
-		case "blocked":
-			styledStatus = style.Dim.Render("blocked")
-		case "closed":
-			styledStatus = style.Dim.Render("closed")
-		}
-
-		// Get MR fields
-		branch := ""
-		convoyID := ""
-		if fields != nil {
-			branch = fields.Branch
-			convoyID = fields.ConvoyID
-		}
-
-		// Format convoy column
-		convoyDisplay := style.Dim.Render("(none)")
-		if convoyID != "" {
-			// Truncate convoy ID for display
-			if len(convoyID) > 12 {
-				convoyID = convoyID[:12]
-			}
-			convoyDisplay = convoyID
-		}
+	// Get MR fields
+	branch := ""
+	convoyID := ""
+	if fields != nil {
+		branch = fields.Branch
+		convoyID = fields.ConvoyID
+	}
 
-		// Format priority with color
-		priority := fmt.Sprintf("P%d", issue.Priority)
-		if issue.Priority <= 1 {
-			priority = style.Error.Render(priority)
-		} else if issue.Priority == 2 {
-			priority = style.Warning.Render(priority)
+	// Format convoy column
+	convoyDisplay := style.Dim.Render("(none)")
+	if convoyID != "" {
+		// Truncate convoy ID for display
+		if len(convoyID) > 12 {
+			convoyID = convoyID[:12]
 		}
+		convoyDisplay = convoyID
+	}
 
-		// Format score
-		scoreStr := fmt.Sprintf("%.1f", item.score)
+	// Format priority with color; ↑ marks a hot branch, scored as P0
+	priority := fmt.Sprintf("P%d", issue.Priority)
+	hot := fields != nil && l.config.HotBranches.Match(fields.Target, fields.Branch)
+	if hot {
+		priority = style.Error.Render(priority + "↑")
+	} else if issue.Priority <= 1 {
+		priority = style.Error.Render(priority)
+	} else if issue.Priority == 2 {
+		priority = style.Warning.Render(priority)
+	}
 
-		// Calculate age
-		age := formatMRAge(issue.CreatedAt)
+	// Format score
+	scoreStr := fmt.Sprintf("%.1f", item.score)
 
-		// Truncate ID if needed
-		displayID := issue.ID
-		if len(displayID) > 12 {
-			displayID = displayID[:12]
-		}
+	// Calculate age
+	age := formatMRAge(issue.CreatedAt)
 
-		table.AddRow(displayID, scoreStr, priority, convoyDisplay, branch, styledStatus, style.Dim.Render(age))
-	}
+	// Truncate ID if needed
+	displayID := l.displayID(issue.ID, idWidth)
 
-	fmt.Print(table.Render())
+	row := []string{displayID, scoreStr, priority, convoyDisplay, branch, styledStatus, style.Dim.Render(age)}
+	if mqListWide {
+		row = append(row, formatAttempts(fields))
+	}
+	return row, hot
+}
 
-	// Show blocking details below table
-	for _, item := range scored {
+// printBlocked prints what each blocked MR is waiting on, below the table.
+// prefix goes before each ID (the rig, when listing several).
+func (l *mqListRig) printBlocked(idWidth int, prefix string) {
+	for _, item := range l.scored {
 		issue := item.issue
 		displayStatus := issue.Status
 		if issue.Status == "open" && (len(issue.BlockedBy) > 0 || issue.BlockedByCount > 0) {
 			displayStatus = "blocked"
 		}
 		if displayStatus == "blocked" && len(issue.BlockedBy) > 0 {
-			displayID := issue.ID
-			if len(displayID) > 12 {
-				displayID = displayID[:12]
-			}
+			displayID := prefix + l.displayID(issue.ID, idWidth)
 			fmt.Printf("  %s %s\n", style.Dim.Render(displayID+":"),
 				style.Dim.Render(fmt.Sprintf("waiting on %s", issue.BlockedBy[0])))
 		}
 	}
+}
 
-	return nil
+// shortenIDs works out how the listed MRs' IDs are displayed cut to width:
+// each is extended to a prefix no other MR in the rig shares, so it can be
+// pasted into any mq command. Returns the ID column width that fits them.
+func (l *mqListRig) shortenIDs(width int) int {
+	listed := make([]string, 0, len(l.scored))
+	truncated := false
+	for _, item := range l.scored {
+		listed = append(listed, item.issue.ID)
+		truncated = truncated || (width > 0 && len(item.issue.ID) > width)
+	}
+	if !truncated {
+		return width
+	}
+
+	// Unique among all the rig's MRs, not just those listed
+	all, err := refinery.MRIDs(beads.New(l.rig.BeadsPath()))
+	if err != nil {
+		all = listed
+	}
+	l.shortIDs = mq.ShortIDs(append(all, listed...), width)
+
+	colWidth := width
+	for _, id := range listed {
+		if len(l.shortIDs[id]) > colWidth {
+			colWidth = len(l.shortIDs[id])
+		}
+	}
+	return colWidth
 }
 
-// formatMRAge formats the age of an MR from its created_at timestamp.
-func formatMRAge(createdAt string) string {
-	t, err := time.Parse(time.RFC3339, createdAt)
+// displayID returns how an MR's ID is shown: its unique prefix if
+// shortenIDs worked one out, else the ID cut to width.
+func (l *mqListRig) displayID(id string, width int) string {
+	if short, ok := l.shortIDs[id]; ok {
+		return short
+	}
+	return truncateID(id, width)
+}
+
+// mqListWidths returns the ID and branch column widths: --id-width and
+// --branch-width if given, else the rig's merge_queue settings.
+func mqListWidths(cmd *cobra.Command, cfg *refinery.MergeQueueConfig) (int, int, error) {
+	idWidth, branchWidth := cfg.ListIDWidth, cfg.ListBranchWidth
+	if cmd.Flags().Changed("id-width") {
+		idWidth = mqListIDWidth
+	}
+	if cmd.Flags().Changed("branch-width") {
+		branchWidth = mqListBranchWidth
+	}
+	if idWidth < 0 || branchWidth < 0 {
+		return 0, 0, fmt.Errorf("--id-width and --branch-width must not be negative")
+	}
+	return idWidth, branchWidth, nil
+}
+
+// MRListRigPage is one rig's queue in gt mq list --all-rigs --json.
+type MRListRigPage struct {
+	Rig string `json:"rig"`
+	MRListPage
+}
+
+// MRListAllRigs is the JSON output of gt mq list --all-rigs: each rig's
+// queue, by rig name, and the rigs that couldn't be listed.
+type MRListAllRigs struct {
+	Rigs   []MRListRigPage   `json:"rigs"`
+	Total  int               `json:"total"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// runMQListAllRigs lists every rig's queue under the rig root, with the
+// same filters, in one table with a RIG column. Rigs that can't be listed
+// are warned about and skipped, and the command then exits 1.
+func runMQListAllRigs(cmd *cobra.Command, format string, updatedCutoff time.Time) error {
+	root, err := findRigRoot()
+	if err != nil {
+		return err
+	}
+	rigs, err := discoverAllRigs(root)
+	if err != nil {
+		return err
+	}
+	sort.Slice(rigs, func(i, j int) bool { return rigs[i].Name < rigs[j].Name })
+
+	var lists []*mqListRig
+	failed := make(map[string]string)
+	for _, r := range rigs {
+		workerFilter, err := mqListWorkerFilter(r)
+		if err != nil {
+			continue // --me: the current user has no worker in this rig
+		}
+		l, err := queryMQList(refinery.NewManager(r), r, workerFilter, updatedCutoff)
+		if err != nil {
+			failed[r.Name] = err.Error()
+			fmt.Fprintf(os.Stderr, "Warning: skipping rig '%s': %v\n", r.Name, err)
+			continue
+		}
+		lists = append(lists, l)
+	}
+	var exitErr error
+	if len(failed) > 0 {
+		exitErr = NewSilentExit(1)
+	}
+
+	switch format {
+	case "json":
+		out := MRListAllRigs{Rigs: []MRListRigPage{}}
+		for _, l := range lists {
+			out.Rigs = append(out.Rigs, MRListRigPage{Rig: l.rig.Name, MRListPage: l.page()})
+			out.Total += l.total
+		}
+		if len(failed) > 0 {
+			out.Errors = failed
+		}
+		if err := outputJSON(out); err != nil {
+			return err
+		}
+		return exitErr
+	case "ndjson":
+		w := structuredOutput()
+		for _, l := range lists {
+			for _, s := range l.scored {
+				item := l.item(s)
+				item.Rig = l.rig.Name
+				if err := outputJSONLine(w, item); err != nil {
+					return err
+				}
+			}
+		}
+		return exitErr
+	}
+
+	// The rigs' own width settings differ; use the flags or the defaults
+	idWidth, branchWidth, err := mqListWidths(cmd, refinery.DefaultMergeQueueConfig())
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lists {
+		l.warnRefineryFailing()
+	}
+	if !mqListNoHeader {
+		fmt.Printf("%s Merge queue across %d rigs:\n\n", style.Bold.Render("📋"), len(lists))
+	}
+
+	rigWidth := len("RIG")
+	idColWidth := idWidth
+	shown := 0
+	for _, l := range lists {
+		if len(l.scored) > 0 && len(l.rig.Name) > rigWidth {
+			rigWidth = len(l.rig.Name)
+		}
+		if w := l.shortenIDs(idWidth); w > idColWidth {
+			idColWidth = w
+		}
+		shown += len(l.scored)
+	}
+	if shown == 0 {
+		if !mqListNoHeader {
+			fmt.Printf("  %s\n", style.Dim.Render("(empty)"))
+		}
+		return exitErr
+	}
+
+	columns := append([]style.Column{{Name: "RIG", Width: rigWidth}}, mqListColumns(idColWidth, branchWidth, mqListWide)...)
+	table := style.NewTable(columns...)
+	if mqListNoHeader {
+		table.SetHeader(false).SetIndent("")
+	}
+	table.SetBorder(format == "table")
+
+	anyHot := false
+	for _, l := range lists {
+		for _, item := range l.scored {
+			row, hot := l.row(item, idWidth)
+			anyHot = anyHot || hot
+			table.AddRow(append([]string{l.rig.Name}, row...)...)
+		}
+	}
+
+	fmt.Print(table.Render())
+	if mqListNoHeader {
+		return exitErr
+	}
+	if anyHot {
+		fmt.Printf("  %s\n", style.Dim.Render("↑ hot branch: scored as P0 (merge_queue.hot_branches)"))
+	}
+	for _, l := range lists {
+		if l.hasMore {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%s: showing %d of %d (raise --limit to see more)", l.rig.Name, len(l.scored), l.total)))
+		}
+	}
+	for _, l := range lists {
+		l.printBlocked(idWidth, l.rig.Name+"/")
+	}
+	return exitErr
+}
+
+// mqListColumns returns the columns of gt mq list's table. Cells may be
+// styled: the table pads them by visible width, so they line up with plain
+// ones.
+func mqListColumns(idWidth, branchWidth int, wide bool) []style.Column {
+	columns := []style.Column{
+		{Name: "ID", Width: idWidth},
+		{Name: "SCORE", Width: 7, Align: style.AlignRight},
+		{Name: "PRI", Width: 4},
+		{Name: "CONVOY", Width: 12},
+		{Name: "BRANCH", Width: branchWidth},
+		{Name: "STATUS", Width: 10},
+		{Name: "AGE", Width: 6, Align: style.AlignRight},
+	}
+	if wide {
+		columns = append(columns, style.Column{Name: "ATTEMPTS", Width: 8, Align: style.AlignRight})
+	}
+	return columns
+}
+
+// mqListStatusCell returns the styled STATUS cell for an MR.
+func mqListStatusCell(issue *beads.Issue, fields *beads.MRFields, merging, held bool) string {
+	displayStatus := issue.Status
+	if merging {
+		displayStatus = "merging"
+	} else if issue.Status == "open" {
+		if fields != nil && fields.Draft {
+			displayStatus = "draft"
+		} else if held {
+			displayStatus = "held"
+		} else if len(issue.BlockedBy) > 0 || issue.BlockedByCount > 0 {
+			displayStatus = "blocked"
+		} else {
+			displayStatus = "ready"
+		}
+	}
+
+	switch displayStatus {
+	case "ready":
+		return style.Success.Render("ready")
+	case "merging":
+		return style.Bold.Render("▶ merging")
+	case "in_progress":
+		return style.Warning.Render("active")
+	case "blocked":
+		return style.Dim.Render("blocked")
+	case "draft":
+		return style.Dim.Render("draft")
+	case "held":
+		return style.Warning.Render("held")
+	case "closed":
+		return style.Dim.Render("closed")
+	}
+	return displayStatus
+}
+
+// truncateID cuts an MR ID to width characters for display. A width of 0
+// leaves it whole.
+func truncateID(id string, width int) string {
+	if width > 0 && len(id) > width {
+		return id[:width]
+	}
+	return id
+}
+
+// chronicAttempts is the attempt count at which an MR is highlighted as
+// chronically failing in gt mq list --wide.
+const chronicAttempts = 3
+
+// loadMQConfig returns the rig's merge queue config, or the defaults if it
+// can't be loaded.
+func loadMQConfig(r *rig.Rig) *refinery.MergeQueueConfig {
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return refinery.DefaultMergeQueueConfig()
+	}
+	return eng.Config()
+}
+
+// mergeWindowNote returns " (window closed)" with when it next opens if the
+// merge window is closed now, else "". Ready MRs won't merge until then.
+func mergeWindowNote(window config.MergeWindow) string {
+	now := time.Now()
+	if window.Open(now) {
+		return ""
+	}
+	note := "(window closed)"
+	if next := window.NextOpen(now); !next.IsZero() {
+		note = fmt.Sprintf("(window closed until %s)", next.Format("Mon 15:04"))
+	}
+	return " " + style.Warning.Render(note)
+}
+
+// formatAttempts renders how many times the refinery has tried to merge an
+// MR, highlighting chronic failures.
+func formatAttempts(fields *beads.MRFields) string {
+	if fields == nil || fields.Attempts == 0 {
+		return style.Dim.Render("0")
+	}
+	n := strconv.Itoa(fields.Attempts)
+	if fields.Attempts >= chronicAttempts {
+		return style.Warning.Render(n)
+	}
+	return n
+}
+
+// parseMRTime parses an MR bead timestamp (created_at, updated_at).
+func parseMRTime(ts string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, ts)
 	if err != nil {
 		// Try other formats
-		t, err = time.Parse("2006-01-02T15:04:05Z", createdAt)
+		t, err = time.Parse("2006-01-02T15:04:05Z", ts)
 		if err != nil {
-			return "?"
+			return time.Time{}, false
 		}
 	}
+	return t, true
+}
+
+// updatedSince reports whether an MR bead was updated at or after cutoff.
+// MRs without a readable updated_at are left out.
+func updatedSince(issue *beads.Issue, cutoff time.Time) bool {
+	t, ok := parseMRTime(issue.UpdatedAt)
+	return ok && !t.Before(cutoff)
+}
+
+// mrAge returns how long ago an MR was created, from its created_at
+// timestamp. ok is false if the timestamp can't be read.
+func mrAge(createdAt string) (d time.Duration, ok bool) {
+	t, ok := parseMRTime(createdAt)
+	if !ok {
+		return 0, false
+	}
+	return time.Since(t), true
+}
+
+// MRListItem is an MR bead in JSON output, with its age in seconds so
+// consumers can threshold or format it themselves (-1 if unknown).
+type MRListItem struct {
+	*beads.Issue
+	AgeSeconds int64 `json:"age_seconds"`
+
+	// Merging is set on the MR(s) the refinery is merging right now.
+	Merging bool `json:"merging,omitempty"`
+
+	// Draft is set on MRs marked draft (gt mq draft); the refinery skips them.
+	Draft bool `json:"draft,omitempty"`
+
+	// Held is the hold pattern matching the MR (gt mq hold), if any; the
+	// refinery skips held MRs.
+	Held string `json:"held,omitempty"`
+
+	// Rig is the MR's rig, set in gt mq list --all-rigs --format=ndjson.
+	Rig string `json:"rig,omitempty"`
+}
+
+// MRListPage is the JSON output of gt mq list: the MRs shown, plus how
+// many matched in all so clients using --limit know whether there are more.
+type MRListPage struct {
+	Items   []MRListItem `json:"items"`
+	Total   int          `json:"total"`
+	Limit   int          `json:"limit"` // 0: no limit
+	HasMore bool         `json:"has_more"`
+}
+
+// pageLimit returns how many of total results to show under limit (0 for
+// no limit), and whether any are left out.
+func pageLimit(total, limit int) (shown int, hasMore bool) {
+	if limit > 0 && total > limit {
+		return limit, true
+	}
+	return total, false
+}
 
-	d := time.Since(t)
+// newMRListItem wraps an MR bead for JSON output.
+func newMRListItem(issue *beads.Issue) MRListItem {
+	item := MRListItem{Issue: issue, AgeSeconds: -1}
+	if d, ok := mrAge(issue.CreatedAt); ok {
+		item.AgeSeconds = int64(d.Seconds())
+	}
+	return item
+}
+
+// formatMRAge formats the age of an MR from its created_at timestamp.
+func formatMRAge(createdAt string) string {
+	d, ok := mrAge(createdAt)
+	if !ok {
+		return "?"
+	}
 
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
@@ -253,43 +808,212 @@ func formatMRAge(createdAt string) string {
 	return fmt.Sprintf("%dd", int(d.Hours()/24))
 }
 
-// outputJSON outputs data as JSON.
+// mrLister is the subset of the beads client used to query the merge queue.
+type mrLister interface {
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+}
+
+// queryMRs fetches merge-request issues matching opts, highest priority
+// first, as the refinery takes them. With ready set, only MRs without open
+// blockers are returned. Type, priority, readiness and order are all part
+// of the beads query, so nothing is fetched only to be dropped here.
+func queryMRs(b mrLister, opts beads.ListOptions, ready bool) ([]*beads.Issue, error) {
+	opts.ReadyOnly = ready
+	opts.Sort = beads.SortPriority
+	return b.List(opts)
+}
+
+// mqListOutputFormat resolves gt mq list's --format and --json flags to
+// "plain", "table" (bordered), "json" or "ndjson".
+func mqListOutputFormat(format string, jsonFlag bool) (string, error) {
+	switch format {
+	case "":
+		if jsonFlag {
+			return "json", nil
+		}
+		return "plain", nil
+	case "plain", "table", "json", "ndjson":
+		if jsonFlag && format != "json" {
+			return "", fmt.Errorf("--json conflicts with --format=%s", format)
+		}
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid --format %q (valid: plain, table, json, ndjson)", format)
+	}
+}
+
+// JSONSchemaVersion is the version of the --json output shape for the mq and
+// refinery commands. Bump it whenever fields are added, removed, or renamed so
+// consumers can detect and adapt.
+//
+// Version 1: initial versioned output.
+const JSONSchemaVersion = 1
+
+// outputJSON outputs data as JSON with a schema_version (see withSchemaVersion).
 func outputJSON(data interface{}) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	out, err := withSchemaVersion(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, out, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err = structuredOutput().Write(buf.Bytes())
+	return err
 }
 
-// calculateMRScore computes the priority score for an MR using the mrqueue scoring function.
-// Higher scores mean higher priority (process first).
-func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time) float64 {
-	// Parse MR creation time
-	mrCreatedAt, err := time.Parse(time.RFC3339, issue.CreatedAt)
+// outputJSONLine writes data to w as one line of JSON Lines, with a
+// top-level schema_version like outputJSON, in a single write. If w is
+// buffered, it is flushed so a reader sees each line as it is produced.
+func outputJSONLine(w io.Writer, data interface{}) error {
+	out, err := withSchemaVersion(data)
 	if err != nil {
-		mrCreatedAt, err = time.Parse("2006-01-02T15:04:05Z", issue.CreatedAt)
-		if err != nil {
-			mrCreatedAt = now // Fallback to now if parsing fails
+		return err
+	}
+	if _, err := w.Write(append(out, '\n')); err != nil {
+		return err
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// withSchemaVersion encodes data with a leading schema_version field.
+// Objects get the field prepended (preserving field order). Lists stay
+// lists, so existing consumers keep working: each object in the list gets
+// the field instead. Anything else is encoded as is.
+func withSchemaVersion(data interface{}) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || raw[0] != '[' {
+		return prependSchemaVersion(raw), nil
+	}
+
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil, err
+	}
+	out := []byte{'['}
+	for i, elem := range elems {
+		if i > 0 {
+			out = append(out, ',')
 		}
+		out = append(out, prependSchemaVersion(elem)...)
 	}
+	return append(out, ']'), nil
+}
 
-	// Build score input
-	input := mrqueue.ScoreInput{
-		Priority:    issue.Priority,
-		MRCreatedAt: mrCreatedAt,
-		Now:         now,
+// prependSchemaVersion adds schema_version as the first field of a JSON
+// object; anything other than an object is returned unchanged.
+func prependSchemaVersion(raw []byte) []byte {
+	if len(raw) == 0 || raw[0] != '{' {
+		return raw
+	}
+	prefix := fmt.Sprintf(`{"schema_version":%d`, JSONSchemaVersion)
+	if string(raw) == "{}" {
+		return []byte(prefix + "}")
 	}
+	return []byte(prefix + "," + string(raw[1:]))
+}
 
-	// Add fields from MR metadata if available
-	if fields != nil {
-		input.RetryCount = fields.RetryCount
+// validateGlob checks that a filter flag's value is a well-formed glob pattern.
+func validateGlob(flag, pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid %s pattern %q: %w", flag, pattern, err)
+	}
+	return nil
+}
 
-		// Parse convoy created at if available
-		if fields.ConvoyCreatedAt != "" {
-			if convoyTime, err := time.Parse(time.RFC3339, fields.ConvoyCreatedAt); err == nil {
-				input.ConvoyCreatedAt = &convoyTime
+// globMatch reports whether value matches the glob pattern (*, ?, [...]).
+// A pattern without wildcards is an exact match. As in paths, * does not
+// cross '/', so "release-*" matches integration/release-1 but not
+// integration/release-1/hotfix.
+func globMatch(pattern, value string) bool {
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// epicTargetMatch reports whether an MR target is the integration branch of
+// an epic matching the --epic glob.
+func epicTargetMatch(epic, target string) bool {
+	return globMatch("integration/"+epic, target)
+}
+
+// queueClaims maps the ID and branch of each live claim in the refinery
+// queue to its holder.
+func queueClaims(q *mrqueue.Queue) (map[string]string, error) {
+	mrs, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+	claims := make(map[string]string)
+	for _, mr := range mrs {
+		if !mr.IsClaimed() {
+			continue
+		}
+		claims[mr.ID] = mr.ClaimedBy
+		if mr.Branch != "" {
+			claims[mr.Branch] = mr.ClaimedBy
+		}
+	}
+	return claims, nil
+}
+
+// blockedBy reports whether an MR is blocked by the MR or issue with the
+// given ID.
+func blockedBy(issue *beads.Issue, id string) bool {
+	for _, b := range issue.BlockedBy {
+		if b == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLabels reports whether an MR carries every one of labels.
+func hasLabels(issue *beads.Issue, labels []string) bool {
+	for _, want := range labels {
+		found := false
+		for _, l := range issue.Labels {
+			if l == want {
+				found = true
+				break
 			}
 		}
+		if !found {
+			return false
+		}
 	}
+	return true
+}
 
-	return mrqueue.ScoreMRWithDefaults(input)
+// validateMRLabels checks labels given with --label. bd takes labels as a
+// comma-separated list, so they can't contain commas (or spaces).
+func validateMRLabels(labels []string) error {
+	for _, l := range labels {
+		if l == "" || strings.ContainsAny(l, ", \t\n") {
+			return fmt.Errorf("invalid --label %q: labels must be non-empty, without commas or spaces", l)
+		}
+	}
+	return nil
+}
+
+// mrClaimant returns who is handling an MR: the bead's assignee, or the
+// holder of a live queue claim on its ID or branch. Empty if nobody.
+func mrClaimant(issue *beads.Issue, fields *beads.MRFields, claims map[string]string) string {
+	if issue.Assignee != "" {
+		return issue.Assignee
+	}
+	if holder := claims[issue.ID]; holder != "" {
+		return holder
+	}
+	if fields != nil && fields.Branch != "" {
+		return claims[fields.Branch]
+	}
+	return ""
 }
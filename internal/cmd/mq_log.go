@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ log command flags
+var (
+	mqLogFull  bool
+	mqLogLines int
+)
+
+var mqLogCmd = &cobra.Command{
+	Use:   "log <rig> <mr-id>",
+	Short: "Show the log of an MR's last failed merge attempt",
+	Long: `Show the log of the refinery's last failed merge attempt for an MR.
+
+The short error shown by 'gt mq list' and 'gt mq status' is rarely enough to
+diagnose a failure. The refinery keeps the complete transcript of each failed
+attempt - its own output, every git command with its output, and the test
+command's output - capped at 256KB (the tail is kept). Any notes left with
+'gt mq note' are shown after the log.
+
+An attempt that found the MR already merged (its target already contained
+the branch, so no merge was made) is kept too, and shown as such.
+
+By default the last --lines lines are shown; use --full for the whole log.
+
+Examples:
+  gt mq log gastown gt-mr-abc
+  gt mq log gastown gt-mr-abc --full`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQLog,
+}
+
+func init() {
+	mqLogCmd.Flags().BoolVar(&mqLogFull, "full", false, "Show the complete log")
+	mqLogCmd.Flags().IntVarP(&mqLogLines, "lines", "n", 40, "Number of trailing lines to show")
+
+	mqCmd.AddCommand(mqLogCmd)
+}
+
+func runMQLog(cmd *cobra.Command, args []string) error {
+	mrID := args[1]
+
+	mgr, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	log, err := refinery.ReadMergeLog(r.Path, mrID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no merge log for '%s' in rig '%s' (logs are kept for failed and already merged attempts only)", mrID, r.Name)
+		}
+		return fmt.Errorf("reading merge log: %w", err)
+	}
+
+	alreadyMerged, log := refinery.MergeLogAlreadyMerged(log)
+	if alreadyMerged {
+		fmt.Printf("%s %s was already merged: its target contained the branch, so no merge was made\n\n",
+			style.Success.Render("✓"), mrID)
+	}
+
+	if mqLogFull {
+		fmt.Print(log)
+	} else {
+		tail, omitted := tailLines(log, mqLogLines)
+		if omitted > 0 {
+			fmt.Println(style.Dim.Render(fmt.Sprintf("... %d earlier line(s); use --full to see all", omitted)))
+		}
+		fmt.Print(tail)
+	}
+
+	// Notes are context, not part of the log: skip them if beads is unavailable
+	if notes, err := mgr.Notes(mrID); err == nil {
+		printMRNotes(notes)
+	}
+	return nil
+}
+
+// tailLines returns the last n lines of s and how many lines were dropped.
+func tailLines(s string, n int) (string, int) {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n < 0 || len(lines) <= n {
+		return s, 0
+	}
+	return strings.Join(lines[len(lines)-n:], ""), len(lines) - n
+}
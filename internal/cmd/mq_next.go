@@ -1,12 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"sort"
-	"time"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
@@ -20,7 +21,10 @@ var (
 var mqNextCmd = &cobra.Command{
 	Use:   "next <rig>",
 	Short: "Show the highest-priority merge request",
-	Long: `Show the next merge request to process based on priority score.
+	Long: `Show the single merge request the refinery will merge on its next cycle.
+
+Applies the refinery's scheduling order, skipping blocked MRs and the MR
+currently being processed. If nothing is ready, prints the reason.
 
 The priority scoring function considers:
   - Convoy age: Older convoys get higher priority (starvation prevention)
@@ -28,7 +32,8 @@ The priority scoring function considers:
   - Retry count: MRs that fail repeatedly get deprioritized
   - MR age: FIFO tiebreaker for same priority/convoy
 
-Use --strategy=fifo for first-in-first-out ordering instead.
+Use --strategy=fifo for first-in-first-out ordering instead: the oldest
+ready MR, skipping the same MRs.
 
 Examples:
   gt mq next gastown                    # Show highest-priority MR
@@ -50,77 +55,30 @@ func init() {
 func runMQNext(cmd *cobra.Command, args []string) error {
 	rigName := args[0]
 
-	_, r, _, err := getRefineryManager(rigName)
+	mgr, r, _, err := getRefineryManager(rigName)
 	if err != nil {
 		return err
 	}
 
-	// Create beads wrapper for the rig
-	b := beads.New(r.BeadsPath())
-
-	// Query for open merge-requests (ready to process)
-	opts := beads.ListOptions{
-		Type:     "merge-request",
-		Status:   "open",
-		Priority: -1, // No priority filter
-	}
-
-	issues, err := b.List(opts)
-	if err != nil {
-		return fmt.Errorf("querying merge queue: %w", err)
+	// Ask the refinery for its scheduling pick
+	pickNext := mgr.Next
+	if mqNextStrategy == "fifo" {
+		pickNext = mgr.NextFIFO
 	}
-
-	// Filter to only ready MRs (no blockers)
-	var ready []*beads.Issue
-	for _, issue := range issues {
-		if len(issue.BlockedBy) == 0 && issue.BlockedByCount == 0 {
-			ready = append(ready, issue)
-		}
+	pick, err := pickNext()
+	if err != nil && !errors.Is(err, refinery.ErrNothingReady) {
+		return beadsQueryError("querying merge queue", err, r.BeadsPath())
 	}
-
-	if len(ready) == 0 {
+	if err != nil {
 		if mqNextQuiet {
 			return nil // Silent exit
 		}
-		fmt.Printf("%s No ready merge requests in queue\n", style.Dim.Render("ℹ"))
+		fmt.Printf("%s Nothing ready: %s\n", style.Dim.Render("ℹ"),
+			strings.TrimPrefix(err.Error(), refinery.ErrNothingReady.Error()+": "))
 		return nil
 	}
 
-	now := time.Now()
-
-	// Sort based on strategy
-	if mqNextStrategy == "fifo" {
-		// FIFO: oldest first by creation time
-		sort.Slice(ready, func(i, j int) bool {
-			ti, _ := time.Parse(time.RFC3339, ready[i].CreatedAt)
-			tj, _ := time.Parse(time.RFC3339, ready[j].CreatedAt)
-			return ti.Before(tj)
-		})
-	} else {
-		// Priority: highest score first
-		type scoredIssue struct {
-			issue *beads.Issue
-			score float64
-		}
-		scored := make([]scoredIssue, len(ready))
-		for i, issue := range ready {
-			fields := beads.ParseMRFields(issue)
-			score := calculateMRScore(issue, fields, now)
-			scored[i] = scoredIssue{issue: issue, score: score}
-		}
-
-		sort.Slice(scored, func(i, j int) bool {
-			return scored[i].score > scored[j].score
-		})
-
-		// Rebuild ready slice in sorted order
-		for i, s := range scored {
-			ready[i] = s.issue
-		}
-	}
-
-	// Get the top MR
-	next := ready[0]
+	next, score, readyCount := pick.Issue, pick.Score, pick.Ready
 	fields := beads.ParseMRFields(next)
 
 	// Output based on format flags
@@ -130,14 +88,12 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 	}
 
 	if mqNextJSON {
-		return outputJSON(next)
+		return outputJSON(newMRListItem(next))
 	}
 
 	// Human-readable output
 	fmt.Printf("%s Next MR to process:\n\n", style.Bold.Render("🎯"))
 
-	score := calculateMRScore(next, fields, now)
-
 	fmt.Printf("  ID:       %s\n", next.ID)
 	fmt.Printf("  Score:    %.1f\n", score)
 	fmt.Printf("  Priority: P%d\n", next.Priority)
@@ -159,8 +115,8 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("  Age:      %s\n", formatMRAge(next.CreatedAt))
 
-	if len(ready) > 1 {
-		fmt.Printf("\n  %s\n", style.Dim.Render(fmt.Sprintf("(%d more in queue)", len(ready)-1)))
+	if readyCount > 1 {
+		fmt.Printf("\n  %s\n", style.Dim.Render(fmt.Sprintf("(%d more in queue)", readyCount-1)))
 	}
 
 	return nil
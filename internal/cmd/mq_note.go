@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqNoteCmd = &cobra.Command{
+	Use:   "note <rig> <mr-id> <text>...",
+	Short: "Leave a note on a merge request",
+	Long: `Leave a note on a merge request without changing it.
+
+Notes give context to others working the queue ("waiting on infra fix").
+Each is stored as a comment on the MR bead with its author and time, and
+all notes are kept in order. They are shown by 'gt mq status' and
+'gt mq log'.
+
+Examples:
+  gt mq note gastown gt-mr-abc "waiting on infra fix"
+  gt mq note gastown gt-mr-abc flaky test, retrying after lunch`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: runMQNote,
+}
+
+func init() {
+	mqCmd.AddCommand(mqNoteCmd)
+}
+
+func runMQNote(cmd *cobra.Command, args []string) error {
+	mrID := args[1]
+	text := strings.Join(args[2:], " ")
+
+	mgr, _, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	if err := mgr.AddNote(mrID, text); err != nil {
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", refinery.ErrMRNotFound, mrID, rigName)
+		}
+		return err
+	}
+
+	fmt.Printf("%s Note added to %s\n", style.Bold.Render("✓"), mrID)
+	return nil
+}
+
+// printMRNotes prints an MR's notes under a heading, oldest first.
+func printMRNotes(notes []beads.IssueComment) {
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Printf("\n%s\n", style.Bold.Render("Operator Notes"))
+	for _, n := range notes {
+		fmt.Printf("   %s %s %s\n", style.Bold.Render(n.Author),
+			style.Dim.Render(n.CreatedAt), formatTimeAgo(n.CreatedAt))
+		for _, line := range strings.Split(n.Text, "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
+}
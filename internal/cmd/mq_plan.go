@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ plan command flags
+var (
+	mqPlanJSON bool
+)
+
+var mqPlanCmd = &cobra.Command{
+	Use:   "plan <rig>",
+	Short: "Preview the refinery's next cycle",
+	Long: `Preview what the refinery would do on its next cycle, without merging.
+
+Lists every open MR with the decision the refinery's scheduler would make:
+- ready:   would be merged, in the order shown
+- blocked: waiting on an open issue (e.g. a conflict resolution task)
+- skipped: left alone this cycle (e.g. already being processed)
+
+Nothing is claimed, merged or changed. This is the whole-queue counterpart
+to 'gt mq next', which shows only the first MR. If the rig is parked or
+docked, the plan says so: nothing merges until it is resumed.
+
+Examples:
+  gt mq plan gastown
+  gt mq plan gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQPlan,
+}
+
+func init() {
+	mqPlanCmd.Flags().BoolVar(&mqPlanJSON, "json", false, "Output as JSON")
+
+	mqCmd.AddCommand(mqPlanCmd)
+}
+
+func runMQPlan(cmd *cobra.Command, args []string) error {
+	mgr, r, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	plan, err := mgr.Plan()
+	if err != nil {
+		return beadsQueryError("planning merge queue", err, r.BeadsPath())
+	}
+
+	if mqPlanJSON {
+		return outputJSON(plan)
+	}
+
+	fmt.Printf("%s Refinery plan for '%s': %d to merge, %d open\n\n",
+		style.Bold.Render("🗺"), rigName, plan.Ready(), len(plan.Entries))
+	if plan.Paused != "" {
+		fmt.Printf("  %s %s; nothing will merge until it is resumed\n\n", style.Warning.Render("⚠"), plan.Paused)
+	}
+	if len(plan.Entries) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(queue is empty)"))
+		return nil
+	}
+
+	table := style.NewTable(
+		style.Column{Name: "#", Width: 3, Align: style.AlignRight},
+		style.Column{Name: "ID", Width: 14},
+		style.Column{Name: "PRI", Width: 4},
+		style.Column{Name: "SCORE", Width: 7, Align: style.AlignRight},
+		style.Column{Name: "DECISION", Width: 9},
+		style.Column{Name: "BRANCH", Width: 28},
+		style.Column{Name: "REASON", Width: 30},
+	)
+	for _, e := range plan.Entries {
+		order := "-"
+		if e.Order > 0 {
+			order = strconv.Itoa(e.Order)
+		}
+		priority := fmt.Sprintf("P%d", e.Priority)
+		if e.Hot {
+			priority += "↑" // Hot branch, scored as P0
+		}
+		table.AddRow(order, e.ID, priority, fmt.Sprintf("%.1f", e.Score),
+			formatPlanDecision(e.Decision), truncateString(e.Branch, 28), e.Reason)
+	}
+	fmt.Print(table.Render())
+	return nil
+}
+
+// formatPlanDecision colors a plan decision for display.
+func formatPlanDecision(decision string) string {
+	switch decision {
+	case refinery.PlanReady:
+		return style.Success.Render(decision)
+	case refinery.PlanBlocked:
+		return style.Error.Render(decision)
+	default:
+		return style.Dim.Render(decision)
+	}
+}
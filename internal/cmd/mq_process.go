@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ process command flags
+var (
+	mqProcessJSON    bool
+	mqProcessVerbose bool
+	mqProcessLoop    bool
+	mqProcessNow     bool
+)
+
+var mqProcessCmd = &cobra.Command{
+	Use:   "process <rig>",
+	Short: "Run one refinery cycle now",
+	Long: `Run one refinery cycle synchronously and report what it did.
+
+Every MR that is ready when the cycle starts is merged (or failed) in
+priority order, without waiting for the refinery's next patrol, e.g. from
+CI right after a push. If another cycle is already running on the rig,
+this fails immediately instead of waiting. Exits 0 if every processed MR
+merged (or nothing was ready), 1 otherwise.
+
+--loop keeps running cycles until interrupted, waiting
+merge_queue.loop_interval between them. --now merges even if the rig's
+merge window is closed. The merge_queue options that shape a cycle
+(batch_size, mr_timeout, isolate_merges, loop_interval, merge_window) are
+described in docs/reference.md.
+
+Examples:
+  gt mq process gastown
+  gt mq process gastown --json
+  gt mq process gastown --loop
+  gt mq process gastown --now     # Merge despite a closed merge window`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQProcess,
+}
+
+func init() {
+	mqProcessCmd.Flags().BoolVar(&mqProcessJSON, "json", false, "Output the cycle result as JSON")
+	mqProcessCmd.Flags().BoolVarP(&mqProcessVerbose, "verbose", "v", false, "Show refinery merge output")
+	mqProcessCmd.Flags().BoolVar(&mqProcessLoop, "loop", false, "Keep running cycles at the rig's loop interval")
+	mqProcessCmd.Flags().BoolVar(&mqProcessNow, "now", false, "Merge even if the rig's merge window is closed")
+
+	mqCmd.AddCommand(mqProcessCmd)
+}
+
+// ProcessReport is the result of one on-demand refinery cycle.
+type ProcessReport struct {
+	Rig       string                 `json:"rig"`
+	Processed []refinery.ProcessedMR `json:"processed"`
+	Merged    int                    `json:"merged"`
+	Failed    int                    `json:"failed"`
+}
+
+func runMQProcess(cmd *cobra.Command, args []string) error {
+	r, err := getRigArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	if mqProcessLoop {
+		if mqProcessJSON {
+			return fmt.Errorf("--json cannot be combined with --loop")
+		}
+		return runMQProcessLoop(r)
+	}
+
+	eng := newProcessEngineer(r)
+
+	var report ProcessReport
+	return runInterruptible(func(ctx context.Context) error {
+		processed, err := eng.ProcessOnce(ctx)
+		if errors.Is(err, refinery.ErrMergeWindowClosed) && !mqProcessJSON {
+			fmt.Printf("%s %v; use --now to merge anyway\n", style.Dim.Render("○"), err)
+			return nil
+		}
+		if errors.Is(err, refinery.ErrMergeStuck) && !mqProcessJSON {
+			style.PrintWarning("%v; remaining MRs left in the queue", err)
+		} else if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, refinery.ErrMergeStuck) {
+			return err
+		}
+
+		report = summarizeProcessed(r.Name, processed)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if mqProcessJSON {
+			if err := outputJSON(report); err != nil {
+				return err
+			}
+		} else {
+			printProcessReport(report)
+		}
+		if report.Failed > 0 {
+			return NewSilentExit(1)
+		}
+		return nil
+	}, func() {
+		// Still report the MRs handled before the interrupt
+		if mqProcessJSON {
+			_ = outputJSON(report)
+		} else {
+			printProcessReport(report)
+			fmt.Println("Interrupted; remaining MRs left in the queue")
+		}
+	})
+}
+
+// runMQProcessLoop runs refinery cycles until interrupted. The engineer,
+// and so the rig's merge queue config, is reloaded every cycle.
+func runMQProcessLoop(r *rig.Rig) error {
+	cycles := 0
+	return runInterruptible(func(ctx context.Context) error {
+		for {
+			eng := newProcessEngineer(r)
+			interval := eng.Config().EffectiveLoopInterval()
+
+			processed, err := eng.ProcessOnce(ctx)
+			cycles++
+			switch {
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case errors.Is(err, refinery.ErrProcessingLocked), errors.Is(err, refinery.ErrMergeWindowClosed):
+				fmt.Printf("%s %v; skipping this cycle\n", style.Dim.Render("○"), err)
+			case err != nil:
+				style.PrintWarning("refinery cycle failed: %v", err)
+			case len(processed) > 0:
+				printProcessReport(summarizeProcessed(r.Name, processed))
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}, func() {
+		fmt.Printf("Stopped after %d cycle(s)\n", cycles)
+	})
+}
+
+// newProcessEngineer creates an engineer with the rig's merge queue config
+// and output set by the process flags.
+func newProcessEngineer(r *rig.Rig) *refinery.Engineer {
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		style.PrintWarning("could not load merge queue config, using defaults: %v", err)
+	}
+	eng.SetIgnoreMergeWindow(mqProcessNow)
+	if mqProcessVerbose && !mqProcessJSON {
+		eng.SetOutput(os.Stdout)
+	} else {
+		eng.SetOutput(io.Discard)
+	}
+	return eng
+}
+
+// summarizeProcessed counts merged and failed MRs in a cycle's results.
+// Blocked MRs count as neither; they wait for their blocker.
+func summarizeProcessed(rigName string, processed []refinery.ProcessedMR) ProcessReport {
+	report := ProcessReport{Rig: rigName, Processed: processed}
+	if report.Processed == nil {
+		report.Processed = []refinery.ProcessedMR{}
+	}
+	for _, p := range processed {
+		switch p.Outcome {
+		case refinery.OutcomeMerged, refinery.OutcomeSkipped:
+			report.Merged++
+		case refinery.OutcomeFailed, refinery.OutcomeConflict:
+			report.Failed++
+		}
+	}
+	return report
+}
+
+func printProcessReport(report ProcessReport) {
+	if len(report.Processed) == 0 {
+		fmt.Printf("%s Nothing ready to merge in '%s'\n", style.Dim.Render("○"), report.Rig)
+		return
+	}
+
+	for _, p := range report.Processed {
+		switch p.Outcome {
+		case refinery.OutcomeMerged:
+			batch := ""
+			if len(p.Batch) > 1 {
+				batch = " " + style.Dim.Render(fmt.Sprintf("in batch of %d", len(p.Batch)))
+			}
+			fmt.Printf("  %s %s merged (%s)%s\n", style.Success.Render("✓"), p.ID, shortSHA(p.MergeCommit), batch)
+		case refinery.OutcomeSkipped:
+			fmt.Printf("  %s %s already merged\n", style.Success.Render("✓"), p.ID)
+		case refinery.OutcomeBlocked:
+			fmt.Printf("  %s %s %s\n", style.Warning.Render("○"), p.ID, p.Error)
+		default:
+			fmt.Printf("  %s %s %s: %s\n", style.Error.Render("✗"), p.ID, p.Outcome, p.Error)
+		}
+	}
+	fmt.Printf("\n%s %d merged, %d failed\n", style.Bold.Render(report.Rig+":"), report.Merged, report.Failed)
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
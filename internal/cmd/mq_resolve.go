@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ resolve command flags
+var mqResolveJSON bool
+
+var mqResolveCmd = &cobra.Command{
+	Use:   "resolve [rig]",
+	Short: "Show how a rig argument resolves, without touching the queue",
+	Long: `Show how gt resolves a rig for the mq commands, one decision at a time.
+
+Prints where rigs are looked up (--rig-root, $GASTOWN_RIG_ROOT or the town
+containing the current directory), how the rig argument matched (exact
+name, case-insensitive name, a path, or the current directory when it is
+omitted), the rig's repo and config files, the environment overlay applied
+(--env or $GASTOWN_ENV), the default branch, and the effective merge queue
+settings. The queue itself is never read or changed.
+
+Use it to answer "why is it using the wrong rig/config?". If a step fails,
+the steps before it are still shown and the command exits 1.
+
+Examples:
+  gt mq resolve greenplace
+  gt mq resolve ./greenplace
+  gt mq resolve --env staging greenplace
+  gt mq resolve --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMQResolve,
+}
+
+func init() {
+	mqResolveCmd.Flags().BoolVar(&mqResolveJSON, "json", false, "Output the resolution as JSON")
+
+	mqCmd.AddCommand(mqResolveCmd)
+}
+
+// MQResolveStep is one decision made resolving a rig: what was picked and
+// why, or the error that stopped resolution there.
+type MQResolveStep struct {
+	Step   string `json:"step"`
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// MQResolveResult is how 'gt mq resolve' resolved a rig argument.
+type MQResolveResult struct {
+	Rig        string          `json:"rig,omitempty"`
+	Path       string          `json:"path,omitempty"`
+	Steps      []MQResolveStep `json:"steps"`
+	MergeQueue *MQConfigOutput `json:"merge_queue,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+func (res *MQResolveResult) add(step, value, reason string) {
+	res.Steps = append(res.Steps, MQResolveStep{Step: step, Value: value, Reason: reason})
+}
+
+// fail records the step that stopped resolution and returns res.
+func (res *MQResolveResult) fail(step string, err error) *MQResolveResult {
+	res.Steps = append(res.Steps, MQResolveStep{Step: step, Error: err.Error()})
+	res.Error = err.Error()
+	return res
+}
+
+func runMQResolve(cmd *cobra.Command, args []string) error {
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	res := resolveMQRig(arg)
+
+	if mqResolveJSON {
+		if err := outputJSON(res); err != nil {
+			return err
+		}
+	} else {
+		printMQResolve(arg, res)
+	}
+	if res.Error != "" {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// resolveMQRig runs the rig resolution the mq commands use, recording each
+// decision. Resolution stops at the first step that fails.
+func resolveMQRig(arg string) *MQResolveResult {
+	res := &MQResolveResult{}
+
+	var r *rig.Rig
+	if arg != "" && looksLikeRigPath(arg) {
+		res.add("rig root", "", "not used: the rig was given as a path")
+		loaded, err := getRigArg(arg)
+		if err != nil {
+			return res.fail("rig", err)
+		}
+		r = loaded
+		res.add("rig", r.Name, fmt.Sprintf("loaded from path %q", arg))
+	} else {
+		root, err := findRigRoot()
+		if err != nil {
+			return res.fail("rig root", err)
+		}
+		reason := "town containing the current directory"
+		if _, source := rigRootSetting(); source != "" {
+			reason = "from " + source
+		}
+		res.add("rig root", root, reason)
+
+		rigsPath := constants.MayorRigsPath(root)
+		if rigsConfig, err := config.LoadRigsConfig(rigsPath); err != nil {
+			res.add("rig registry", rigsPath, fmt.Sprintf("unreadable (%v); no rigs registered", err))
+		} else {
+			res.add("rig registry", rigsPath, fmt.Sprintf("%d rigs registered", len(rigsConfig.Rigs)))
+		}
+
+		if arg == "" {
+			name, current, err := findCurrentRig(root)
+			if err != nil {
+				return res.fail("rig", fmt.Errorf("no rig given and %w", err))
+			}
+			r = current
+			res.add("rig", name, "inferred from the current directory")
+		} else {
+			_, found, err := getRig(arg)
+			if err != nil {
+				return res.fail("rig", err)
+			}
+			r = found
+			reason := "exact name match"
+			if r.Name != arg {
+				reason = fmt.Sprintf("case-insensitive match for %q", arg)
+			}
+			res.add("rig", r.Name, reason)
+		}
+	}
+	res.Rig, res.Path = r.Name, r.Path
+	res.add("rig path", r.Path, "")
+
+	if repo, bare := rigRepoPath(r); bare {
+		res.add("repo", repo, "shared bare repo")
+	} else {
+		res.add("repo", repo, "mayor clone (no shared bare repo)")
+	}
+	res.add("beads", r.BeadsPath(), "")
+
+	configPath := filepath.Join(r.Path, "config.json")
+	if _, err := os.Stat(configPath); err != nil {
+		res.add("config", configPath, "missing; defaults apply")
+	} else {
+		res.add("config", configPath, "")
+	}
+
+	env := config.ActiveEnv()
+	switch {
+	case env == "":
+		res.add("environment", "", "none: neither --env nor $"+config.EnvVar+" is set")
+	case envFlag != "":
+		res.add("environment", env, "from --env")
+	default:
+		res.add("environment", env, "from $"+config.EnvVar)
+	}
+	if env != "" {
+		if err := config.ValidateEnv(env); err != nil {
+			return res.fail("overlay", err)
+		}
+		overlay := config.OverlayPath(configPath, env)
+		if _, err := os.Stat(overlay); err != nil {
+			res.add("overlay", overlay, "not found; base config used as-is")
+		} else {
+			res.add("overlay", overlay, "merged over config.json")
+		}
+	}
+
+	settingsPath := config.RigSettingsPath(r.Path)
+	if _, err := os.Stat(settingsPath); err != nil {
+		res.add("settings", settingsPath, "missing; defaults apply")
+	} else {
+		res.add("settings", settingsPath, "")
+	}
+
+	rigCfg, err := rig.LoadRigConfig(r.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return res.fail("default branch", fmt.Errorf("reading rig config: %w", err))
+	}
+	if rigCfg != nil && rigCfg.DefaultBranch != "" {
+		res.add("default branch", rigCfg.DefaultBranch, "default_branch in config")
+	} else {
+		res.add("default branch", r.DefaultBranch(), "default_branch not set")
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return res.fail("merge queue", fmt.Errorf("loading merge queue config: %w", err))
+	}
+	out := newMQConfigOutput(r.Name, eng.Config())
+	res.MergeQueue = &out
+	res.add("merge queue", fmt.Sprintf("target %s, poll %s, loop %s", out.TargetBranch, out.PollInterval, out.LoopInterval),
+		"see 'gt mq config "+r.Name+"' for every setting")
+	return res
+}
+
+// printMQResolve prints the resolution steps, one per line.
+func printMQResolve(arg string, res *MQResolveResult) {
+	what := "the current directory"
+	if arg != "" {
+		what = fmt.Sprintf("'%s'", arg)
+	}
+	fmt.Printf("%s Resolving rig for %s:\n\n", style.Bold.Render("⚙"), what)
+	for _, s := range res.Steps {
+		label := fmt.Sprintf("%-16s", s.Step+":")
+		if s.Error != "" {
+			fmt.Printf("  %s %s %s\n", style.Error.Render("✗"), label, s.Error)
+			continue
+		}
+		value := s.Value
+		if value == "" {
+			value = style.Dim.Render("-")
+		}
+		line := fmt.Sprintf("    %s %s", label, value)
+		if s.Reason != "" {
+			line += "  " + style.Dim.Render("("+s.Reason+")")
+		}
+		fmt.Println(line)
+	}
+}
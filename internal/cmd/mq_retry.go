@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// runMQRetryUntilSuccess merges an MR in the foreground, retrying on failure.
+func runMQRetryUntilSuccess(r *rig.Rig, mrID string) error {
+	if mqRetryMaxAttempts < 1 {
+		return fmt.Errorf("--max-attempts must be at least 1")
+	}
+
+	bd := beads.New(r.BeadsPath())
+	mr, err := bd.Show(mrID)
+	if err != nil {
+		if err == beads.ErrNotFound {
+			return fmt.Errorf("%w: '%s' in rig '%s'", refinery.ErrMRNotFound, mrID, r.Name)
+		}
+		return beadsQueryError("fetching merge request", err, r.BeadsPath())
+	}
+	if mr.Status == "closed" {
+		return fmt.Errorf("%w: '%s'; submit a new MR instead", refinery.ErrMRClosed, mrID)
+	}
+	if fields := beads.ParseMRFields(mr); fields != nil {
+		note, err := checkRetryWorker(r, fields.Worker, fields.Branch)
+		if err != nil {
+			return err
+		}
+		if note != "" {
+			style.PrintWarning("%s", note)
+		}
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		style.PrintWarning("could not load merge queue config, using defaults: %v", err)
+	}
+	eng.SetIgnoreMergeWindow(mqRetryNow)
+	if err := eng.CheckMergeWindow(); err != nil {
+		return fmt.Errorf("%w; use --now to merge anyway", err)
+	}
+
+	fmt.Printf("Retrying merge request %s until success (max %d attempts)\n", mrID, mqRetryMaxAttempts)
+	attempts := 0
+	return runInterruptible(func(ctx context.Context) error {
+		// Each attempt merges in the refinery's checkout, so it takes the
+		// processing lock; if a refinery cycle holds it, give up at once
+		// rather than record a failure the MR didn't have.
+		attemptCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		var lockErr error
+		var result refinery.ProcessResult
+		result, attempts = retryUntilSuccess(attemptCtx, func(ctx context.Context) refinery.ProcessResult {
+			unlock, err := eng.LockProcessing()
+			if err != nil {
+				lockErr = err
+				stop()
+				return refinery.ProcessResult{Error: err.Error()}
+			}
+			defer unlock()
+			return eng.ProcessMR(ctx, mr)
+		}, mqRetryMaxAttempts, mqRetryInterval, os.Stdout)
+		if lockErr != nil {
+			return fmt.Errorf("%w; try again when it finishes", lockErr)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		eng.FinishMR(mr, result)
+		if !result.Success {
+			return fmt.Errorf("merge request %s failed after %d attempt(s): %w", mrID, attempts, result.Err())
+		}
+
+		fmt.Printf("%s Merged %s on attempt %d\n", style.Bold.Render("✓"), mrID, attempts)
+		return nil
+	}, func() {
+		fmt.Printf("Interrupted after %d attempt(s); %s left as-is\n", attempts, mrID)
+	})
+}
+
+// MQEpicRetryResult is the outcome of retrying an epic's failed MRs.
+type MQEpicRetryResult struct {
+	Epic    string           `json:"epic"`
+	Retried []MQActionResult `json:"retried"`
+	Failed  []MQRetryFailure `json:"failed"`
+}
+
+// MQRetryFailure is an MR that could not be retried.
+type MQRetryFailure struct {
+	MRID   string `json:"mr_id"`
+	Branch string `json:"branch"`
+	Error  string `json:"error"`
+}
+
+// runMQRetryEpic retries every failed MR targeting an epic's integration
+// branch, reporting each and then a summary.
+func runMQRetryEpic(rigName, epic string) error {
+	if err := validateGlob("--epic", epic); err != nil {
+		return err
+	}
+
+	mgr, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+	if mqRetryJSON {
+		mgr.SetOutput(os.Stderr)
+	}
+
+	failed, err := mgr.FailedMRs()
+	if err != nil {
+		return fmt.Errorf("listing failed merge requests: %w", err)
+	}
+	var matched []*refinery.MergeRequest
+	for _, mr := range failed {
+		if epicTargetMatch(epic, mr.TargetBranch) {
+			matched = append(matched, mr)
+		}
+	}
+
+	result := MQEpicRetryResult{Epic: epic, Retried: []MQActionResult{}, Failed: []MQRetryFailure{}}
+	if len(matched) == 0 {
+		if mqRetryJSON {
+			return outputJSON(result)
+		}
+		fmt.Printf("%s No failed MRs targeting integration/%s\n", style.Dim.Render("○"), epic)
+		return nil
+	}
+
+	changes := make([]string, 0, len(matched))
+	for _, mr := range matched {
+		changes = append(changes, fmt.Sprintf("%s %s (%s)", mr.ID, mr.Branch, mr.Worker))
+	}
+	action := fmt.Sprintf("Retrying %d failed MR(s) targeting integration/%s", len(matched), epic)
+	if !confirmDestructive(action, changes, mqRetryYes) {
+		return errCanceled()
+	}
+
+	if !mqRetryJSON {
+		fmt.Printf("Retrying %d failed MR(s) targeting integration/%s:\n", len(matched), epic)
+	}
+	opts := refinery.RetryOptions{ProcessNow: mqRetryNow, Deprioritize: mqRetryDeprioritize}
+	var eligibleAt time.Time // the same for every MR: the rig's merge window
+	for _, mr := range matched {
+		note, err := checkRetryWorker(r, mr.Worker, mr.Branch)
+		if err == nil && mqRetryRevalidate {
+			err = revalidateMR(r, mr, true)
+		}
+		var eligible time.Time
+		if err == nil {
+			eligible, err = mgr.Retry(mr.ID, opts)
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, MQRetryFailure{MRID: mr.ID, Branch: mr.Branch, Error: err.Error()})
+			if !mqRetryJSON {
+				fmt.Printf("  %s %s %s: %v\n", style.Error.Render("✗"), mr.ID, mr.Branch, err)
+			}
+			continue
+		}
+
+		retried := MQActionResult{
+			Action:   "retry",
+			MRID:     mr.ID,
+			Branch:   mr.Branch,
+			Worker:   mr.Worker,
+			Status:   string(mr.Status),
+			IssueID:  mr.IssueID,
+			Priority: mr.Priority,
+		}
+		if updated, err := mgr.GetMR(mr.ID); err == nil {
+			retried.Status, retried.Priority = string(updated.Status), updated.Priority
+		}
+		if !eligible.IsZero() {
+			retried.EligibleAt = &eligible
+			eligibleAt = eligible
+		}
+		if note != "" {
+			retried.Warnings = []string{note}
+		}
+		result.Retried = append(result.Retried, retried)
+		if !mqRetryJSON {
+			fmt.Printf("  %s %s %s\n", style.Success.Render("✓"), mr.ID, mr.Branch)
+			if note != "" {
+				fmt.Printf("      %s\n", style.Dim.Render(note))
+			}
+		}
+	}
+
+	if mqRetryJSON {
+		if err := outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n%s Retried %d of %d; %d failed\n", style.Bold.Render("integration/"+epic+":"),
+			len(result.Retried), len(matched), len(result.Failed))
+		if !eligibleAt.IsZero() {
+			fmt.Printf("  %s\n", style.Dim.Render(retryEligibleNote(eligibleAt, time.Now())))
+		}
+	}
+	if len(result.Failed) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// revalidateMR runs the refinery's pre-merge gates against the MR branch's
+// current head before a retry, so a branch that changed since it failed
+// can't be requeued with a regression. Each gate is printed unless quiet;
+// the error names the gate that failed.
+func revalidateMR(r *rig.Rig, mr *refinery.MergeRequest, quiet bool) error {
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	eng.SetOutput(io.Discard)
+
+	// Gate output (git, test runs) is only shown if a gate fails
+	ctx, stop := interruptContext()
+	defer stop()
+	var log bytes.Buffer
+	target := mr.TargetBranch
+	if target == "" {
+		target = r.DefaultBranch()
+	}
+	results, err := eng.Revalidate(ctx, mr.Branch, target, &log)
+	if !quiet {
+		for _, g := range results {
+			if g.Passed {
+				fmt.Printf("  %s %s gate: %s\n", style.Success.Render("✓"), g.Gate, style.Dim.Render(g.Detail))
+			} else {
+				fmt.Printf("  %s %s gate: %s\n", style.Error.Render("✗"), g.Gate, g.Detail)
+			}
+		}
+	}
+	if err != nil {
+		if errors.Is(err, refinery.ErrGateFailed) && len(results) > 0 {
+			if !quiet && log.Len() > 0 {
+				tail, _ := tailLines(log.String(), 20)
+				fmt.Print(style.Dim.Render(tail))
+			}
+			failed := results[len(results)-1]
+			return fmt.Errorf("not retrying %s: %s gate failed: %s", mr.ID, failed.Gate, failed.Detail)
+		}
+		return fmt.Errorf("revalidating %s: %w", mr.ID, err)
+	}
+	return nil
+}
+
+// checkRetryWorker makes sure the MR's worker still exists before a retry.
+// A failed merge is handed back to the worker, so retrying for a removed
+// worker fails later in confusing ways. With --recreate a missing polecat is
+// recreated on the MR's branch (or a fresh one if the MR has none); with
+// --force the retry goes ahead. Returns a note for the user when the worker
+// was missing.
+func checkRetryWorker(r *rig.Rig, worker, branch string) (string, error) {
+	if worker == "" {
+		return "", nil
+	}
+	if _, err := workerWorktreePath(r, worker); err == nil {
+		return "", nil
+	}
+
+	switch {
+	case mqRetryRecreate:
+		mgr := polecat.NewManager(r, git.NewGit(r.Path))
+		var p *polecat.Polecat
+		var err error
+		if branch != "" {
+			p, err = mgr.AddFromBranch(worker, branch)
+		} else {
+			p, err = mgr.Add(worker)
+		}
+		if err != nil {
+			return "", fmt.Errorf("recreating worker '%s': %w", worker, err)
+		}
+		return fmt.Sprintf("worker '%s' no longer existed; recreated at %s", worker, p.ClonePath), nil
+	case mqRetryForce:
+		return fmt.Sprintf("worker '%s' no longer exists in rig '%s'; retrying anyway", worker, r.Name), nil
+	default:
+		return "", fmt.Errorf("worker '%s' no longer exists in rig '%s'; use --recreate to recreate it or --force to retry anyway",
+			worker, r.Name)
+	}
+}
+
+// retryUntilSuccess runs attempt up to maxAttempts times, waiting interval
+// between failures. It stops on the first success or when ctx is cancelled.
+// Returns the last result and the number of attempts made.
+func retryUntilSuccess(ctx context.Context, attempt func(context.Context) refinery.ProcessResult,
+	maxAttempts int, interval time.Duration, out io.Writer) (refinery.ProcessResult, int) {
+	var result refinery.ProcessResult
+	for n := 1; n <= maxAttempts; n++ {
+		_, _ = fmt.Fprintf(out, "Attempt %d/%d...\n", n, maxAttempts)
+		result = attempt(ctx)
+		if ctx.Err() != nil {
+			return result, n
+		}
+		if result.Success {
+			_, _ = fmt.Fprintf(out, "  %s attempt %d succeeded\n", style.Success.Render("✓"), n)
+			return result, n
+		}
+		_, _ = fmt.Fprintf(out, "  %s attempt %d failed: %s\n", style.Error.Render("✗"), n, result.Error)
+
+		if n == maxAttempts {
+			return result, n
+		}
+		_, _ = fmt.Fprintf(out, "  %s\n", style.Dim.Render(fmt.Sprintf("waiting %s before next attempt", interval)))
+		select {
+		case <-ctx.Done():
+			return result, n
+		case <-time.After(interval):
+		}
+	}
+	return result, maxAttempts
+}
+
+// MQRetryPlanResult is the JSON output of gt mq retry --dry-run.
+type MQRetryPlanResult struct {
+	*refinery.RetryPlan
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// runMQRetryDryRun previews retrying an MR without changing anything.
+func runMQRetryDryRun(mgr *refinery.Manager, r *rig.Rig, mrID string) error {
+	if mqRetryJSON {
+		mgr.SetOutput(os.Stderr)
+	}
+	plan, err := mgr.RetryPlan(mrID, refinery.RetryOptions{Deprioritize: mqRetryDeprioritize})
+	if err != nil {
+		if errors.Is(err, refinery.ErrMRNotFound) {
+			return fmt.Errorf("%w: '%s' in rig '%s'", err, mrID, r.Name)
+		}
+		return fmt.Errorf("planning retry: %w", err)
+	}
+
+	result := MQRetryPlanResult{RetryPlan: plan}
+	if mr, err := mgr.GetMR(mrID); err == nil && mr.Worker != "" {
+		if _, err := workerWorktreePath(r, mr.Worker); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"worker '%s' no longer exists; retry needs --recreate or --force", mr.Worker))
+		}
+	}
+
+	if mqRetryJSON {
+		if err := outputJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printRetryPlan(result, time.Now())
+	}
+	if plan.Refused != "" {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// printRetryPlan prints a retry preview.
+func printRetryPlan(result MQRetryPlanResult, now time.Time) {
+	plan := result.RetryPlan
+	fmt.Printf("Retry plan for %s %s\n", plan.MRID, style.Dim.Render("(dry run; nothing changed)"))
+	fmt.Printf("  Branch: %s\n", plan.Branch)
+	fmt.Printf("  Target: %s\n", plan.Target)
+	if plan.PreviousError != "" {
+		fmt.Printf("  Previous error: %s\n", style.Dim.Render(plan.PreviousError))
+	}
+	fmt.Println()
+
+	if plan.Refused != "" {
+		fmt.Printf("  %s retry would be refused: %s\n", style.Error.Render("✗"), plan.Refused)
+	} else {
+		fmt.Printf("  %s retry would requeue it at P%d\n", style.Success.Render("✓"), plan.Priority)
+	}
+	for _, w := range result.Warnings {
+		fmt.Printf("  %s %s\n", style.Warning.Render("⚠"), w)
+	}
+
+	switch {
+	case plan.BranchMissing:
+		fmt.Printf("  %s branch %s no longer exists; the merge would fail\n", style.Error.Render("✗"), plan.Branch)
+	case plan.NeedsRebase:
+		fmt.Printf("  %s needs a rebase: %d commit(s) behind %s, conflicting in %s\n",
+			style.Error.Render("✗"), plan.Behind, plan.Target, strings.Join(plan.Conflicts, ", "))
+	case plan.Behind > 0:
+		fmt.Printf("  %s %d commit(s) behind %s, but merges cleanly\n", style.Success.Render("✓"), plan.Behind, plan.Target)
+	default:
+		fmt.Printf("  %s up to date with %s\n", style.Success.Render("✓"), plan.Target)
+	}
+
+	if len(plan.Blockers) == 0 {
+		fmt.Printf("  %s nothing else blocks it\n", style.Success.Render("✓"))
+	}
+	for _, c := range plan.Blockers {
+		fmt.Printf("  %s %s: %s\n", style.Warning.Render("○"), c.Name, c.Detail)
+	}
+
+	backoff := "no earlier retries"
+	if plan.RetryCount > 0 {
+		backoff = fmt.Sprintf("retried %d time(s); scores %.0f lower in the queue", plan.RetryCount, plan.RetryPenalty)
+	}
+	fmt.Printf("  %s %s\n", style.Dim.Render("○"), backoff)
+	var eligible time.Time
+	if plan.EligibleAt != nil {
+		eligible = *plan.EligibleAt
+	}
+	fmt.Printf("  %s\n", style.Dim.Render(retryEligibleNote(eligible, now)))
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqSetTargetCmd = &cobra.Command{
+	Use:   "set-target <rig> <mr-id> <target-branch>",
+	Short: "Change the branch a merge request merges into",
+	Long: `Point a merge request at a different target branch.
+
+Use this to move an MR to another integration branch (or back to main)
+without resubmitting it. The target must already exist on origin and must
+not be a protected branch; otherwise the MR is left as it was.
+
+The MR keeps its place in the queue. 'gt mq list --epic' and the refinery
+use the new target from then on.
+
+Examples:
+  gt mq set-target greenplace gp-mr-abc123 integration/gp-auth
+  gt mq set-target greenplace gp-mr-abc123 main`,
+	Args: cobra.ExactArgs(3),
+	RunE: runMQSetTarget,
+}
+
+func init() {
+	mqCmd.AddCommand(mqSetTargetCmd)
+}
+
+func runMQSetTarget(cmd *cobra.Command, args []string) error {
+	rigName, mrID, target := args[0], args[1], args[2]
+
+	mgr, _, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+	if mrID, err = mgr.ResolveMRID(mrID); err != nil {
+		return err
+	}
+
+	old, err := mgr.SetTarget(mrID, target)
+	if err != nil {
+		switch {
+		case errors.Is(err, refinery.ErrMRNotFound):
+			return fmt.Errorf("%w: '%s' in rig '%s'", refinery.ErrMRNotFound, mrID, rigName)
+		case errors.Is(err, refinery.ErrMRAlreadyClosed):
+			return fmt.Errorf("cannot retarget: %w", err)
+		case errors.Is(err, refinery.ErrTargetNotFound):
+			return fmt.Errorf("cannot retarget %s: %w; push the branch first", mrID, err)
+		}
+		return fmt.Errorf("retargeting %s: %w", mrID, err)
+	}
+
+	if old == target {
+		fmt.Printf("%s %s already targets %s\n", style.Dim.Render("○"), mrID, target)
+		return nil
+	}
+	if old == "" {
+		old = "(default)"
+	}
+	fmt.Printf("%s Retargeted %s: %s -> %s\n", style.Bold.Render("✓"), mrID, old, target)
+	return nil
+}
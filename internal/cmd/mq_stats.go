@@ -0,0 +1,368 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ stats command flags
+var (
+	mqStatsByWorker bool
+	mqStatsHistory  bool
+	mqStatsSince    string
+	mqStatsJSON     bool
+)
+
+var mqStatsCmd = &cobra.Command{
+	Use:   "stats <rig>",
+	Short: "Show merge queue throughput",
+	Long: `Show merge queue throughput over a time window.
+
+Counts MRs merged, rejected and failed in the window (--since, default 7d)
+and the average merge latency: the time from submission to merge.
+
+With --by-worker, the same figures are broken down per worker, busiest
+first - a leaderboard for team leads.
+
+Outcomes are read from the timestamps the refinery records on each MR bead
+(merged_at, rejected_at, failed_at); MRs finished before the refinery
+recorded them are not counted. An MR that failed and later merged counts
+in both columns.
+
+The effective loop interval of 'gt mq process --loop'
+(merge_queue.loop_interval) is shown alongside. It does not pace the
+refinery patrol agent. If the refinery's last cycle failed, its error
+(last_error in --json) is shown too.
+
+With --history, the window is instead broken down per day (UTC) from the
+refinery's event log (the one 'gt mq tail' follows): merges, rejections,
+failed merge attempts and the failure rate (failed attempts over all
+attempts) for each day, including days with no activity. With --json this
+is a series ready for charting.
+
+Examples:
+  gt mq stats gastown
+  gt mq stats gastown --by-worker --since=30d
+  gt mq stats gastown --by-worker --json
+  gt mq stats gastown --history --since=7d --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQStats,
+}
+
+func init() {
+	mqStatsCmd.Flags().BoolVar(&mqStatsByWorker, "by-worker", false, "Break the figures down per worker")
+	mqStatsCmd.Flags().BoolVar(&mqStatsHistory, "history", false, "Break the window down per day from the event log")
+	mqStatsCmd.Flags().StringVar(&mqStatsSince, "since", "7d", "Time window (e.g. 24h, 7d)")
+	mqStatsCmd.Flags().BoolVar(&mqStatsJSON, "json", false, "Output as JSON")
+
+	mqCmd.AddCommand(mqStatsCmd)
+}
+
+// MQStats is merge queue throughput over a window.
+type MQStats struct {
+	Rig      string        `json:"rig"`
+	Since    time.Time     `json:"since"`
+	Total    WorkerStats   `json:"total"`
+	ByWorker []WorkerStats `json:"by_worker,omitempty"`
+
+	// LoopInterval is the effective wait between 'gt mq process --loop' cycles.
+	LoopInterval     time.Duration `json:"-"`
+	LoopIntervalSecs int64         `json:"loop_interval_seconds"`
+
+	// LastError is the refinery's persisted last cycle error, if it is
+	// failing (see gt mq healthcheck); LastErrorAt is when it was recorded.
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// WorkerStats counts one worker's MR outcomes in a window.
+type WorkerStats struct {
+	Worker   string `json:"worker,omitempty"`
+	Merged   int    `json:"merged"`
+	Rejected int    `json:"rejected"`
+	Failed   int    `json:"failed"`
+
+	// AvgMergeLatency is the mean submit-to-merge time of merged MRs.
+	AvgMergeLatency time.Duration `json:"-"`
+	AvgLatencySecs  int64         `json:"avg_merge_latency_seconds"`
+
+	latencyTotal time.Duration
+}
+
+func runMQStats(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	window, err := parseDuration(mqStatsSince)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid --since %q: use a duration like 24h or 7d", mqStatsSince)
+	}
+
+	r, err := getRigArg(rigName)
+	if err != nil {
+		return err
+	}
+
+	if mqStatsHistory {
+		if mqStatsByWorker {
+			return fmt.Errorf("--history and --by-worker can't be combined")
+		}
+		return runMQStatsHistory(r.Path, rigName, window)
+	}
+
+	b := beads.New(r.BeadsPath())
+	issues, err := b.List(beads.ListOptions{Type: "merge-request", Status: "all", Priority: -1})
+	if err != nil {
+		return beadsQueryError("querying merge requests", err, r.BeadsPath())
+	}
+
+	stats := computeMQStats(issues, time.Now().Add(-window))
+	stats.Rig = rigName
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil && !mqStatsJSON {
+		style.PrintWarning("could not load merge queue config, using defaults: %v", err)
+	}
+	stats.LoopInterval = eng.Config().EffectiveLoopInterval()
+	stats.LoopIntervalSecs = int64(stats.LoopInterval.Seconds())
+	if ref, err := refinery.NewManager(r).Status(); err == nil {
+		stats.LastError = ref.LastError
+		stats.LastErrorAt = ref.LastErrorAt
+	}
+	if !mqStatsByWorker {
+		stats.ByWorker = nil
+	} else if stats.ByWorker == nil {
+		stats.ByWorker = []WorkerStats{}
+	}
+
+	if mqStatsJSON {
+		return outputJSON(stats)
+	}
+	printMQStats(stats)
+	return nil
+}
+
+// computeMQStats tallies MR outcomes recorded at or after since, in total
+// and per worker (busiest first).
+func computeMQStats(issues []*beads.Issue, since time.Time) MQStats {
+	stats := MQStats{Since: since}
+	workers := make(map[string]*WorkerStats)
+
+	inWindow := func(ts string) bool {
+		t, err := time.Parse(time.RFC3339, ts)
+		return err == nil && !t.Before(since)
+	}
+
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil {
+			continue
+		}
+		worker := fields.Worker
+		if worker == "" {
+			worker = "(unknown)"
+		}
+		ws := workers[worker]
+		if ws == nil {
+			ws = &WorkerStats{Worker: worker}
+			workers[worker] = ws
+		}
+
+		if inWindow(fields.MergedAt) {
+			merged, _ := time.Parse(time.RFC3339, fields.MergedAt)
+			var latency time.Duration
+			if created, err := time.Parse(time.RFC3339, issue.CreatedAt); err == nil && merged.After(created) {
+				latency = merged.Sub(created)
+			}
+			for _, s := range []*WorkerStats{ws, &stats.Total} {
+				s.Merged++
+				s.latencyTotal += latency
+			}
+		}
+		if inWindow(fields.RejectedAt) {
+			ws.Rejected++
+			stats.Total.Rejected++
+		}
+		if inWindow(fields.FailedAt) {
+			ws.Failed++
+			stats.Total.Failed++
+		}
+	}
+
+	stats.Total.finish()
+	for _, ws := range workers {
+		if ws.Merged+ws.Rejected+ws.Failed == 0 {
+			continue
+		}
+		ws.finish()
+		stats.ByWorker = append(stats.ByWorker, *ws)
+	}
+	sort.Slice(stats.ByWorker, func(i, j int) bool {
+		a, b := stats.ByWorker[i], stats.ByWorker[j]
+		if a.Merged != b.Merged {
+			return a.Merged > b.Merged
+		}
+		return a.Worker < b.Worker
+	})
+	return stats
+}
+
+// finish computes the average latency from the accumulated total.
+func (s *WorkerStats) finish() {
+	if s.Merged > 0 {
+		s.AvgMergeLatency = s.latencyTotal / time.Duration(s.Merged)
+	}
+	s.AvgLatencySecs = int64(s.AvgMergeLatency.Seconds())
+}
+
+func printMQStats(stats MQStats) {
+	fmt.Printf("%s Merge queue stats for '%s' since %s:\n\n",
+		style.Bold.Render("📊"), stats.Rig, stats.Since.Format("2006-01-02 15:04"))
+
+	if stats.ByWorker == nil {
+		fmt.Printf("  Merged:        %d\n", stats.Total.Merged)
+		fmt.Printf("  Rejected:      %d\n", stats.Total.Rejected)
+		fmt.Printf("  Failed:        %d\n", stats.Total.Failed)
+		fmt.Printf("  Avg latency:   %s\n", formatLatency(stats.Total))
+		fmt.Printf("  Loop interval: %s\n", stats.LoopInterval)
+		printMQStatsLastError(stats)
+		return
+	}
+
+	table := style.NewTable(
+		style.Column{Name: "WORKER", Width: 16},
+		style.Column{Name: "MERGED", Width: 7, Align: style.AlignRight},
+		style.Column{Name: "REJECTED", Width: 9, Align: style.AlignRight},
+		style.Column{Name: "FAILED", Width: 7, Align: style.AlignRight},
+		style.Column{Name: "AVG LATENCY", Width: 12, Align: style.AlignRight},
+	)
+	for _, ws := range append(stats.ByWorker, stats.Total) {
+		name := ws.Worker
+		if name == "" {
+			name = style.Bold.Render("total")
+		}
+		table.AddRow(name, strconv.Itoa(ws.Merged), strconv.Itoa(ws.Rejected),
+			strconv.Itoa(ws.Failed), formatLatency(ws))
+	}
+	fmt.Print(table.Render())
+	fmt.Printf("\n  %s\n", style.Dim.Render(fmt.Sprintf("Loop interval (gt mq process --loop): %s", stats.LoopInterval)))
+	printMQStatsLastError(stats)
+}
+
+// printMQStatsLastError warns that the refinery is failing, if it is.
+func printMQStatsLastError(stats MQStats) {
+	if stats.LastError == "" {
+		return
+	}
+	ref := &refinery.Refinery{LastError: stats.LastError, LastErrorAt: stats.LastErrorAt}
+	fmt.Printf("\n%s refinery is failing: %s\n", style.Error.Render("⚠"), formatRefineryError(ref))
+}
+
+// MQStatsHistory is merge queue activity per day over a window.
+type MQStatsHistory struct {
+	Rig   string       `json:"rig"`
+	Since time.Time    `json:"since"`
+	Days  []MQStatsDay `json:"days"`
+}
+
+// MQStatsDay counts merge queue events on one UTC day.
+type MQStatsDay struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	Merged   int    `json:"merged"`
+	Rejected int    `json:"rejected"`
+	Failed   int    `json:"failed"`
+
+	// FailureRate is failed merge attempts over all attempts (failed plus
+	// merged), 0 on a day without attempts.
+	FailureRate float64 `json:"failure_rate"`
+}
+
+func runMQStatsHistory(rigPath, rigName string, window time.Duration) error {
+	events, _, err := mrqueue.NewEventLoggerFromRig(rigPath).ReadEvents(0)
+	if err != nil {
+		return fmt.Errorf("reading merge queue events: %w", err)
+	}
+
+	now := time.Now()
+	history := MQStatsHistory{
+		Rig:   rigName,
+		Since: now.Add(-window),
+		Days:  computeMQHistory(events, now.Add(-window), now),
+	}
+	if mqStatsJSON {
+		return outputJSON(history)
+	}
+
+	fmt.Printf("%s Merge queue history for '%s' since %s:\n\n",
+		style.Bold.Render("📊"), rigName, history.Since.Format("2006-01-02 15:04"))
+	table := style.NewTable(
+		style.Column{Name: "DATE", Width: 10},
+		style.Column{Name: "MERGED", Width: 7, Align: style.AlignRight},
+		style.Column{Name: "REJECTED", Width: 9, Align: style.AlignRight},
+		style.Column{Name: "FAILED", Width: 7, Align: style.AlignRight},
+		style.Column{Name: "FAILURE RATE", Width: 12, Align: style.AlignRight},
+	)
+	for _, day := range history.Days {
+		rate := "-"
+		if day.Merged+day.Failed > 0 {
+			rate = fmt.Sprintf("%.0f%%", day.FailureRate*100)
+		}
+		table.AddRow(day.Date, strconv.Itoa(day.Merged), strconv.Itoa(day.Rejected),
+			strconv.Itoa(day.Failed), rate)
+	}
+	fmt.Print(table.Render())
+	return nil
+}
+
+// computeMQHistory buckets the merged, rejected and merge_failed events
+// logged between since and now by UTC day. Every day in the window gets a
+// bucket, oldest first, so the series has no gaps.
+func computeMQHistory(events []mrqueue.Event, since, now time.Time) []MQStatsDay {
+	day := func(t time.Time) time.Time {
+		return t.UTC().Truncate(24 * time.Hour)
+	}
+	first := day(since)
+	days := []MQStatsDay{}
+	for d := first; !d.After(day(now)); d = d.AddDate(0, 0, 1) {
+		days = append(days, MQStatsDay{Date: d.Format("2006-01-02")})
+	}
+
+	for _, e := range events {
+		if e.Timestamp.Before(since) || e.Timestamp.After(now) {
+			continue
+		}
+		i := int(day(e.Timestamp).Sub(first) / (24 * time.Hour))
+		if i < 0 || i >= len(days) {
+			continue
+		}
+		switch e.Type {
+		case mrqueue.EventMerged:
+			days[i].Merged++
+		case mrqueue.EventRejected:
+			days[i].Rejected++
+		case mrqueue.EventMergeFailed:
+			days[i].Failed++
+		}
+	}
+
+	for i := range days {
+		if attempts := days[i].Merged + days[i].Failed; attempts > 0 {
+			days[i].FailureRate = float64(days[i].Failed) / float64(attempts)
+		}
+	}
+	return days
+}
+
+// formatLatency renders a worker's average merge latency, or "-" with no merges.
+func formatLatency(ws WorkerStats) string {
+	if ws.Merged == 0 {
+		return "-"
+	}
+	return formatDuration(ws.AvgMergeLatency)
+}
@@ -1,7 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,21 +10,29 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hosting"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
 // MRStatusOutput is the JSON output structure for gt mq status.
 type MRStatusOutput struct {
 	// Core issue fields
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	Priority  int    `json:"priority"`
-	Type      string `json:"type"`
-	Assignee  string `json:"assignee,omitempty"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	ClosedAt  string `json:"closed_at,omitempty"`
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  int      `json:"priority"`
+	Type      string   `json:"type"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	ClosedAt  string   `json:"closed_at,omitempty"`
+
+	// AgeSeconds is the time since created_at (-1 if unknown)
+	AgeSeconds int64 `json:"age_seconds"`
 
 	// MR-specific fields
 	Branch      string `json:"branch,omitempty"`
@@ -34,9 +43,32 @@ type MRStatusOutput struct {
 	MergeCommit string `json:"merge_commit,omitempty"`
 	CloseReason string `json:"close_reason,omitempty"`
 
+	// Rejection details
+	RejectCategory string `json:"reject_category,omitempty"`
+	RejectReason   string `json:"reject_reason,omitempty"`
+	RejectThread   string `json:"reject_thread,omitempty"`
+	SupersededBy   string `json:"superseded_by,omitempty"`
+	RejectForcedBy string `json:"reject_forced_by,omitempty"`
+
 	// Dependencies
 	DependsOn []DependencyInfo `json:"depends_on,omitempty"`
 	Blocks    []DependencyInfo `json:"blocks,omitempty"`
+
+	// Operator notes (gt mq note), oldest first
+	Notes []beads.IssueComment `json:"notes,omitempty"`
+
+	// Readiness is the refinery's merge checklist for open MRs
+	Readiness *refinery.Readiness `json:"readiness,omitempty"`
+
+	// Signature is the verification of the branch's head commit signature
+	// (merge_queue.signature_policy), for open MRs
+	Signature *refinery.SignatureCheck `json:"signature,omitempty"`
+
+	// PullRequest is the live pull request from the rig's hosting provider
+	// (the rig's "hosting" config), when one is configured. HostingError
+	// says why it couldn't be fetched; the local record is still shown.
+	PullRequest  *hosting.PullRequest `json:"pull_request,omitempty"`
+	HostingError string               `json:"hosting_error,omitempty"`
 }
 
 // DependencyInfo represents a dependency or blocker.
@@ -61,13 +93,23 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 	// Initialize beads client
 	bd := beads.New(workDir)
 
-	// Fetch the issue
+	// Fetch the issue, expanding an ID prefix as shown by 'gt mq list'
 	issue, err := bd.Show(mrID)
+	if err == beads.ErrNotFound {
+		full, resolveErr := refinery.ResolveMRIDIn(bd, mrID)
+		if resolveErr != nil {
+			return resolveErr
+		}
+		if full != mrID {
+			mrID = full
+			issue, err = bd.Show(mrID)
+		}
+	}
 	if err != nil {
 		if err == beads.ErrNotFound {
 			return fmt.Errorf("merge request '%s' not found", mrID)
 		}
-		return fmt.Errorf("fetching merge request: %w", err)
+		return beadsQueryError("fetching merge request", err, workDir)
 	}
 
 	// Parse MR-specific fields from description
@@ -81,10 +123,13 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		Priority:  issue.Priority,
 		Type:      issue.Type,
 		Assignee:  issue.Assignee,
+		Labels:    issue.Labels,
 		CreatedAt: issue.CreatedAt,
 		UpdatedAt: issue.UpdatedAt,
 		ClosedAt:  issue.ClosedAt,
+		Notes:     issue.Comments,
 	}
+	output.AgeSeconds = newMRListItem(issue).AgeSeconds
 
 	// Add MR fields if present
 	if mrFields != nil {
@@ -95,6 +140,11 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		output.Rig = mrFields.Rig
 		output.MergeCommit = mrFields.MergeCommit
 		output.CloseReason = mrFields.CloseReason
+		output.RejectCategory = mrFields.RejectCategory
+		output.RejectReason = mrFields.RejectReason
+		output.RejectThread = mrFields.RejectThread
+		output.SupersededBy = mrFields.SupersededBy
+		output.RejectForcedBy = mrFields.RejectForcedBy
 	}
 
 	// Add dependency info from the issue's Dependencies field
@@ -119,15 +169,147 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		})
 	}
 
+	// Ask the rig's refinery why the MR is (or isn't) ready to merge, and
+	// the hosting provider for the live PR. Best-effort: status still works
+	// outside a rig workspace, and with no provider configured.
+	if mrFields != nil && mrFields.Rig != "" {
+		if mgr, r, _, err := getRefineryManager(mrFields.Rig); err == nil {
+			if issue.Status != "closed" {
+				if readiness, err := mgr.Readiness(issue.ID); err == nil {
+					output.Readiness = readiness
+				}
+				if mrFields.Branch != "" {
+					if check, err := mgr.CheckSignature(mrFields.Branch); err == nil {
+						output.Signature = check
+					}
+				}
+			}
+			if mrFields.Branch != "" {
+				pr, err := fetchHostingPR(r, mrFields.Branch)
+				switch {
+				case err == nil:
+					output.PullRequest = pr
+				case !errors.Is(err, hosting.ErrNotConfigured):
+					output.HostingError = err.Error()
+				}
+			}
+		}
+	}
+
 	// JSON output
 	if mqStatusJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(output)
+		return outputJSON(output)
 	}
 
 	// Human-readable output
-	return printMqStatus(issue, mrFields)
+	if err := printMqStatus(issue, mrFields); err != nil {
+		return err
+	}
+	printMRSignature(output.Signature)
+	printMRReadiness(output.Readiness)
+	printMRPullRequest(output.PullRequest, output.HostingError)
+	return nil
+}
+
+// fetchHostingPR fetches branch's pull request from the rig's hosting
+// provider. Returns hosting.ErrNotConfigured when the rig has none.
+func fetchHostingPR(r *rig.Rig, branch string) (*hosting.PullRequest, error) {
+	rigCfg, err := rig.LoadRigConfig(r.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, hosting.ErrNotConfigured
+		}
+		return nil, fmt.Errorf("reading rig config: %w", err)
+	}
+	provider, err := hosting.New(rigCfg.Hosting)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hosting.DefaultTimeout)
+	defer cancel()
+	return provider.PullRequest(ctx, branch)
+}
+
+// printMRPullRequest prints the live pull request from the hosting
+// provider, or why it couldn't be fetched. Prints nothing when no provider
+// is configured.
+func printMRPullRequest(pr *hosting.PullRequest, hostingErr string) {
+	if hostingErr != "" {
+		fmt.Printf("\n%s\n   %s %s\n", style.Bold.Render("Pull Request"),
+			style.Warning.Render("⚠"), style.Dim.Render("unavailable: "+hostingErr))
+		return
+	}
+	if pr == nil {
+		return
+	}
+	state := pr.State
+	if pr.Draft {
+		state += " (draft)"
+	}
+	fmt.Printf("\n%s %s\n", style.Bold.Render("Pull Request"), style.Dim.Render("(live from "+pr.Provider+")"))
+	fmt.Printf("   #%d %s\n", pr.Number, pr.URL)
+	fmt.Printf("   State:     %s\n", state)
+	fmt.Printf("   CI:        %s\n", hostingStatus(pr.CI, hosting.CIPass, hosting.CIFail))
+	approvals := fmt.Sprintf("%d", pr.Approvals)
+	if pr.ChangesRequested {
+		approvals += " " + style.Error.Render("(changes requested)")
+	}
+	fmt.Printf("   Approvals: %s\n", approvals)
+	fmt.Printf("   Mergeable: %s\n", hostingStatus(pr.Mergeable, hosting.MergeableReady, hosting.MergeableConflict))
+}
+
+// hostingStatus colors a provider status: good green, bad red, else dim.
+func hostingStatus(status, good, bad string) string {
+	switch status {
+	case good:
+		return style.Success.Render(status)
+	case bad:
+		return style.Error.Render(status)
+	default:
+		return style.Dim.Render(status)
+	}
+}
+
+// printMRSignature prints the signature verification of an MR's head commit.
+func printMRSignature(c *refinery.SignatureCheck) {
+	if c == nil {
+		return
+	}
+	icon := style.Success.Render("✓")
+	switch {
+	case c.Policy == "" || c.Policy == config.SignaturePolicyOff:
+		icon = style.Dim.Render("○")
+	case !c.Trusted:
+		icon = style.Error.Render("✗")
+	}
+	line := fmt.Sprintf("   %s %s", icon, c.Detail)
+	if c.Signer != "" {
+		line += " " + style.Dim.Render("("+c.Signer+")")
+	}
+	fmt.Printf("\n%s %s\n%s\n", style.Bold.Render("Signature"), style.Dim.Render("(signature_policy: "+c.Policy+")"), line)
+}
+
+// printMRReadiness prints the refinery's merge checklist for an MR.
+func printMRReadiness(r *refinery.Readiness) {
+	if r == nil {
+		return
+	}
+	verdict := style.Success.Render("ready to merge")
+	if !r.Ready {
+		verdict = style.Warning.Render("not ready")
+	}
+	fmt.Printf("\n%s %s\n", style.Bold.Render("Readiness"), style.Dim.Render("(")+verdict+style.Dim.Render(")"))
+	for _, c := range r.Checks {
+		icon := style.Success.Render("✓")
+		if !c.OK {
+			icon = style.Error.Render("✗")
+		}
+		line := fmt.Sprintf("   %s %s", icon, c.Name)
+		if c.Detail != "" {
+			line += " " + style.Dim.Render("- "+c.Detail)
+		}
+		fmt.Println(line)
+	}
 }
 
 // printMqStatus prints detailed MR status in human-readable format.
@@ -147,6 +329,9 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 	if issue.Assignee != "" {
 		fmt.Printf("   Assignee: %s\n", issue.Assignee)
 	}
+	if len(issue.Labels) > 0 {
+		fmt.Printf("   Labels:   %s\n", strings.Join(issue.Labels, ", "))
+	}
 
 	// Timestamps
 	fmt.Printf("\n%s\n", style.Bold.Render("Timeline"))
@@ -178,12 +363,32 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		if mrFields.Rig != "" {
 			fmt.Printf("   Rig:          %s\n", mrFields.Rig)
 		}
+		if mrFields.Draft {
+			fmt.Printf("   Draft:        %s\n", style.Warning.Render("yes (skipped by the refinery until 'gt mq ready')"))
+		}
 		if mrFields.MergeCommit != "" {
 			fmt.Printf("   Merge Commit: %s\n", mrFields.MergeCommit)
 		}
 		if mrFields.CloseReason != "" {
 			fmt.Printf("   Close Reason: %s\n", mrFields.CloseReason)
 		}
+		if mrFields.RejectReason != "" {
+			fmt.Printf("   Rejected:     %s\n", mrFields.RejectReason)
+		}
+		if mrFields.RejectCategory != "" {
+			fmt.Printf("   Category:     %s\n", mrFields.RejectCategory)
+		}
+		if mrFields.RejectThread != "" {
+			fmt.Printf("   Thread:       %s %s\n", mrFields.RejectThread,
+				style.Dim.Render("(gt mail thread "+mrFields.RejectThread+")"))
+		}
+		if mrFields.SupersededBy != "" {
+			fmt.Printf("   Superseded:   %s %s\n", mrFields.SupersededBy,
+				style.Dim.Render("(gt mq status "+mrFields.SupersededBy+")"))
+		}
+		if mrFields.RejectForcedBy != "" {
+			fmt.Printf("   Forced by:    %s %s\n", mrFields.RejectForcedBy, style.Dim.Render("(--force)"))
+		}
 	}
 
 	// Dependencies (what this MR is waiting on)
@@ -222,6 +427,8 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		}
 	}
 
+	printMRNotes(issue.Comments)
+
 	return nil
 }
 
@@ -12,6 +12,9 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mq"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -58,16 +61,41 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
-	// Find current rig
-	rigName, _, err := findCurrentRig(townRoot)
-	if err != nil {
+	if mqSubmitTarget != "" && mqSubmitEpic != "" {
+		return fmt.Errorf("--target and --epic are mutually exclusive")
+	}
+	if err := validateMRLabels(mqSubmitLabels); err != nil {
 		return err
 	}
 
-	// Initialize git for the current directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("getting current directory: %w", err)
+	// Find rig: explicit argument, or detected from current directory
+	var rigName string
+	var r *rig.Rig
+	if len(args) > 0 {
+		_, r, err = getRig(args[0])
+		if err != nil {
+			return err
+		}
+		rigName = r.Name
+	} else {
+		rigName, r, err = findCurrentRig(townRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Work from the worker's worktree if --worker is given, else the current directory
+	var cwd string
+	if mqSubmitWorker != "" {
+		cwd, err = workerWorktreePath(r, mqSubmitWorker)
+		if err != nil {
+			return err
+		}
+	} else {
+		cwd, err = os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current directory: %w", err)
+		}
 	}
 	g := git.NewGit(cwd)
 
@@ -99,6 +127,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		issueID = info.Issue
 	}
 	worker := info.Worker
+	if mqSubmitWorker != "" {
+		worker = mqSubmitWorker
+	}
 
 	if issueID == "" {
 		return fmt.Errorf("cannot determine source issue from branch '%s'; use --issue to specify", branch)
@@ -109,7 +140,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 
 	// Determine target branch
 	target := defaultBranch
-	if mqSubmitEpic != "" {
+	if mqSubmitTarget != "" {
+		target = mqSubmitTarget
+	} else if mqSubmitEpic != "" {
 		// Explicit --epic flag takes precedence
 		target = "integration/" + mqSubmitEpic
 	} else {
@@ -123,6 +156,12 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// The refinery pushes merges straight to the target, which the host
+	// rejects for protected branches; fail before queueing doomed work.
+	if err := r.CheckBranchUnprotected(target); err != nil {
+		return fmt.Errorf("cannot submit to %s: %w", target, err)
+	}
+
 	// Get source issue for priority inheritance
 	var priority int
 	if mqSubmitPriority >= 0 {
@@ -147,16 +186,28 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create MR bead (ephemeral wisp - will be cleaned up after merge)
-	mrIssue, err := bd.Create(beads.CreateOptions{
+	mrIssue, err := createMRBead(bd, r, branch, beads.CreateOptions{
 		Title:       title,
 		Type:        "merge-request",
 		Priority:    priority,
 		Description: description,
+		Labels:      mqSubmitLabels,
 	})
 	if err != nil {
-		return fmt.Errorf("creating merge request bead: %w", err)
+		return beadsQueryError("creating merge request bead", err, cwd)
 	}
 
+	// Best-effort: the event log only feeds watchers (gt mq tail)
+	_ = mrqueue.NewEventLoggerFromRig(r.Path).LogEvent(mrqueue.Event{
+		Type:        mrqueue.EventQueued,
+		MRID:        mrIssue.ID,
+		Branch:      branch,
+		Target:      target,
+		Worker:      worker,
+		SourceIssue: issueID,
+		Rig:         rigName,
+	})
+
 	// Success output
 	fmt.Printf("%s Submitted to merge queue\n", style.Bold.Render("✓"))
 	fmt.Printf("  MR ID: %s\n", style.Bold.Render(mrIssue.ID))
@@ -167,10 +218,16 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Worker: %s\n", worker)
 	}
 	fmt.Printf("  Priority: P%d\n", priority)
+	if len(mqSubmitLabels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(mqSubmitLabels, ", "))
+	}
 
-	// Auto-cleanup for polecats: if this is a polecat branch and cleanup not disabled,
-	// send lifecycle request and wait for termination
-	if worker != "" && !mqSubmitNoCleanup {
+	// Auto-cleanup for polecats: if this is a polecat submitting its own
+	// branch and cleanup not disabled, send lifecycle request and wait for
+	// termination. With --worker an operator is submitting on the worker's
+	// behalf from their own shell, which must neither retire the worker nor
+	// block waiting for it.
+	if worker != "" && mqSubmitWorker == "" && !mqSubmitNoCleanup {
 		fmt.Println()
 		fmt.Printf("%s Auto-cleanup: polecat work submitted\n", style.Bold.Render("✓"))
 		if err := polecatCleanup(rigName, worker, townRoot); err != nil {
@@ -185,6 +242,16 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// workerWorktreePath returns the worktree directory for a worker in a rig,
+// searching the rig's worker roots in order (polecats, then crew, unless
+// the rig config sets worker_roots).
+func workerWorktreePath(r *rig.Rig, worker string) (string, error) {
+	if path := r.WorkerDir(worker); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("worker '%s' not found in rig '%s'", worker, r.Name)
+}
+
 // detectIntegrationBranch checks if an issue is a child of an epic that has an integration branch.
 // Returns the integration branch target (e.g., "integration/gt-epic") if found, or "" if not.
 func detectIntegrationBranch(bd *beads.Beads, g *git.Git, issueID string) (string, error) {
@@ -284,3 +351,18 @@ Please verify state and execute lifecycle action.
 		}
 	}
 }
+
+// createMRBead creates an MR bead. If the rig sets merge_queue.mr_id_prefix
+// the bead is named <prefix>-mr-<hash>; otherwise beads assigns the ID as
+// for any other bead.
+func createMRBead(bd *beads.Beads, r *rig.Rig, branch string, opts beads.CreateOptions) (*beads.Issue, error) {
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		style.PrintWarning("could not load merge queue config, using default MR IDs: %v", err)
+		return bd.Create(opts)
+	}
+	if prefix := eng.Config().MRIDPrefix; prefix != "" {
+		return bd.CreateWithID(mq.GenerateMRID(prefix, branch), opts)
+	}
+	return bd.Create(opts)
+}
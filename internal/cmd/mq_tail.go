@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ tail command flags
+var (
+	mqTailLines    int
+	mqTailInterval time.Duration
+)
+
+var mqTailCmd = &cobra.Command{
+	Use:   "tail <rig>",
+	Short: "Stream merge queue events as they happen",
+	Long: `Stream merge queue events as they happen, one line each.
+
+Shows the last --lines events, then follows the rig's merge queue event log
+(.beads/mq_events.jsonl) like 'tail -f': MRs queued, merges started,
+merged, failed, skipped and rejected. Press Ctrl-C to stop.
+
+Examples:
+  gt mq tail gastown
+  gt mq tail gastown --lines=0    # Only new events`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQTail,
+}
+
+func init() {
+	mqTailCmd.Flags().IntVarP(&mqTailLines, "lines", "n", 10, "Number of past events to show first")
+	mqTailCmd.Flags().DurationVar(&mqTailInterval, "interval", time.Second, "How often to check for new events")
+
+	mqCmd.AddCommand(mqTailCmd)
+}
+
+func runMQTail(cmd *cobra.Command, args []string) error {
+	r, err := getRigArg(args[0])
+	if err != nil {
+		return err
+	}
+	if mqTailInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	logger := mrqueue.NewEventLoggerFromRig(r.Path)
+	past, offset, err := logger.ReadEvents(0)
+	if err != nil {
+		return err
+	}
+	if mqTailLines >= 0 && len(past) > mqTailLines {
+		past = past[len(past)-mqTailLines:]
+	}
+	for _, event := range past {
+		fmt.Println(formatMQEvent(event))
+	}
+
+	return runInterruptible(func(ctx context.Context) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(mqTailInterval):
+			}
+
+			events, next, err := logger.ReadEvents(offset)
+			if err != nil {
+				return err
+			}
+			offset = next
+			for _, event := range events {
+				fmt.Println(formatMQEvent(event))
+			}
+		}
+	}, nil)
+}
+
+// formatMQEvent renders a merge queue event as one styled line.
+func formatMQEvent(e mrqueue.Event) string {
+	var label string
+	switch e.Type {
+	case mrqueue.EventMerged:
+		label = style.Success.Render(fmt.Sprintf("%-13s", e.Type))
+	case mrqueue.EventMergeFailed, mrqueue.EventRejected:
+		label = style.Error.Render(fmt.Sprintf("%-13s", e.Type))
+	case mrqueue.EventMergeStarted:
+		label = style.Bold.Render(fmt.Sprintf("%-13s", e.Type))
+	default:
+		label = style.Dim.Render(fmt.Sprintf("%-13s", e.Type))
+	}
+
+	line := fmt.Sprintf("%s %s %s %s → %s", style.Dim.Render(e.Timestamp.Local().Format("15:04:05")),
+		label, e.MRID, e.Branch, e.Target)
+	if e.Worker != "" {
+		line += " " + style.Dim.Render("("+e.Worker+")")
+	}
+	switch {
+	case e.MergeCommit != "":
+		line += " " + shortSHA(e.MergeCommit)
+		if len(e.Batch) > 1 {
+			line += " " + style.Dim.Render(fmt.Sprintf("[batch: %s]", strings.Join(e.Batch, ", ")))
+		}
+	case e.Reason != "":
+		line += ": " + e.Reason
+	}
+	if e.SupersededBy != "" {
+		line += " " + style.Dim.Render("(superseded by "+e.SupersededBy+")")
+	}
+	return line
+}
@@ -1,9 +1,32 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hosting"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tui/triage"
 )
 
 func TestAddIntegrationBranchField(t *testing.T) {
@@ -101,6 +124,306 @@ func TestParseBranchName(t *testing.T) {
 	}
 }
 
+func TestWorkerWorktreePath(t *testing.T) {
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	for _, dir := range []string{"polecats/Nux", "crew/max"} {
+		if err := os.MkdirAll(filepath.Join(r.Path, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		worker  string
+		want    string
+		wantErr bool
+	}{
+		{worker: "Nux", want: filepath.Join(r.Path, "polecats", "Nux")},
+		{worker: "max", want: filepath.Join(r.Path, "crew", "max")},
+		{worker: "ghost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.worker, func(t *testing.T) {
+			got, err := workerWorktreePath(r, tt.worker)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("workerWorktreePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("workerWorktreePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMqSubmit_WorkerFlagSkipsCleanup(t *testing.T) {
+	townRoot := setupTestTownForCrewList(t, map[string][]string{"gastown": nil})
+	worktree := filepath.Join(townRoot, "gastown", "polecats", "Nux")
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"checkout", "-q", "-b", "polecat/Nux/gt-1"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = worktree
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	// Fake bd creates the MR; fake gt logs any lifecycle mail (and fails,
+	// so a regression can't block waiting for retirement)
+	binDir := t.TempDir()
+	gtLog := filepath.Join(binDir, "gt.log")
+	scripts := map[string]string{
+		"bd": `#!/bin/sh
+case "$*" in
+  *create*) printf '%s' '{"id":"gt-mr-1","title":"Merge: gt-1","issue_type":"merge-request","status":"open"}' ;;
+  *) exit 1 ;;
+esac
+`,
+		"gt": "#!/bin/sh\necho \"$@\" >> " + gtLog + "\nexit 1\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Chdir(townRoot)
+
+	defer func(w string) { mqSubmitWorker = w }(mqSubmitWorker)
+	mqSubmitWorker = "Nux"
+	var err error
+	out := captureStdout(t, func() {
+		err = runMqSubmit(mqSubmitCmd, []string{"gastown"})
+	})
+	if err != nil {
+		t.Fatalf("runMqSubmit --worker: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "gt-mr-1") {
+		t.Errorf("output = %q, want the MR submitted", out)
+	}
+	if strings.Contains(out, "Auto-cleanup") {
+		t.Errorf("output = %q, want no auto-cleanup with --worker", out)
+	}
+	if data, err := os.ReadFile(gtLog); err == nil {
+		t.Errorf("--worker sent the worker a lifecycle request: %s", data)
+	}
+}
+
+func TestWithSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{
+			name: "object",
+			data: struct {
+				ID string `json:"id"`
+			}{ID: "gt-mr1"},
+			want: `{"schema_version":1,"id":"gt-mr1"}`,
+		},
+		{
+			name: "empty object",
+			data: struct{}{},
+			want: `{"schema_version":1}`,
+		},
+		{
+			name: "list of objects",
+			data: []struct {
+				ID string `json:"id"`
+			}{{ID: "gt-mr1"}, {ID: "gt-mr2"}},
+			want: `[{"schema_version":1,"id":"gt-mr1"},{"schema_version":1,"id":"gt-mr2"}]`,
+		},
+		{
+			name: "list of strings",
+			data: []string{"a", "b"},
+			want: `["a","b"]`,
+		},
+		{
+			name: "empty list",
+			data: []string{},
+			want: `[]`,
+		},
+		{
+			name: "nil list",
+			data: []string(nil),
+			want: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withSchemaVersion(tt.data)
+			if err != nil {
+				t.Fatalf("withSchemaVersion() error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("withSchemaVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageLimit(t *testing.T) {
+	tests := []struct {
+		total, limit int
+		wantShown    int
+		wantMore     bool
+	}{
+		{total: 5, limit: 0, wantShown: 5},
+		{total: 5, limit: 10, wantShown: 5},
+		{total: 5, limit: 5, wantShown: 5},
+		{total: 5, limit: 2, wantShown: 2, wantMore: true},
+		{total: 0, limit: 2, wantShown: 0},
+	}
+	for _, tt := range tests {
+		shown, more := pageLimit(tt.total, tt.limit)
+		if shown != tt.wantShown || more != tt.wantMore {
+			t.Errorf("pageLimit(%d, %d) = %d, %v; want %d, %v", tt.total, tt.limit, shown, more, tt.wantShown, tt.wantMore)
+		}
+	}
+}
+
+func TestMRListPage_JSON(t *testing.T) {
+	got, err := withSchemaVersion(MRListPage{Items: []MRListItem{}, Total: 3, Limit: 0})
+	if err != nil {
+		t.Fatalf("withSchemaVersion() error: %v", err)
+	}
+	want := `{"schema_version":1,"items":[],"total":3,"limit":0,"has_more":false}`
+	if string(got) != want {
+		t.Errorf("MRListPage JSON = %s, want %s", got, want)
+	}
+}
+
+// flushCounter records writes and flushes, like a bufio.Writer would see them.
+type flushCounter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushCounter) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestOutputJSONLine(t *testing.T) {
+	var w flushCounter
+	for _, id := range []string{"gt-mr-1", "gt-mr-2"} {
+		if err := outputJSONLine(&w, MRListItem{Issue: &beads.Issue{ID: id}, Draft: id == "gt-mr-2"}); err != nil {
+			t.Fatalf("outputJSONLine: %v", err)
+		}
+	}
+	if w.flushes != 2 {
+		t.Errorf("flushes = %d, want one per line", w.flushes)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), w.String())
+	}
+	for i, line := range lines {
+		var item struct {
+			SchemaVersion int    `json:"schema_version"`
+			ID            string `json:"id"`
+			Draft         bool   `json:"draft"`
+		}
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", i, err, line)
+		}
+		if item.SchemaVersion != JSONSchemaVersion || item.ID != fmt.Sprintf("gt-mr-%d", i+1) || item.Draft != (i == 1) {
+			t.Errorf("line %d = %+v", i, item)
+		}
+	}
+}
+
+func TestMQListOutputFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		json    bool
+		want    string
+		wantErr bool
+	}{
+		{format: "", want: "plain"},
+		{format: "table", want: "table"},
+		{format: "", json: true, want: "json"},
+		{format: "ndjson", want: "ndjson"},
+		{format: "json", json: true, want: "json"},
+		{format: "ndjson", json: true, wantErr: true},
+		{format: "yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := mqListOutputFormat(tt.format, tt.json)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("mqListOutputFormat(%q, %v) = %q, %v; want %q (error %v)", tt.format, tt.json, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestOutputJSON_OutFlag(t *testing.T) {
+	outFlag = filepath.Join(t.TempDir(), "out.json")
+	defer func() { outFlag = "" }()
+
+	if err := openStructuredOutput(); err != nil {
+		t.Fatalf("openStructuredOutput: %v", err)
+	}
+	if err := outputJSON(map[string]string{"id": "gt-mr-1"}); err != nil {
+		t.Fatalf("outputJSON: %v", err)
+	}
+	if err := closeStructuredOutput(); err != nil {
+		t.Fatalf("closeStructuredOutput: %v", err)
+	}
+
+	data, err := os.ReadFile(outFlag)
+	if err != nil {
+		t.Fatalf("reading --out file: %v", err)
+	}
+	if !strings.Contains(string(data), `"id": "gt-mr-1"`) {
+		t.Errorf("--out file = %s, want the JSON output", data)
+	}
+
+	outFlag = filepath.Join(t.TempDir(), "missing", "out.json")
+	if err := openStructuredOutput(); err == nil {
+		_ = closeStructuredOutput()
+		t.Error("openStructuredOutput() with an uncreatable path: want error")
+	}
+}
+
+func TestWorkerForUser(t *testing.T) {
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir(), Crew: []string{"alice"}}
+	cfg := `{"type":"rig","name":"gastown","operators":{"bob":"Nux"}}`
+	if err := os.WriteFile(filepath.Join(r.Path, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		user    string
+		want    string
+		wantErr bool
+	}{
+		{user: "bob", want: "Nux"},     // config mapping
+		{user: "alice", want: "alice"}, // worker named after the user
+		{user: "carol", wantErr: true}, // no mapping
+		{user: "", wantErr: true},      // $USER unset
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user, func(t *testing.T) {
+			got, err := workerForUser(r, tt.user)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("workerForUser(%q) error = %v, wantErr %v", tt.user, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("workerForUser(%q) = %q, want %q", tt.user, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormatMRAge(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -134,6 +457,46 @@ func TestFormatMRAge(t *testing.T) {
 	}
 }
 
+func TestNewMRListItem_AgeSeconds(t *testing.T) {
+	created := time.Now().Add(-90 * time.Second).UTC().Format(time.RFC3339)
+	item := newMRListItem(&beads.Issue{ID: "gt-mr-1", CreatedAt: created})
+	if item.AgeSeconds < 89 || item.AgeSeconds > 100 {
+		t.Errorf("AgeSeconds = %d, want about 90", item.AgeSeconds)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"id":"gt-mr-1"`) || !strings.Contains(string(data), `"age_seconds":`) {
+		t.Errorf("JSON = %s, want the bead fields plus age_seconds", data)
+	}
+
+	if item := newMRListItem(&beads.Issue{ID: "gt-mr-2", CreatedAt: "not-a-date"}); item.AgeSeconds != -1 {
+		t.Errorf("AgeSeconds for an unreadable created_at = %d, want -1", item.AgeSeconds)
+	}
+}
+
+func TestUpdatedSince(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		updatedAt string
+		want      bool
+	}{
+		{"2026-01-01T13:00:00Z", true},
+		{"2026-01-01T12:00:00Z", true},
+		{"2026-01-01T11:00:00Z", false},
+		{"", false},
+		{"not-a-date", false},
+	}
+	for _, tt := range tests {
+		issue := &beads.Issue{ID: "gt-mr-1", CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: tt.updatedAt}
+		if got := updatedSince(issue, cutoff); got != tt.want {
+			t.Errorf("updatedSince(updated_at=%q) = %v, want %v", tt.updatedAt, got, tt.want)
+		}
+	}
+}
+
 func TestGetDescriptionWithoutMRFields(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -434,3 +797,892 @@ func TestFilterMRsByTarget_NoMRFields(t *testing.T) {
 		t.Errorf("filterMRsByTarget() should filter out issues without MR fields, got %d", len(got))
 	}
 }
+
+func TestQueryMRs_ReadyFiltersType(t *testing.T) {
+	b := newMockBeads()
+	b.addIssue(makeTestMR("mr-1", "polecat/Nux/gt-1", "main", "Nux", "open"))
+	b.addIssue(makeTestIssue("gt-1", "Task", "task", "open"))
+
+	got, err := queryMRs(b, beads.ListOptions{Type: "merge-request", Priority: -1}, true)
+	if err != nil {
+		t.Fatalf("queryMRs() error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "mr-1" {
+		t.Errorf("queryMRs(ready) = %v, want only mr-1", got)
+	}
+}
+
+func TestQueryMRs_PushesReadyAndOrderIntoQuery(t *testing.T) {
+	b := newMockBeads()
+	var queried beads.ListOptions
+	b.listFunc = func(opts beads.ListOptions) ([]*beads.Issue, error) {
+		queried = opts
+		return nil, nil
+	}
+
+	for _, ready := range []bool{false, true} {
+		if _, err := queryMRs(b, beads.ListOptions{Type: "merge-request", Priority: -1}, ready); err != nil {
+			t.Fatalf("queryMRs(ready=%v): %v", ready, err)
+		}
+		if queried.ReadyOnly != ready || queried.Type != "merge-request" || queried.Sort != beads.SortPriority {
+			t.Errorf("queryMRs(ready=%v) queried %+v, want ready=%v merge-requests by priority", ready, queried, ready)
+		}
+	}
+}
+
+func TestQueryMRs_BeadsUnavailable(t *testing.T) {
+	b := newMockBeads()
+	unavailable := fmt.Errorf("%w: failed to open database", beads.ErrUnavailable)
+	b.listFunc = func(opts beads.ListOptions) ([]*beads.Issue, error) {
+		return nil, unavailable
+	}
+
+	for _, ready := range []bool{false, true} {
+		_, err := queryMRs(b, beads.ListOptions{Type: "merge-request", Priority: -1}, ready)
+		if err == nil {
+			t.Fatalf("queryMRs(ready=%v) expected error", ready)
+		}
+		wrapped := beadsQueryError("querying merge queue", err, "/town/gastown")
+		if !beads.IsUnavailable(wrapped) {
+			t.Errorf("beadsQueryError() lost the underlying error: %v", wrapped)
+		}
+		if !strings.Contains(wrapped.Error(), "bd doctor") {
+			t.Errorf("beadsQueryError() = %q, want setup guidance", wrapped.Error())
+		}
+	}
+
+	// Other failures pass through without setup guidance
+	err := beadsQueryError("querying merge queue", beads.ErrNotFound, "/town/gastown")
+	if strings.Contains(err.Error(), "bd doctor") {
+		t.Errorf("beadsQueryError(ErrNotFound) = %q, should not include setup guidance", err.Error())
+	}
+}
+
+func TestRunMQRetryUntilSuccess_ProcessingLocked(t *testing.T) {
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+printf '%s' '[{"id":"gt-mr-1","status":"open","issue_type":"merge-request","description":"branch: polecat/Nux/gt-1\ntarget: main"}]'
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// A refinery cycle is running
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	unlock, err := refinery.NewEngineer(r).LockProcessing()
+	if err != nil {
+		t.Fatalf("LockProcessing: %v", err)
+	}
+	defer unlock()
+
+	defer func(n int, d time.Duration) { mqRetryMaxAttempts, mqRetryInterval = n, d }(mqRetryMaxAttempts, mqRetryInterval)
+	mqRetryMaxAttempts, mqRetryInterval = 3, time.Hour
+	captureStdout(t, func() {
+		err = runMQRetryUntilSuccess(r, "gt-mr-1")
+	})
+	if !errors.Is(err, refinery.ErrProcessingLocked) {
+		t.Errorf("runMQRetryUntilSuccess while a cycle runs = %v, want ErrProcessingLocked at once", err)
+	}
+}
+
+func TestRunMQRetryUntilSuccess_MergeWindowClosed(t *testing.T) {
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+printf '%s' '[{"id":"gt-mr-1","status":"open","issue_type":"merge-request","description":"branch: polecat/Nux/gt-1\ntarget: main"}]'
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// The window is only open tomorrow
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	day := time.Now().AddDate(0, 0, 1).Format("Mon")
+	cfg := fmt.Sprintf(`{"merge_queue": {"merge_window": ["%s 00:00-23:59"]}}`, day)
+	if err := os.WriteFile(filepath.Join(r.Path, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(n int, d time.Duration) { mqRetryMaxAttempts, mqRetryInterval = n, d }(mqRetryMaxAttempts, mqRetryInterval)
+	mqRetryMaxAttempts, mqRetryInterval = 3, time.Hour
+	var err error
+	captureStdout(t, func() {
+		err = runMQRetryUntilSuccess(r, "gt-mr-1")
+	})
+	if !errors.Is(err, refinery.ErrMergeWindowClosed) {
+		t.Errorf("runMQRetryUntilSuccess outside the window = %v, want ErrMergeWindowClosed", err)
+	}
+}
+
+func TestRetryUntilSuccess(t *testing.T) {
+	t.Run("stops on first success", func(t *testing.T) {
+		calls := 0
+		attempt := func(ctx context.Context) refinery.ProcessResult {
+			calls++
+			if calls < 2 {
+				return refinery.ProcessResult{Error: "tests failed", TestsFailed: true}
+			}
+			return refinery.ProcessResult{Success: true, MergeCommit: "abc123"}
+		}
+
+		result, attempts := retryUntilSuccess(context.Background(), attempt, 3, time.Millisecond, io.Discard)
+		if !result.Success || attempts != 2 || calls != 2 {
+			t.Errorf("retryUntilSuccess() = (%+v, %d), calls %d; want success on attempt 2", result, attempts, calls)
+		}
+	})
+
+	t.Run("exhausts attempts", func(t *testing.T) {
+		attempt := func(ctx context.Context) refinery.ProcessResult {
+			return refinery.ProcessResult{Error: "conflict", Conflict: true}
+		}
+
+		result, attempts := retryUntilSuccess(context.Background(), attempt, 3, time.Millisecond, io.Discard)
+		if result.Success || attempts != 3 {
+			t.Errorf("retryUntilSuccess() = (%+v, %d), want failure after 3 attempts", result, attempts)
+		}
+	})
+
+	t.Run("cancellation stops waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempt := func(ctx context.Context) refinery.ProcessResult {
+			cancel()
+			return refinery.ProcessResult{Error: "flaky"}
+		}
+
+		_, attempts := retryUntilSuccess(ctx, attempt, 3, time.Hour, io.Discard)
+		if attempts != 1 {
+			t.Errorf("retryUntilSuccess() attempts = %d, want 1 after cancel", attempts)
+		}
+	})
+}
+
+func TestCheckRetryWorker(t *testing.T) {
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	if err := os.MkdirAll(filepath.Join(r.Path, "polecats", "Nux"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if note, err := checkRetryWorker(r, "Nux", "polecat/Nux-abc123"); err != nil || note != "" {
+		t.Errorf("existing worker: note %q, err %v", note, err)
+	}
+	if note, err := checkRetryWorker(r, "", "polecat/Nux-abc123"); err != nil || note != "" {
+		t.Errorf("no worker recorded: note %q, err %v", note, err)
+	}
+	_, err := checkRetryWorker(r, "ghost", "polecat/Nux-abc123")
+	if err == nil || !strings.Contains(err.Error(), "--recreate") {
+		t.Errorf("missing worker error = %v, want hint about --recreate", err)
+	}
+
+	mqRetryForce = true
+	defer func() { mqRetryForce = false }()
+	if note, err := checkRetryWorker(r, "ghost", "polecat/Nux-abc123"); err != nil || note == "" {
+		t.Errorf("missing worker with --force: note %q, err %v", note, err)
+	}
+}
+
+func TestCheckQueueAge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mr := func(id string, age time.Duration, blocked bool) *beads.Issue {
+		issue := &beads.Issue{ID: id, CreatedAt: now.Add(-age).Format(time.RFC3339)}
+		if blocked {
+			issue.BlockedBy = []string{"gt-x"}
+		}
+		return issue
+	}
+	draft := mr("gt-draft", 3*time.Hour, false)
+	draft.Description = "branch: polecat/Nux\ndraft: true"
+
+	tests := []struct {
+		name   string
+		issues []*beads.Issue
+		wantOK bool
+	}{
+		{"empty queue", nil, true},
+		{"fresh MRs", []*beads.Issue{mr("a", 10*time.Minute, false), mr("b", time.Hour, false)}, true},
+		{"stuck MR", []*beads.Issue{mr("a", 3*time.Hour, false)}, false},
+		{"old but blocked", []*beads.Issue{mr("a", 3*time.Hour, true)}, true},
+		{"old but draft", []*beads.Issue{draft}, true},
+		{"old but held", []*beads.Issue{mr("gt-held", 3*time.Hour, false)}, true},
+	}
+
+	holds := refinery.Holds{"gt-held"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkQueueAge(tt.issues, holds, nil, now, 2*time.Hour)
+			if got.OK != tt.wantOK {
+				t.Errorf("checkQueueAge() = %+v, want OK=%v", got, tt.wantOK)
+			}
+		})
+	}
+
+	// Nothing merges while the merge window is closed (noon is outside it)
+	closed, err := config.ParseMergeWindow([]string{"02:00-04:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := checkQueueAge([]*beads.Issue{mr("a", 3*time.Hour, false)}, nil, closed, now, 2*time.Hour); !got.OK {
+		t.Errorf("checkQueueAge() with window closed = %+v, want OK", got)
+	}
+}
+
+func TestCheckRemoteHealth_Timeout(t *testing.T) {
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	repo := filepath.Join(r.Path, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"remote", "add", "origin", "ssh://example.invalid/repo.git"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	// An ssh that never answers
+	t.Setenv("GIT_SSH_COMMAND", "sleep 30 #")
+
+	saved := remoteHealthTimeout
+	remoteHealthTimeout = 200 * time.Millisecond
+	defer func() { remoteHealthTimeout = saved }()
+
+	start := time.Now()
+	got := checkRemoteHealth(r)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("checkRemoteHealth took %v, want it bounded by the timeout", elapsed)
+	}
+	if got.OK || !strings.Contains(got.Detail, "did not answer") {
+		t.Errorf("checkRemoteHealth() = %+v, want a timeout failure", got)
+	}
+}
+
+func TestFormatHealthLine(t *testing.T) {
+	report := HealthReport{
+		Rig:     "gastown",
+		Healthy: false,
+		Checks: []HealthCheck{
+			{Name: "refinery", OK: true, Detail: "session gt-gastown-refinery"},
+			{Name: "remote", OK: false, Detail: "timeout"},
+		},
+	}
+	want := "FAIL gastown: refinery=ok (session gt-gastown-refinery), remote=FAIL (timeout)"
+	if got := formatHealthLine(report); got != want {
+		t.Errorf("formatHealthLine() = %q, want %q", got, want)
+	}
+}
+
+func TestSummarizeProcessed(t *testing.T) {
+	report := summarizeProcessed("gastown", []refinery.ProcessedMR{
+		{ID: "gt-mr-1", Outcome: refinery.OutcomeMerged},
+		{ID: "gt-mr-2", Outcome: refinery.OutcomeSkipped},
+		{ID: "gt-mr-3", Outcome: refinery.OutcomeConflict},
+		{ID: "gt-mr-4", Outcome: refinery.OutcomeBlocked},
+		{ID: "gt-mr-5", Outcome: refinery.OutcomeFailed},
+	})
+	if report.Merged != 2 || report.Failed != 2 {
+		t.Errorf("summarizeProcessed() merged=%d failed=%d, want 2 and 2", report.Merged, report.Failed)
+	}
+
+	empty := summarizeProcessed("gastown", nil)
+	if empty.Processed == nil {
+		t.Error("summarizeProcessed(nil) should report an empty list, not null")
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"nux", "nux", true},
+		{"nux", "nuxx", false},
+		{"f*", "furiosa", true},
+		{"f*", "nux", false},
+		{"integration/release-*", "integration/release-1.2", true},
+		{"integration/release-*", "integration/release-1/hotfix", false},
+		{"integration/gt-?", "integration/gt-a", true},
+		{"integration/gt-[ab]", "integration/gt-c", false},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+
+	if err := validateGlob("--worker", "[bad"); err == nil {
+		t.Error("validateGlob([bad) should fail")
+	}
+	if err := validateGlob("--worker", ""); err != nil {
+		t.Errorf("validateGlob(\"\") = %v, want nil", err)
+	}
+}
+
+func TestMRClaimant(t *testing.T) {
+	claims := map[string]string{
+		"gt-mr-claimed":        "refinery-1",
+		"polecat/Nux/gt-claim": "refinery-2",
+	}
+	tests := []struct {
+		name   string
+		issue  *beads.Issue
+		fields *beads.MRFields
+		want   string
+	}{
+		{"assigned bead", &beads.Issue{ID: "gt-mr-a", Assignee: "gastown/crew/max"}, nil, "gastown/crew/max"},
+		{"claimed by ID", &beads.Issue{ID: "gt-mr-claimed"}, nil, "refinery-1"},
+		{"claimed by branch", &beads.Issue{ID: "gt-mr-b"}, &beads.MRFields{Branch: "polecat/Nux/gt-claim"}, "refinery-2"},
+		{"unclaimed", &beads.Issue{ID: "gt-mr-c"}, &beads.MRFields{Branch: "polecat/Toast/gt-free"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mrClaimant(tt.issue, tt.fields, claims); got != tt.want {
+				t.Errorf("mrClaimant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockedBy(t *testing.T) {
+	issue := &beads.Issue{ID: "gt-mr-b", BlockedBy: []string{"gt-mr-a", "gt-task-1"}}
+	if !blockedBy(issue, "gt-mr-a") || !blockedBy(issue, "gt-task-1") {
+		t.Errorf("blockedBy() = false for a listed blocker, want true")
+	}
+	if blockedBy(issue, "gt-mr") {
+		t.Errorf("blockedBy(%q) = true, want false for a partial ID", "gt-mr")
+	}
+	if blockedBy(&beads.Issue{ID: "gt-mr-c"}, "gt-mr-a") {
+		t.Errorf("blockedBy() = true for an unblocked MR, want false")
+	}
+}
+
+func TestHasLabels(t *testing.T) {
+	issue := &beads.Issue{ID: "gt-mr-a", Labels: []string{"team:payments", "component:api"}}
+	tests := []struct {
+		labels []string
+		want   bool
+	}{
+		{nil, true},
+		{[]string{"team:payments"}, true},
+		{[]string{"team:payments", "component:api"}, true},
+		{[]string{"team:payments", "component:web"}, false}, // AND, not OR
+		{[]string{"team"}, false},
+	}
+	for _, tt := range tests {
+		if got := hasLabels(issue, tt.labels); got != tt.want {
+			t.Errorf("hasLabels(%q) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestValidateMRLabels(t *testing.T) {
+	if err := validateMRLabels([]string{"team:payments", "urgent"}); err != nil {
+		t.Errorf("validateMRLabels() = %v, want nil", err)
+	}
+	for _, bad := range []string{"", "a,b", "team payments"} {
+		if err := validateMRLabels([]string{bad}); err == nil {
+			t.Errorf("validateMRLabels(%q) = nil, want error", bad)
+		}
+	}
+}
+
+func TestComputeMQStats(t *testing.T) {
+	since := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	mr := func(created, fields string) *beads.Issue {
+		return &beads.Issue{CreatedAt: created, Description: fields}
+	}
+	issues := []*beads.Issue{
+		// Nux: two merges (1h and 3h latency), one failure
+		mr("2026-01-11T10:00:00Z", "worker: Nux\nmerged_at: 2026-01-11T11:00:00Z"),
+		mr("2026-01-12T10:00:00Z", "worker: Nux\nmerged_at: 2026-01-12T13:00:00Z\nfailed_at: 2026-01-12T11:00:00Z"),
+		// Toast: one rejection, one merge before the window
+		mr("2026-01-11T10:00:00Z", "worker: Toast\nrejected_at: 2026-01-11T12:00:00Z"),
+		mr("2026-01-01T10:00:00Z", "worker: Toast\nmerged_at: 2026-01-02T10:00:00Z"),
+		// Still open, nothing recorded
+		mr("2026-01-12T10:00:00Z", "worker: Slit"),
+	}
+
+	stats := computeMQStats(issues, since)
+
+	if stats.Total.Merged != 2 || stats.Total.Rejected != 1 || stats.Total.Failed != 1 {
+		t.Errorf("total = %+v, want 2 merged, 1 rejected, 1 failed", stats.Total)
+	}
+	if stats.Total.AvgMergeLatency != 2*time.Hour {
+		t.Errorf("total latency = %v, want 2h", stats.Total.AvgMergeLatency)
+	}
+	if len(stats.ByWorker) != 2 {
+		t.Fatalf("by worker = %+v, want Nux and Toast", stats.ByWorker)
+	}
+	nux, toast := stats.ByWorker[0], stats.ByWorker[1]
+	if nux.Worker != "Nux" || nux.Merged != 2 || nux.Failed != 1 || nux.AvgLatencySecs != 7200 {
+		t.Errorf("Nux = %+v", nux)
+	}
+	if toast.Worker != "Toast" || toast.Merged != 0 || toast.Rejected != 1 {
+		t.Errorf("Toast = %+v", toast)
+	}
+}
+
+func TestPrintMQStats_LastError(t *testing.T) {
+	at := time.Now().Add(-5 * time.Minute)
+	stats := MQStats{Rig: "gastown", LastError: "fetching origin: exit status 128", LastErrorAt: &at}
+
+	out := captureStdout(t, func() { printMQStats(stats) })
+	if !strings.Contains(out, "refinery is failing: fetching origin: exit status 128") {
+		t.Errorf("output missing the refinery's last error:\n%s", out)
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"last_error":"fetching origin: exit status 128"`) || !strings.Contains(string(data), `"last_error_at"`) {
+		t.Errorf("JSON missing last_error: %s", data)
+	}
+
+	stats.LastError, stats.LastErrorAt = "", nil
+	if out := captureStdout(t, func() { printMQStats(stats) }); strings.Contains(out, "failing") {
+		t.Errorf("healthy refinery reported as failing:\n%s", out)
+	}
+}
+
+func TestComputeMQHistory(t *testing.T) {
+	now := time.Date(2026, 1, 12, 15, 0, 0, 0, time.UTC)
+	since := now.Add(-3 * 24 * time.Hour) // Jan 9 15:00
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+	events := []mrqueue.Event{
+		{Timestamp: at(9, 10), Type: mrqueue.EventMerged}, // before the window
+		{Timestamp: at(9, 16), Type: mrqueue.EventMerged},
+		{Timestamp: at(9, 17), Type: mrqueue.EventMergeFailed},
+		{Timestamp: at(11, 9), Type: mrqueue.EventRejected},
+		{Timestamp: at(11, 10), Type: mrqueue.EventMergeStarted}, // not counted
+		{Timestamp: at(12, 9), Type: mrqueue.EventMergeFailed},
+	}
+
+	days := computeMQHistory(events, since, now)
+
+	want := []MQStatsDay{
+		{Date: "2026-01-09", Merged: 1, Failed: 1, FailureRate: 0.5},
+		{Date: "2026-01-10"},
+		{Date: "2026-01-11", Rejected: 1},
+		{Date: "2026-01-12", Failed: 1, FailureRate: 1},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("computeMQHistory() = %+v, want %d days", days, len(want))
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, days[i], want[i])
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	log := "one\ntwo\nthree\nfour\n"
+
+	got, omitted := tailLines(log, 2)
+	if got != "three\nfour\n" || omitted != 2 {
+		t.Errorf("tailLines(2) = %q, %d; want last two lines, 2 omitted", got, omitted)
+	}
+	got, omitted = tailLines(log, 10)
+	if got != log || omitted != 0 {
+		t.Errorf("tailLines(10) = %q, %d; want whole log", got, omitted)
+	}
+}
+
+func TestNewMQConfigOutput(t *testing.T) {
+	cfg := refinery.DefaultMergeQueueConfig()
+	cfg.LoopInterval = 2 * time.Minute
+	window, err := config.ParseMergeWindow([]string{"Mon-Fri 09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseMergeWindow: %v", err)
+	}
+	cfg.MergeWindow = window
+
+	out := newMQConfigOutput("gastown", cfg)
+	if out.PollInterval != "30s" || out.LoopInterval != "2m0s" {
+		t.Errorf("intervals = %q, %q; want 30s, 2m0s", out.PollInterval, out.LoopInterval)
+	}
+	if len(out.MergeWindow) != 1 || out.MergeWindow[0] != "Mon-Fri 09:00-17:00" {
+		t.Errorf("MergeWindow = %q, want [Mon-Fri 09:00-17:00]", out.MergeWindow)
+	}
+	if out.HotBranches == nil {
+		t.Error("HotBranches should be an empty list, not null, in JSON")
+	}
+}
+
+func TestTruncateID(t *testing.T) {
+	tests := []struct {
+		id    string
+		width int
+		want  string
+	}{
+		{"gt-mr-abcdefghij", 12, "gt-mr-abcdef"},
+		{"gt-mr-abc", 12, "gt-mr-abc"},
+		{"gt-mr-abcdefghij", 0, "gt-mr-abcdefghij"},
+		{"gt-mr-abcdefghij", 5, "gt-mr"},
+	}
+	for _, tt := range tests {
+		if got := truncateID(tt.id, tt.width); got != tt.want {
+			t.Errorf("truncateID(%q, %d) = %q, want %q", tt.id, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestMQListTable_AlignsStyledStatuses(t *testing.T) {
+	// Render with colors, as on a terminal, so styled cells carry escapes
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(prev)
+
+	open := func(id string) *beads.Issue { return &beads.Issue{ID: id, Status: "open"} }
+	statuses := []string{
+		mqListStatusCell(open("gt-mr-ready"), nil, false, false),
+		mqListStatusCell(open("gt-mr-merging"), nil, true, false),
+		mqListStatusCell(open("gt-mr-held"), nil, false, true),
+		mqListStatusCell(&beads.Issue{ID: "gt-mr-blocked", Status: "open", BlockedBy: []string{"gt-x"}}, nil, false, false),
+		mqListStatusCell(&beads.Issue{ID: "gt-mr-odd", Status: "deferred"}, nil, false, false),
+	}
+	if !strings.Contains(statuses[0], "\x1b[") || strings.Contains(statuses[4], "\x1b[") {
+		t.Fatalf("want a styled ready status and a plain unknown one, got %q and %q", statuses[0], statuses[4])
+	}
+
+	for _, border := range []bool{false, true} {
+		table := style.NewTable(mqListColumns(12, 24, true)...).SetBorder(border)
+		for i, status := range statuses {
+			table.AddRow(fmt.Sprintf("gt-mr-%d", i), "1234.5", style.Error.Render("P0↑"), style.Dim.Render("(none)"),
+				"polecat/Nux/gt-abc", status, style.Dim.Render("5m"), "0")
+		}
+
+		// Every line, and the start of every column, lines up
+		lines := strings.Split(strings.TrimRight(table.Render(), "\n"), "\n")
+		statusCol := -1
+		for i, line := range lines {
+			plain := ansiEscapes.ReplaceAllString(line, "")
+			if w := style.VisibleWidth(line); w != style.VisibleWidth(lines[0]) {
+				t.Errorf("border=%v: line %d is %d columns wide, want %d:\n%s", border, i, w, style.VisibleWidth(lines[0]), plain)
+			}
+			at := strings.Index(plain, "polecat/Nux/gt-abc")
+			if at < 0 {
+				continue // header and rules
+			}
+			col := style.VisibleWidth(plain[:at]) + 24
+			if statusCol == -1 {
+				statusCol = col
+			}
+			if col != statusCol {
+				t.Errorf("border=%v: STATUS starts at column %d on line %d, want %d:\n%s", border, col, i, statusCol, plain)
+			}
+		}
+	}
+}
+
+// ansiEscapes matches ANSI SGR escape sequences.
+var ansiEscapes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func TestResolveMQRig(t *testing.T) {
+	root := t.TempDir()
+	rigPath := filepath.Join(root, "gastown")
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(rigPath, ".repo.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigsConfig := &config.RigsConfig{
+		Version: 1,
+		Rigs:    map[string]config.RigEntry{"gastown": {GitURL: "https://example.com/gastown.git"}},
+	}
+	if err := config.SaveRigsConfig(filepath.Join(root, "mayor", "rigs.json"), rigsConfig); err != nil {
+		t.Fatal(err)
+	}
+	base := `{"type": "rig", "name": "gastown", "default_branch": "develop", "merge_queue": {"poll_interval": "30s"}}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+	overlay := `{"merge_queue": {"poll_interval": "5m"}}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.staging.json"), []byte(overlay), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(t.TempDir())
+	rigRootFlag = root
+	defer func() { rigRootFlag = "" }()
+	t.Setenv(config.EnvVar, "staging")
+
+	res := resolveMQRig("Gastown")
+	if res.Error != "" {
+		t.Fatalf("resolveMQRig: %s", res.Error)
+	}
+	steps := map[string]MQResolveStep{}
+	for _, s := range res.Steps {
+		steps[s.Step] = s
+	}
+	checks := []struct{ step, value, reason string }{
+		{"rig root", root, "from --rig-root"},
+		{"rig", "gastown", `case-insensitive match for "Gastown"`},
+		{"repo", filepath.Join(rigPath, ".repo.git"), "shared bare repo"},
+		{"environment", "staging", "from $" + config.EnvVar},
+		{"overlay", filepath.Join(rigPath, "config.staging.json"), "merged over config.json"},
+		{"default branch", "develop", "default_branch in config"},
+	}
+	for _, c := range checks {
+		if s := steps[c.step]; s.Value != c.value || s.Reason != c.reason {
+			t.Errorf("step %q = %q (%s), want %q (%s)", c.step, s.Value, s.Reason, c.value, c.reason)
+		}
+	}
+	if res.MergeQueue == nil || res.MergeQueue.PollInterval != "5m0s" {
+		t.Errorf("merge queue = %+v, want the staging overlay's poll_interval", res.MergeQueue)
+	}
+
+	// A failing step stops resolution, keeping the steps before it
+	res = resolveMQRig("nope")
+	last := res.Steps[len(res.Steps)-1]
+	if res.Error == "" || last.Step != "rig" || last.Error == "" || res.Steps[0].Value != root {
+		t.Errorf("resolveMQRig(nope) = %+v, want failure at the rig step after resolving the root", res)
+	}
+}
+
+func TestMQListAllRigs(t *testing.T) {
+	root := t.TempDir()
+	entries := map[string]config.RigEntry{}
+	for _, name := range []string{"alpha", "beta", "broken"} {
+		rigPath := filepath.Join(root, name)
+		if err := os.MkdirAll(rigPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		cfg := fmt.Sprintf(`{"type": "rig", "name": %q}`, name)
+		if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		entries[name] = config.RigEntry{GitURL: "https://example.com/" + name + ".git"}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveRigsConfig(filepath.Join(root, "mayor", "rigs.json"), &config.RigsConfig{Version: 1, Rigs: entries}); err != nil {
+		t.Fatal(err)
+	}
+
+	// bd answers per rig, from the directory it runs in
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+case "$(basename "$(pwd)")" in
+  alpha) printf '%s' '[{"id":"al-mr-1","status":"open","priority":2,"issue_type":"merge-request","description":"branch: polecat/Nux/al-1\ntarget: main\nworker: Nux"}]' ;;
+  beta) printf '%s' '[{"id":"be-mr-1","status":"open","priority":0,"issue_type":"merge-request","description":"branch: polecat/Toast/be-1\ntarget: main\nworker: Toast"}]' ;;
+  *) echo "database locked" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Chdir(t.TempDir())
+	rigRootFlag = root
+	defer func() { rigRootFlag = "" }()
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runMQListAllRigs(mqListCmd, "json", time.Time{})
+	})
+	if code, ok := IsSilentExit(err); !ok || code != 1 {
+		t.Errorf("runMQListAllRigs with a failing rig = %v, want exit 1", err)
+	}
+	var got MRListAllRigs
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, out)
+	}
+	if len(got.Rigs) != 2 || got.Rigs[0].Rig != "alpha" || got.Rigs[1].Rig != "beta" || got.Total != 2 {
+		t.Errorf("rigs = %+v, want alpha and beta with one MR each", got.Rigs)
+	}
+	if got.Errors["broken"] == "" {
+		t.Errorf("errors = %v, want the broken rig's error", got.Errors)
+	}
+
+	// Filters apply to every rig
+	mqListWorker = "nux"
+	defer func() { mqListWorker = "" }()
+	out = captureStdout(t, func() {
+		_ = runMQListAllRigs(mqListCmd, "plain", time.Time{})
+	})
+	if !regexp.MustCompile(`(?m)^\s*RIG\s+ID\s`).MatchString(out) {
+		t.Errorf("output has no RIG column:\n%s", out)
+	}
+	if !strings.Contains(out, "alpha") || !strings.Contains(out, "al-mr-1") || strings.Contains(out, "be-mr-1") {
+		t.Errorf("--worker nux across rigs should list only alpha's MR:\n%s", out)
+	}
+}
+
+func TestMQListShortIDs(t *testing.T) {
+	// A closed MR, not listed, shares the listed one's first 14 characters
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+case "$*" in
+  *--status=all*) printf '%s' '[{"id":"gt-mr-abcdefgh1"},{"id":"gt-mr-abcdefgh2"},{"id":"gt-mr-xyz987654"}]' ;;
+  *) exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	scored := func(ids ...string) []scoredMR {
+		var items []scoredMR
+		for _, id := range ids {
+			items = append(items, scoredMR{issue: &beads.Issue{ID: id, Status: "open"}})
+		}
+		return items
+	}
+	l := &mqListRig{rig: &rig.Rig{Name: "gastown", Path: t.TempDir()}, scored: scored("gt-mr-abcdefgh1", "gt-mr-xyz987654")}
+	if got := l.shortenIDs(12); got != 15 {
+		t.Errorf("shortenIDs(12) column width = %d, want 15", got)
+	}
+	for id, want := range map[string]string{"gt-mr-abcdefgh1": "gt-mr-abcdefgh1", "gt-mr-xyz987654": "gt-mr-xyz987"} {
+		if got := l.displayID(id, 12); got != want {
+			t.Errorf("displayID(%q) = %q, want %q", id, got, want)
+		}
+	}
+
+	// Nothing truncated: bd isn't asked, IDs show whole
+	l = &mqListRig{rig: &rig.Rig{Name: "gastown", Path: t.TempDir()}, scored: scored("gt-mr-a1")}
+	if got := l.shortenIDs(12); got != 12 || l.displayID("gt-mr-a1", 12) != "gt-mr-a1" {
+		t.Errorf("shortenIDs(12) = %d, displayID = %q; want 12, whole ID", got, l.displayID("gt-mr-a1", 12))
+	}
+}
+
+func TestFetchHostingPR(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/widgets/pulls":
+			fmt.Fprint(w, `[{"number":5}]`)
+		case "/repos/acme/widgets/pulls/5":
+			fmt.Fprint(w, `{"number":5,"html_url":"https://github.com/acme/widgets/pull/5","state":"open","mergeable":true,"head":{"sha":"abc"}}`)
+		case "/repos/acme/widgets/pulls/5/reviews":
+			fmt.Fprint(w, `[{"state":"APPROVED","user":{"login":"ann"}}]`)
+		case "/repos/acme/widgets/commits/abc/check-runs":
+			fmt.Fprint(w, `{"check_runs":[{"status":"completed","conclusion":"success"}]}`)
+		case "/repos/acme/widgets/commits/abc/status":
+			fmt.Fprint(w, `{"state":"pending","statuses":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv(config.EnvVar, "")
+
+	r := &rig.Rig{Name: "widgets", Path: t.TempDir()}
+	writeConfig := func(cfg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(r.Path, "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No config.json, or no hosting section: local data only
+	if _, err := fetchHostingPR(r, "polecat/Nux/gt-1"); !errors.Is(err, hosting.ErrNotConfigured) {
+		t.Errorf("fetchHostingPR without config = %v, want ErrNotConfigured", err)
+	}
+	writeConfig(`{"type":"rig","name":"widgets"}`)
+	if _, err := fetchHostingPR(r, "polecat/Nux/gt-1"); !errors.Is(err, hosting.ErrNotConfigured) {
+		t.Errorf("fetchHostingPR without hosting = %v, want ErrNotConfigured", err)
+	}
+
+	writeConfig(`{"type":"rig","name":"widgets","hosting":{"provider":"github","repo":"acme/widgets","base_url":"` + srv.URL + `"}}`)
+	pr, err := fetchHostingPR(r, "polecat/Nux/gt-1")
+	if err != nil {
+		t.Fatalf("fetchHostingPR: %v", err)
+	}
+	if pr.Number != 5 || pr.CI != hosting.CIPass || pr.Approvals != 1 || pr.Mergeable != hosting.MergeableReady {
+		t.Errorf("fetchHostingPR = %+v, want #5 passing, 1 approval, mergeable", *pr)
+	}
+
+	out := captureStdout(t, func() { printMRPullRequest(pr, "") })
+	for _, want := range []string{"Pull Request", "#5 https://github.com/acme/widgets/pull/5", "Approvals: 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("printMRPullRequest output missing %q:\n%s", want, out)
+		}
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	if _, err := fetchHostingPR(r, "polecat/Nux/gt-1"); !errors.Is(err, hosting.ErrNoToken) {
+		t.Errorf("fetchHostingPR without token = %v, want ErrNoToken", err)
+	}
+}
+
+func TestComputeMQCompletions(t *testing.T) {
+	now := time.Date(2026, 1, 12, 15, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	events := []mrqueue.Event{
+		{Timestamp: now.Add(-30 * time.Hour), Type: mrqueue.EventMerged, MRID: "gt-mr-old"}, // before the window
+		{Timestamp: now.Add(-20 * time.Hour), Type: mrqueue.EventMerged, MRID: "gt-mr-a", Worker: "Nux",
+			Target: "main", MergeCommit: "abcdef1234567890"},
+		{Timestamp: now.Add(-10 * time.Hour), Type: mrqueue.EventMergeFailed, MRID: "gt-mr-b"}, // not a completion
+		{Timestamp: now.Add(-5 * time.Hour), Type: mrqueue.EventRejected, MRID: "gt-mr-c", Worker: "Toast",
+			Target: "integration/gt-auth", Reason: "Split up", SupersededBy: "gt-mr-d"},
+		{Timestamp: now.Add(time.Hour), Type: mrqueue.EventMerged, MRID: "gt-mr-future"}, // after now
+	}
+
+	history := computeMQCompletions(events, since, now)
+	if history.Merged != 1 || history.Rejected != 1 {
+		t.Errorf("counts = %d merged, %d rejected; want 1, 1", history.Merged, history.Rejected)
+	}
+	var ids []string
+	for _, c := range history.Completed {
+		ids = append(ids, c.MRID)
+	}
+	if got := strings.Join(ids, ","); got != "gt-mr-c,gt-mr-a" {
+		t.Errorf("completed = %s, want newest first: gt-mr-c,gt-mr-a", got)
+	}
+	if c := history.Completed[0]; c.Outcome != "rejected" || c.Worker != "Toast" || c.Target != "integration/gt-auth" || c.Reason != "Split up" {
+		t.Errorf("rejected entry = %+v", c)
+	}
+
+	history.Rig = "gastown"
+	out := captureStdout(t, func() { printMQHistory(history) })
+	for _, want := range []string{"gt-mr-a", "abcdef12", "Split up (superseded by gt-mr-d)", "1 merged, 1 rejected"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if empty := computeMQCompletions(nil, since, now); empty.Completed == nil {
+		t.Error("empty history has nil completions, want [] for JSON")
+	}
+}
+
+func TestApplyTriage_RejectConfirms(t *testing.T) {
+	old := stdinIsTerminal
+	defer func() { stdinIsTerminal = old }()
+	stdinIsTerminal = func() bool { return true }
+
+	// Answer "n" at the prompt
+	stdin, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.WriteString("n\n")
+	_ = w.Close()
+	defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+	os.Stdin = stdin
+
+	r := &rig.Rig{Name: "gastown", Path: t.TempDir()}
+	mgr := refinery.NewManager(r)
+	d := triage.Decision{Action: triage.ActionReject, IDs: []string{"gt-mr-1"}, Reason: "obsolete"}
+
+	out := captureStdout(t, func() { err = applyTriage(mgr, r, d, false) })
+	if err == nil {
+		t.Fatal("applyTriage declined = nil, want a canceled exit")
+	}
+	if strings.Contains(out, "Rejecting 1 merge request(s):") {
+		t.Errorf("declined rejection still ran:\n%s", out)
+	}
+
+	// --yes skips the prompt (the reject itself fails here: no bd)
+	t.Setenv("PATH", t.TempDir())
+	out = captureStdout(t, func() { _ = applyTriage(mgr, r, d, true) })
+	if !strings.Contains(out, "Rejecting 1 merge request(s):") {
+		t.Errorf("--yes did not reject:\n%s", out)
+	}
+}
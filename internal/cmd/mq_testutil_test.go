@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"sort"
+
 	"github.com/steveyegge/gastown/internal/beads"
 )
 
@@ -42,11 +44,18 @@ func (m *mockBeads) List(opts beads.ListOptions) ([]*beads.Issue, error) {
 		if opts.Type != "" && issue.Type != opts.Type {
 			continue
 		}
-		if opts.Status != "" && issue.Status != opts.Status {
+		if opts.ReadyOnly {
+			if issue.Status != "open" || len(issue.BlockedBy) > 0 {
+				continue
+			}
+		} else if opts.Status != "" && issue.Status != opts.Status {
 			continue
 		}
 		result = append(result, issue)
 	}
+	if opts.Sort == beads.SortPriority {
+		sort.SliceStable(result, func(i, j int) bool { return result[i].Priority < result[j].Priority })
+	}
 	return result, nil
 }
 
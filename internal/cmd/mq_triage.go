@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tui/triage"
+	"golang.org/x/term"
+)
+
+// MQ triage command flags
+var (
+	mqTriageAction   string
+	mqTriageReason   string
+	mqTriageCategory string
+	mqTriageNotify   bool
+	mqTriageYes      bool
+)
+
+var mqTriageCmd = &cobra.Command{
+	Use:   "triage <rig> [mr-id...]",
+	Short: "Retry or reject many merge requests at once",
+	Long: `Pick merge requests from the queue and retry or reject them in one go.
+
+On a terminal this opens an interactive list of the rig's open MRs (failed
+ones show their last error). Move with j/k, select with space (a selects
+all), then press r to retry or D to reject the selection. The reason is
+asked for once and applied to every selected MR: rejections record it as
+with 'gt mq reject' (a reason is required), retries add it as a note (see
+'gt mq note'), if given.
+
+Each MR is then handled as by 'gt mq retry' or 'gt mq reject', and a line
+is printed per MR. One MR failing (e.g. retrying an MR that hasn't failed)
+doesn't stop the rest; the command exits 1 if any did.
+
+Without a terminal, or for scripts, give the MR IDs with --action (and
+--reason) instead. --action=reject asks for confirmation first, as 'gt mq
+reject' does, unless --yes is given or stdin is not a terminal. Without
+--action and no terminal, the MRs are listed and nothing is changed.
+
+Examples:
+  gt mq triage greenplace
+  gt mq triage greenplace gp-mr-abc gp-mr-def --action=retry
+  gt mq triage greenplace gp-mr-abc gp-mr-def --action=reject --reason "Obsolete after the API rewrite" --category obsolete`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runMQTriage,
+}
+
+func init() {
+	mqTriageCmd.Flags().StringVar(&mqTriageAction, "action", "", "Apply this to the given MRs without the interactive list: retry or reject")
+	mqTriageCmd.Flags().StringVarP(&mqTriageReason, "reason", "r", "", "Reason, for --action (required to reject)")
+	mqTriageCmd.Flags().StringVar(&mqTriageCategory, "category", "", "Rejection category: "+strings.Join(refinery.RejectCategories, ", "))
+	mqTriageCmd.Flags().BoolVar(&mqTriageNotify, "notify", false, "Mail each worker about their rejected MR")
+	mqTriageCmd.Flags().BoolVarP(&mqTriageYes, "yes", "y", false, "Don't ask for confirmation before --action=reject")
+
+	mqCmd.AddCommand(mqTriageCmd)
+}
+
+func runMQTriage(cmd *cobra.Command, args []string) error {
+	rigName, ids := args[0], args[1:]
+
+	mgr, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	if mqTriageAction != "" {
+		if len(ids) == 0 {
+			return fmt.Errorf("--action needs the MR IDs to apply it to")
+		}
+		for i := range ids {
+			if ids[i], err = mgr.ResolveMRID(ids[i]); err != nil {
+				return err
+			}
+		}
+		return applyTriage(mgr, r, triage.Decision{Action: mqTriageAction, IDs: ids, Reason: mqTriageReason}, mqTriageYes)
+	}
+	if len(ids) > 0 {
+		return fmt.Errorf("MR IDs are only taken with --action; run without them to pick interactively")
+	}
+
+	items, err := triageItems(mgr)
+	if err != nil {
+		return err
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		printTriageItems(items)
+		fmt.Printf("\n%s\n", style.Dim.Render("Not a terminal: nothing changed. Use --action=retry|reject with MR IDs to apply."))
+		return nil
+	}
+
+	p := tea.NewProgram(triage.New(items), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("running triage: %w", err)
+	}
+	decision, ok := final.(triage.Model).Decision()
+	if !ok {
+		fmt.Printf("%s Nothing changed\n", style.Dim.Render("○"))
+		return nil
+	}
+	// The reason prompt in the list is the confirmation
+	return applyTriage(mgr, r, decision, true)
+}
+
+// triageItems lists the rig's open MRs for triage, in queue order, with
+// the last error of those that failed. The MR being merged is left out.
+func triageItems(mgr *refinery.Manager) ([]triage.Item, error) {
+	queue, err := mgr.Queue()
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[string]string)
+	if mrs, err := mgr.FailedMRs(); err == nil {
+		for _, mr := range mrs {
+			failed[mr.ID] = mr.Error
+		}
+	}
+
+	items := make([]triage.Item, 0, len(queue))
+	for _, q := range queue {
+		if q.Position == 0 {
+			continue // being merged right now
+		}
+		item := triage.Item{
+			ID:     q.MR.ID,
+			Branch: q.MR.Branch,
+			Worker: q.MR.Worker,
+			Status: string(q.MR.Status),
+			Age:    q.Age,
+		}
+		if errMsg, ok := failed[q.MR.ID]; ok {
+			item.Status = "failed"
+			item.Error = errMsg
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// printTriageItems lists triage candidates for the non-interactive fallback.
+func printTriageItems(items []triage.Item) {
+	if len(items) == 0 {
+		fmt.Println("No open merge requests.")
+		return
+	}
+	table := style.NewTable(
+		style.Column{Name: "ID", Width: 14},
+		style.Column{Name: "STATUS", Width: 8},
+		style.Column{Name: "BRANCH", Width: 28},
+		style.Column{Name: "WORKER", Width: 10},
+		style.Column{Name: "AGE", Width: 5, Align: style.AlignRight},
+	)
+	for _, item := range items {
+		table.AddRow(item.ID, item.Status, item.Branch, item.Worker, item.Age)
+	}
+	fmt.Print(table.Render())
+}
+
+// applyTriage retries or rejects each MR in d, reporting each, and exits 1
+// if any could not be handled. Rejections are confirmed first unless yes.
+func applyTriage(mgr *refinery.Manager, r *rig.Rig, d triage.Decision, yes bool) error {
+	var verb string
+	switch d.Action {
+	case triage.ActionRetry:
+		verb = "Retrying"
+	case triage.ActionReject:
+		if strings.TrimSpace(d.Reason) == "" {
+			return fmt.Errorf("a --reason is required to reject")
+		}
+		action := fmt.Sprintf("Rejecting %d merge request(s)", len(d.IDs))
+		if !confirmDestructive(action, triageRejectChanges(mgr, d), yes) {
+			return errCanceled()
+		}
+		verb = "Rejecting"
+	default:
+		return fmt.Errorf("invalid action %q: want %s or %s", d.Action, triage.ActionRetry, triage.ActionReject)
+	}
+
+	fmt.Printf("%s %d merge request(s):\n", verb, len(d.IDs))
+	failures := 0
+	for _, id := range d.IDs {
+		var note string
+		var err error
+		if d.Action == triage.ActionRetry {
+			note, err = triageRetry(mgr, r, id, d.Reason)
+		} else {
+			_, err = mgr.RejectMR(id, refinery.RejectOptions{Reason: d.Reason, Category: mqTriageCategory, Notify: mqTriageNotify})
+		}
+		if err != nil {
+			failures++
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), id, err)
+			continue
+		}
+		fmt.Printf("  %s %s\n", style.Success.Render("✓"), id)
+		if note != "" {
+			fmt.Printf("      %s\n", style.Dim.Render(note))
+		}
+	}
+
+	fmt.Printf("\n%s %d of %d; %d failed\n", style.Bold.Render(d.Action+":"), len(d.IDs)-failures, len(d.IDs), failures)
+	if failures > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// triageRejectChanges lists what rejecting d's MRs will do, for the
+// confirmation prompt.
+func triageRejectChanges(mgr *refinery.Manager, d triage.Decision) []string {
+	changes := make([]string, 0, len(d.IDs)+4)
+	for _, id := range d.IDs {
+		if mr, err := mgr.FindMR(id); err == nil {
+			changes = append(changes, fmt.Sprintf("MR:     %s %s (%s)", mr.ID, mr.Branch, mr.Worker))
+		} else {
+			changes = append(changes, "MR:     "+id)
+		}
+	}
+	changes = append(changes, "Status: -> rejected (closed without merging)", "Reason: "+d.Reason)
+	if mqTriageCategory != "" {
+		changes = append(changes, "Category: "+strings.ToLower(mqTriageCategory))
+	}
+	if mqTriageNotify {
+		changes = append(changes, "Workers will be mailed the reason")
+	}
+	return changes
+}
+
+// triageRetry retries one failed MR, adding reason as a note if given.
+// It returns a warning about the MR's worker, if any.
+func triageRetry(mgr *refinery.Manager, r *rig.Rig, id, reason string) (string, error) {
+	mr, err := mgr.GetMR(id)
+	if err != nil {
+		return "", err
+	}
+	note, err := checkRetryWorker(r, mr.Worker, mr.Branch)
+	if err != nil {
+		return "", err
+	}
+	if _, err := mgr.Retry(id, refinery.RetryOptions{}); err != nil {
+		if errors.Is(err, refinery.ErrMRNotFailed) {
+			return "", fmt.Errorf("has not failed (status: %s)", mr.Status)
+		}
+		return "", err
+	}
+	if strings.TrimSpace(reason) != "" {
+		if err := mgr.AddNote(id, "retry: "+reason); err != nil {
+			return note, fmt.Errorf("retried, but adding the note failed: %w", err)
+		}
+	}
+	return note, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// outFlag is the global --out flag: a file to receive the command's
+// structured (JSON) output instead of stdout.
+var outFlag string
+
+// outFile is the opened --out file, if any.
+var outFile *os.File
+
+// openStructuredOutput creates the --out file before the command runs, so a
+// bad path fails fast instead of after the command has done its work.
+func openStructuredOutput() error {
+	if outFlag == "" || outFlag == "-" || outFile != nil {
+		return nil
+	}
+	f, err := os.Create(outFlag)
+	if err != nil {
+		return fmt.Errorf("creating --out file: %w", err)
+	}
+	outFile = f
+	return nil
+}
+
+// structuredOutput returns where machine-readable output goes: the --out
+// file if one was given, else stdout. Human messages always go to stdout.
+func structuredOutput() io.Writer {
+	if outFile != nil {
+		return outFile
+	}
+	return os.Stdout
+}
+
+// closeStructuredOutput flushes and closes the --out file, if any.
+func closeStructuredOutput() error {
+	if outFile == nil {
+		return nil
+	}
+	err := outFile.Close()
+	outFile = nil
+	if err != nil {
+		return fmt.Errorf("writing --out file: %w", err)
+	}
+	return nil
+}
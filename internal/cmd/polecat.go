@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -65,6 +66,12 @@ var polecatAddCmd = &cobra.Command{
 Creates a polecat directory, clones the rig repo, creates a work branch,
 and initializes state.
 
+If the rig's config.json sets hooks_dir (relative to the rig, or absolute),
+the polecat's worktree runs its git hooks from there (core.hooksPath), so
+shared policy hooks such as commit-msg and pre-push apply to every worker.
+Other worktrees of the repo, such as the refinery's, are unaffected. The
+add fails if hooks_dir is not an existing directory.
+
 Example:
   gt polecat add greenplace Toast`,
 	Args: cobra.ExactArgs(2),
@@ -89,6 +96,23 @@ Examples:
 	RunE: runPolecatRemove,
 }
 
+var polecatRenameCmd = &cobra.Command{
+	Use:   "rename <rig>/<polecat> <new-name>",
+	Short: "Rename a polecat",
+	Long: `Rename a polecat.
+
+Moves the polecat's worktree and renames its branch to match
+(polecat/<old>-<suffix> becomes polecat/<new>-<suffix>), through git so the
+worktree metadata follows. Open MRs for the old branch - their beads and
+merge queue entries - are pointed at the new branch and worker.
+
+Fails if the polecat's session is running (stop it first).
+
+Example:
+  gt polecat rename greenplace/Toast Furiosa`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPolecatRename,
+}
 
 var polecatSyncCmd = &cobra.Command{
 	Use:   "sync <rig>/<polecat>",
@@ -298,6 +322,7 @@ func init() {
 	polecatCmd.AddCommand(polecatListCmd)
 	polecatCmd.AddCommand(polecatAddCmd)
 	polecatCmd.AddCommand(polecatRemoveCmd)
+	polecatCmd.AddCommand(polecatRenameCmd)
 	polecatCmd.AddCommand(polecatSyncCmd)
 	polecatCmd.AddCommand(polecatStatusCmd)
 	polecatCmd.AddCommand(polecatGitStateCmd)
@@ -566,6 +591,48 @@ func runPolecatRemove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runPolecatRename(cmd *cobra.Command, args []string) error {
+	rigName, oldName, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	newName := args[1]
+
+	mgr, r, err := getPolecatManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	if running, _ := polecat.NewSessionManager(tmux.NewTmux(), r).IsRunning(oldName); running {
+		return fmt.Errorf("%s/%s: session is running (stop first)", rigName, oldName)
+	}
+
+	oldBranch, newBranch, err := mgr.Rename(oldName, newName)
+	if err != nil {
+		switch {
+		case errors.Is(err, polecat.ErrPolecatNotFound):
+			return fmt.Errorf("polecat '%s/%s' not found", rigName, oldName)
+		case errors.Is(err, polecat.ErrPolecatExists):
+			return fmt.Errorf("polecat '%s/%s' already exists", rigName, newName)
+		}
+		return fmt.Errorf("renaming polecat: %w", err)
+	}
+	fmt.Printf("%s Renamed polecat %s/%s → %s/%s\n", style.Success.Render("✓"), rigName, oldName, rigName, newName)
+
+	if newBranch == oldBranch {
+		return nil
+	}
+	fmt.Printf("  Branch: %s → %s\n", oldBranch, newBranch)
+	updated, err := refinery.NewManager(r).RenameMRBranch(oldBranch, newBranch, newName)
+	if err != nil {
+		return fmt.Errorf("updating MRs for %s: %w", oldBranch, err)
+	}
+	if updated > 0 {
+		fmt.Printf("  Updated %d open MR(s)\n", updated)
+	}
+	return nil
+}
+
 func runPolecatSync(cmd *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("rig or rig/polecat address required")
@@ -606,7 +673,7 @@ func runPolecatSync(cmd *cobra.Command, args []string) error {
 	// Sync each polecat
 	var syncErrors []string
 	for _, name := range polecatsToSync {
-		polecatDir := filepath.Join(r.Path, "polecats", name)
+		polecatDir := filepath.Join(r.PolecatsDir(), name)
 
 		// Check directory exists
 		if _, err := os.Stat(polecatDir); os.IsNotExist(err) {
@@ -82,6 +82,7 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	// Build add options with hook_bead set atomically at spawn time
 	addOpts := polecat.AddOptions{
 		HookBead: opts.HookBead,
+		Force:    opts.Force, // Also clears a stale worktree lock
 	}
 
 	if err == nil {
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+func TestRunPolecatRename(t *testing.T) {
+	townRoot := setupTestTownForCrewList(t, map[string][]string{"gastown": nil})
+	rigPath := filepath.Join(townRoot, "gastown")
+	mayorRig := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := filepath.Join(rigPath, "polecats", "Toast")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"worktree", "add", "-q", "-b", "polecat/Toast-abc123", oldPath},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = mayorRig
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	queued := &mrqueue.MR{Branch: "polecat/Toast-abc123", Target: "main", Worker: "Toast"}
+	if err := mrqueue.New(rigPath).Submit(queued); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fake bd lists one open MR bead for the branch and logs updates
+	binDir := t.TempDir()
+	bdLog := filepath.Join(binDir, "bd.log")
+	script := `#!/bin/sh
+case "$*" in
+  *list*) printf '%s' '[{"id":"gt-mr-1","title":"Merge","issue_type":"merge-request","status":"open","description":"branch: polecat/Toast-abc123\nworker: Toast"}]' ;;
+  *update*) echo "$@" >> ` + bdLog + ` ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Chdir(townRoot)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runPolecatRename(polecatRenameCmd, []string{"gastown/Toast", "Furiosa"})
+	})
+	if err != nil {
+		t.Fatalf("runPolecatRename: %v\n%s", err, out)
+	}
+
+	newPath := filepath.Join(rigPath, "polecats", "Furiosa")
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old worktree still exists: %v", err)
+	}
+	if branch, err := git.NewGit(newPath).CurrentBranch(); err != nil || branch != "polecat/Furiosa-abc123" {
+		t.Errorf("new worktree branch = %q, %v; want polecat/Furiosa-abc123", branch, err)
+	}
+
+	got, err := mrqueue.New(rigPath).Get(queued.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Branch != "polecat/Furiosa-abc123" || got.Worker != "Furiosa" {
+		t.Errorf("queued MR branch %q worker %q, want polecat/Furiosa-abc123 and Furiosa", got.Branch, got.Worker)
+	}
+	if data, err := os.ReadFile(bdLog); err != nil || !strings.Contains(string(data), "gt-mr-1") {
+		t.Errorf("MR bead not updated (bd log %q, %v)", data, err)
+	}
+	if !strings.Contains(out, "Updated 1 open MR(s)") {
+		t.Errorf("output = %q, want the MR update reported", out)
+	}
+}
@@ -268,18 +268,14 @@ func detectRole(cwd, townRoot string) RoleInfo {
 		return ctx
 	}
 
-	// Check for polecat: <rig>/polecats/<name>/
-	if len(parts) >= 3 && parts[1] == "polecats" {
-		ctx.Role = RolePolecat
-		ctx.Polecat = parts[2]
-		return ctx
-	}
-
-	// Check for crew: <rig>/crew/<name>/
-	if len(parts) >= 3 && parts[1] == "crew" {
-		ctx.Role = RoleCrew
-		ctx.Polecat = parts[2] // Use Polecat field for crew member name
-		return ctx
+	// Check for workers: <rig>/polecats/<name>/, <rig>/crew/<name>/ or
+	// another worker root of the rig (<rig>/<root>/<name>/)
+	if len(parts) >= 3 {
+		if role, ok := workerRole(townRoot, rigName, parts[1]); ok {
+			ctx.Role = role
+			ctx.Polecat = parts[2] // Also holds the crew member name
+			return ctx
+		}
 	}
 
 	// Default: could be rig root - treat as unknown
@@ -95,3 +95,31 @@ func TestGetAgentBeadID_UsesRigPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectRole_CustomWorkerRoot(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "bots", "alpha"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `{"type":"rig","name":"gastown","worker_roots":["polecats","crew","bots"]}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := detectRole(filepath.Join(rigPath, "bots", "alpha"), townRoot)
+	if ctx.Role != RolePolecat || ctx.Rig != "gastown" || ctx.Polecat != "alpha" {
+		t.Errorf("detectRole() = %s %q %q, want polecat gastown alpha", ctx.Role, ctx.Rig, ctx.Polecat)
+	}
+	if ctx := detectRole(filepath.Join(rigPath, "crew", "max"), townRoot); ctx.Role != RoleCrew || ctx.Polecat != "max" {
+		t.Errorf("detectRole(crew/max) = %s %q, want crew max", ctx.Role, ctx.Polecat)
+	}
+
+	role, rig, name := parseRoleString("gastown/bots/alpha", townRoot)
+	if role != RolePolecat || rig != "gastown" || name != "alpha" {
+		t.Errorf("parseRoleString() = %s %q %q, want polecat gastown alpha", role, rig, name)
+	}
+	if home := getRoleHome(RolePolecat, "gastown", "alpha", townRoot); home != filepath.Join(rigPath, "bots", "alpha") {
+		t.Errorf("getRoleHome() = %q, want the bots/ worktree", home)
+	}
+}
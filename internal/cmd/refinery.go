@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
@@ -11,7 +11,6 @@ import (
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // Refinery command flags
@@ -30,7 +29,10 @@ var refineryCmd = &cobra.Command{
 	Long: `Manage the Refinery merge queue processor for a rig.
 
 The Refinery processes merge requests from polecats, merging their work
-into integration branches and ultimately to main.`,
+into integration branches and ultimately to main.
+
+The rig argument is a registered rig name or a path to a rig directory
+(anything containing a '/', or '.' or '..').`,
 }
 
 var refineryStartCmd = &cobra.Command{
@@ -123,11 +125,16 @@ var refineryClaimCmd = &cobra.Command{
 	Long: `Claim a merge request for processing by this refinery worker.
 
 When running multiple refinery workers in parallel, each worker must claim
-an MR before processing to prevent double-processing. Claims expire after
-10 minutes if not processed (for crash recovery).
+an MR before processing to prevent double-processing. A claim is a lease:
+claims are taken under a per-MR file lock in the rig's queue, so when
+workers race for the same MR exactly one wins. A claim expires after 10
+minutes (for crash recovery) unless the worker renews it with
+'gt refinery renew'; the refinery's own merge loop claims each MR the same
+way and renews its claims while merging.
 
 The worker ID is automatically determined from the GT_REFINERY_WORKER
-environment variable, or defaults to "refinery-1".
+environment variable, or defaults to "refinery-1". Every worker sharing a
+queue must use a distinct ID: a worker can always re-claim an MR it holds.
 
 Examples:
   gt refinery claim gt-abc123
@@ -136,6 +143,22 @@ Examples:
 	RunE: runRefineryClaim,
 }
 
+var refineryRenewCmd = &cobra.Command{
+	Use:   "renew <mr-id>",
+	Short: "Renew this worker's claim on an MR",
+	Long: `Renew this refinery worker's claim on a merge request.
+
+Claims expire after 10 minutes; a worker still processing an MR (e.g.
+during a long test run) should renew its claim every few minutes so no
+other worker takes the MR over. Fails if the claim has already been lost
+to another worker; stop processing the MR in that case.
+
+Examples:
+  gt refinery renew gt-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRefineryRenew,
+}
+
 var refineryReleaseCmd = &cobra.Command{
 	Use:   "release <mr-id>",
 	Short: "Release a claimed MR back to the queue",
@@ -232,6 +255,7 @@ func init() {
 	refineryCmd.AddCommand(refineryQueueCmd)
 	refineryCmd.AddCommand(refineryAttachCmd)
 	refineryCmd.AddCommand(refineryClaimCmd)
+	refineryCmd.AddCommand(refineryRenewCmd)
 	refineryCmd.AddCommand(refineryReleaseCmd)
 	refineryCmd.AddCommand(refineryUnclaimedCmd)
 	refineryCmd.AddCommand(refineryReadyCmd)
@@ -245,9 +269,9 @@ func init() {
 func getRefineryManager(rigName string) (*refinery.Manager, *rig.Rig, string, error) {
 	// Infer rig from cwd if not provided
 	if rigName == "" {
-		townRoot, err := workspace.FindFromCwdOrError()
+		townRoot, err := findRigRoot()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+			return nil, nil, "", err
 		}
 		rigName, err = inferRigFromCwd(townRoot)
 		if err != nil {
@@ -255,13 +279,13 @@ func getRefineryManager(rigName string) (*refinery.Manager, *rig.Rig, string, er
 		}
 	}
 
-	_, r, err := getRig(rigName)
+	r, err := getRigArg(rigName)
 	if err != nil {
 		return nil, nil, "", err
 	}
 
 	mgr := refinery.NewManager(r)
-	return mgr, r, rigName, nil
+	return mgr, r, r.Name, nil // the registered name, whatever case was typed
 }
 
 func runRefineryStart(cmd *cobra.Command, args []string) error {
@@ -278,7 +302,7 @@ func runRefineryStart(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Starting refinery for %s...\n", rigName)
 
 	if err := mgr.Start(refineryForeground); err != nil {
-		if err == refinery.ErrAlreadyRunning {
+		if errors.Is(err, refinery.ErrAlreadyRunning) {
 			fmt.Printf("%s Refinery is already running\n", style.Dim.Render("⚠"))
 			return nil
 		}
@@ -307,7 +331,7 @@ func runRefineryStop(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := mgr.Stop(); err != nil {
-		if err == refinery.ErrNotRunning {
+		if errors.Is(err, refinery.ErrNotRunning) {
 			fmt.Printf("%s Refinery is not running\n", style.Dim.Render("⚠"))
 			return nil
 		}
@@ -336,9 +360,7 @@ func runRefineryStatus(cmd *cobra.Command, args []string) error {
 
 	// JSON output
 	if refineryStatusJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(ref)
+		return outputJSON(ref)
 	}
 
 	// Human-readable output
@@ -381,6 +403,9 @@ func runRefineryStatus(cmd *cobra.Command, args []string) error {
 	if ref.LastMergeAt != nil {
 		fmt.Printf("  Last merge: %s\n", ref.LastMergeAt.Format("2006-01-02 15:04:05"))
 	}
+	if ref.LastError != "" {
+		fmt.Printf("  %s %s\n", style.Error.Render("Last error:"), formatRefineryError(ref))
+	}
 
 	return nil
 }
@@ -391,21 +416,19 @@ func runRefineryQueue(cmd *cobra.Command, args []string) error {
 		rigName = args[0]
 	}
 
-	mgr, _, rigName, err := getRefineryManager(rigName)
+	mgr, r, rigName, err := getRefineryManager(rigName)
 	if err != nil {
 		return err
 	}
 
 	queue, err := mgr.Queue()
 	if err != nil {
-		return fmt.Errorf("getting queue: %w", err)
+		return beadsQueryError("getting queue", err, r.BeadsPath())
 	}
 
 	// JSON output
 	if refineryQueueJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(queue)
+		return outputJSON(queue)
 	}
 
 	// Human-readable output
@@ -514,7 +537,7 @@ func runRefineryRestart(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Restarting refinery for %s...\n", rigName)
 
 	// Stop if running (ignore ErrNotRunning)
-	if err := mgr.Stop(); err != nil && err != refinery.ErrNotRunning {
+	if err := mgr.Stop(); err != nil && !errors.Is(err, refinery.ErrNotRunning) {
 		return fmt.Errorf("stopping refinery: %w", err)
 	}
 
@@ -560,6 +583,29 @@ func runRefineryClaim(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runRefineryRenew(cmd *cobra.Command, args []string) error {
+	mrID := args[0]
+	workerID := getWorkerID()
+
+	q, err := mrqueue.NewFromWorkdir(".")
+	if err != nil {
+		return fmt.Errorf("finding merge queue: %w", err)
+	}
+
+	if err := q.Renew(mrID, workerID); err != nil {
+		if err == mrqueue.ErrNotFound {
+			return fmt.Errorf("MR %s not found in queue", mrID)
+		}
+		if err == mrqueue.ErrClaimLost {
+			return fmt.Errorf("MR %s is no longer claimed by %s", mrID, workerID)
+		}
+		return fmt.Errorf("renewing claim: %w", err)
+	}
+
+	fmt.Printf("%s Renewed claim on %s for %s\n", style.Bold.Render("✓"), mrID, workerID)
+	return nil
+}
+
 func runRefineryRelease(cmd *cobra.Command, args []string) error {
 	mrID := args[0]
 
@@ -595,9 +641,7 @@ func runRefineryUnclaimed(cmd *cobra.Command, args []string) error {
 
 	// JSON output
 	if refineryUnclaimedJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(unclaimed)
+		return outputJSON(unclaimed)
 	}
 
 	// Human-readable output
@@ -634,14 +678,12 @@ func runRefineryReady(cmd *cobra.Command, args []string) error {
 	// Get ready MRs (unclaimed AND unblocked)
 	ready, err := eng.ListReadyMRs()
 	if err != nil {
-		return fmt.Errorf("listing ready MRs: %w", err)
+		return beadsQueryError("listing ready MRs", err, r.BeadsPath())
 	}
 
 	// JSON output
 	if refineryReadyJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(ready)
+		return outputJSON(ready)
 	}
 
 	// Human-readable output
@@ -678,14 +720,12 @@ func runRefineryBlocked(cmd *cobra.Command, args []string) error {
 	// Get blocked MRs
 	blocked, err := eng.ListBlockedMRs()
 	if err != nil {
-		return fmt.Errorf("listing blocked MRs: %w", err)
+		return beadsQueryError("listing blocked MRs", err, r.BeadsPath())
 	}
 
 	// JSON output
 	if refineryBlockedJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(blocked)
+		return outputJSON(blocked)
 	}
 
 	// Human-readable output
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/witness"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 )
 
 var rigCmd = &cobra.Command{
@@ -60,9 +62,20 @@ The command also:
   - Creates ~/gt/plugins/ (town-level) if it doesn't exist
   - Creates <rig>/plugins/ (rig-level)
 
+--clone-arg passes a raw flag to every git clone of the rig (repeatable).
+Values must be joined with '=' (--clone-arg=--jobs=4). They are saved as
+clone_args in config.json and reused for crew clones. Only transfer
+flags are allowed (--filter, --depth, --jobs, --shallow-since,
+--single-branch, --no-tags, ...), spelled out in full, and --config only
+for tuning keys such as core.autocrlf or http.postBuffer.
+
+When stderr is a terminal, git's clone progress (object counts, transfer
+rate) is shown while the repository is cloned.
+
 Example:
   gt rig add gastown https://github.com/steveyegge/gastown
-  gt rig add my-project git@github.com:user/repo.git --prefix mp`,
+  gt rig add my-project git@github.com:user/repo.git --prefix mp
+  gt rig add big-repo https://example.com/big.git --clone-arg=--filter=blob:none`,
 	Args: cobra.ExactArgs(2),
 	RunE: runRigAdd,
 }
@@ -256,6 +269,7 @@ var (
 	rigAddPrefix       string
 	rigAddLocalRepo    string
 	rigAddBranch       string
+	rigAddCloneArgs    []string
 	rigResetHandoff    bool
 	rigResetMail       bool
 	rigResetStale      bool
@@ -286,6 +300,7 @@ func init() {
 	rigAddCmd.Flags().StringVar(&rigAddPrefix, "prefix", "", "Beads issue prefix (default: derived from name)")
 	rigAddCmd.Flags().StringVar(&rigAddLocalRepo, "local-repo", "", "Local repo path to share git objects (optional)")
 	rigAddCmd.Flags().StringVar(&rigAddBranch, "branch", "", "Default branch name (default: auto-detected from remote)")
+	rigAddCmd.Flags().StringArrayVar(&rigAddCloneArgs, "clone-arg", nil, "Extra raw git clone flag, e.g. --filter=blob:none (repeatable)")
 
 	rigResetCmd.Flags().BoolVar(&rigResetHandoff, "handoff", false, "Clear handoff content")
 	rigResetCmd.Flags().BoolVar(&rigResetMail, "mail", false, "Clear stale mail messages")
@@ -305,6 +320,16 @@ func init() {
 	rigRestartCmd.Flags().BoolVar(&rigRestartNuclear, "nuclear", false, "DANGER: Bypass ALL safety checks (loses uncommitted work!)")
 }
 
+// cloneProgressWriter returns where rig add streams git clone progress:
+// stderr when it is a terminal, nil (discarded) otherwise so logs and pipes
+// don't fill with carriage-return updates.
+func cloneProgressWriter() io.Writer {
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	return os.Stderr
+}
+
 func runRigAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	gitURL := args[1]
@@ -350,6 +375,8 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		BeadsPrefix:   rigAddPrefix,
 		LocalRepo:     rigAddLocalRepo,
 		DefaultBranch: rigAddBranch,
+		CloneArgs:     rigAddCloneArgs,
+		Progress:      cloneProgressWriter(),
 	})
 	if err != nil {
 		return fmt.Errorf("adding rig: %w", err)
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
@@ -10,13 +15,58 @@ import (
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+// RigRootEnv overrides where rigs are looked up, like --rig-root.
+const RigRootEnv = "GASTOWN_RIG_ROOT"
+
+// rigRootFlag is the global --rig-root flag.
+var rigRootFlag string
+
+// findRigRoot returns the directory rigs are resolved in: --rig-root, then
+// $GASTOWN_RIG_ROOT, then the town containing the current directory.
+// An explicit root must have registered rigs, so a mistyped path fails
+// clearly instead of as "rig not found".
+func findRigRoot() (string, error) {
+	root, source := rigRootSetting()
+	if root == "" {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		return townRoot, nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", source, err)
+	}
+	rigsConfigPath := constants.MayorRigsPath(absRoot)
+	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
+	if err != nil || len(rigsConfig.Rigs) == 0 {
+		return "", fmt.Errorf("no rigs found under %s (from %s): expected rigs registered in %s",
+			absRoot, source, rigsConfigPath)
+	}
+	return absRoot, nil
+}
+
+// rigRootSetting returns the explicitly configured rig root and where it
+// came from (--rig-root or $GASTOWN_RIG_ROOT), or "" if neither is set.
+func rigRootSetting() (root, source string) {
+	if rigRootFlag != "" {
+		return rigRootFlag, "--rig-root"
+	}
+	if env := os.Getenv(RigRootEnv); env != "" {
+		return env, RigRootEnv
+	}
+	return "", ""
+}
+
 // getRig finds the town root and retrieves the specified rig.
 // This is the common boilerplate extracted from get*Manager functions.
 // Returns the town root path and rig instance.
 func getRig(rigName string) (string, *rig.Rig, error) {
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := findRigRoot()
 	if err != nil {
-		return "", nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return "", nil, err
 	}
 
 	rigsConfigPath := constants.MayorRigsPath(townRoot)
@@ -29,8 +79,93 @@ func getRig(rigName string) (string, *rig.Rig, error) {
 	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
 	r, err := rigMgr.GetRig(rigName)
 	if err != nil {
+		if errors.Is(err, rig.ErrRigNameAmbiguous) {
+			return "", nil, err
+		}
 		return "", nil, fmt.Errorf("rig '%s' not found", rigName)
 	}
 
 	return townRoot, r, nil
 }
+
+// looksLikeRigPath reports whether a rig argument is a directory path
+// rather than a rig name: it contains a path separator or is "." or "..".
+func looksLikeRigPath(arg string) bool {
+	return arg == "." || arg == ".." || strings.ContainsRune(arg, '/') || strings.ContainsRune(arg, filepath.Separator)
+}
+
+// getRigArg resolves a rig argument that may be a registered rig name or
+// a path to a rig directory. A path is loaded directly, bypassing the
+// name lookup (and --rig-root), for rigs outside the default rig root.
+func getRigArg(arg string) (*rig.Rig, error) {
+	if !looksLikeRigPath(arg) {
+		_, r, err := getRig(arg)
+		return r, err
+	}
+	r, err := rig.LoadRigFromPath(arg)
+	if err != nil {
+		if errors.Is(err, rig.ErrNotARig) {
+			return nil, fmt.Errorf("'%s' is not a rig directory: %w", arg, err)
+		}
+		return nil, fmt.Errorf("loading rig at '%s': %w", arg, err)
+	}
+	return r, nil
+}
+
+// resolveCurrentWorker maps the current operator to their worker name in a rig.
+// Resolution order:
+//  1. GT_POLECAT / GT_CREW (running inside a worker session)
+//  2. The rig config "operators" mapping for $USER
+//  3. $USER itself, if a worker with that name exists in any worker root
+//
+// Shared by commands offering a --me convenience.
+func resolveCurrentWorker(r *rig.Rig) (string, error) {
+	if polecat := os.Getenv("GT_POLECAT"); polecat != "" {
+		return polecat, nil
+	}
+	if crew := os.Getenv("GT_CREW"); crew != "" {
+		return crew, nil
+	}
+	return workerForUser(r, os.Getenv("USER"))
+}
+
+// workerForUser looks up the worker name for an OS user in a rig.
+func workerForUser(r *rig.Rig, user string) (string, error) {
+	if user == "" {
+		return "", fmt.Errorf("cannot determine current user: $USER is not set")
+	}
+
+	if cfg, err := rig.LoadRigConfig(r.Path); err == nil {
+		if worker, ok := cfg.Operators[user]; ok && worker != "" {
+			return worker, nil
+		}
+	}
+
+	if slices.Contains(r.Polecats, user) || slices.Contains(r.Crew, user) || slices.Contains(r.Workers, user) {
+		return user, nil
+	}
+
+	return "", fmt.Errorf("no worker found for user %q in rig '%s'\n"+
+		"  Map it in %s: \"operators\": {\"%s\": \"<worker>\"}, or set GT_CREW",
+		user, r.Name, filepath.Join(r.Path, "config.json"), user)
+}
+
+// rigRepoGit returns a Git for the rig's repo base: the shared bare repo
+// (.repo.git) polecat worktrees hang off, or mayor/rig for legacy rigs.
+func rigRepoGit(r *rig.Rig) *git.Git {
+	repo, bare := rigRepoPath(r)
+	if bare {
+		return git.NewGitWithDir(repo, "")
+	}
+	return git.NewGit(repo)
+}
+
+// rigRepoPath returns the path of the rig's repo base (see rigRepoGit) and
+// whether it is the shared bare repo.
+func rigRepoPath(r *rig.Rig) (string, bool) {
+	bareRepo := filepath.Join(r.Path, ".repo.git")
+	if info, err := os.Stat(bareRepo); err == nil && info.IsDir() {
+		return bareRepo, true
+	}
+	return filepath.Join(r.Path, "mayor", "rig"), false
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestGetRig_RigRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir mayor: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "gastown"), 0755); err != nil {
+		t.Fatalf("mkdir rig: %v", err)
+	}
+	rigsConfig := &config.RigsConfig{
+		Version: 1,
+		Rigs:    map[string]config.RigEntry{"gastown": {GitURL: "https://example.com/gastown.git"}},
+	}
+	if err := config.SaveRigsConfig(filepath.Join(root, "mayor", "rigs.json"), rigsConfig); err != nil {
+		t.Fatalf("save rigs.json: %v", err)
+	}
+
+	// Run from outside any workspace
+	t.Chdir(t.TempDir())
+
+	t.Run("flag", func(t *testing.T) {
+		rigRootFlag = root
+		defer func() { rigRootFlag = "" }()
+
+		townRoot, r, err := getRig("gastown")
+		if err != nil {
+			t.Fatalf("getRig: %v", err)
+		}
+		if townRoot != root || r.Name != "gastown" {
+			t.Errorf("got root %q rig %q, want %q gastown", townRoot, r.Name, root)
+		}
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(RigRootEnv, root)
+		if _, _, err := getRig("gastown"); err != nil {
+			t.Fatalf("getRig: %v", err)
+		}
+	})
+
+	t.Run("root without rigs", func(t *testing.T) {
+		t.Setenv(RigRootEnv, t.TempDir())
+		_, _, err := getRig("gastown")
+		if err == nil || !strings.Contains(err.Error(), "no rigs found") {
+			t.Errorf("getRig error = %v, want 'no rigs found'", err)
+		}
+	})
+}
+
+func TestGetRigArg_Path(t *testing.T) {
+	town := t.TempDir()
+	rigPath := filepath.Join(town, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "refinery", "rig"), 0755); err != nil {
+		t.Fatalf("mkdir rig: %v", err)
+	}
+	cfg := `{"type": "rig", "version": 1, "name": "gastown", "git_url": "https://example.com/gastown.git", "beads": {"prefix": "gt"}}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatalf("write config.json: %v", err)
+	}
+	notRig := filepath.Join(town, "notes")
+	if err := os.MkdirAll(notRig, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	// Outside any workspace, so a name lookup would fail.
+	t.Chdir(town)
+
+	for _, arg := range []string{"./gastown", rigPath, "gastown/"} {
+		r, err := getRigArg(arg)
+		if err != nil {
+			t.Fatalf("getRigArg(%q): %v", arg, err)
+		}
+		if r.Name != "gastown" || r.Path != rigPath || !r.HasRefinery || r.Config.Prefix != "gt" {
+			t.Errorf("getRigArg(%q) = %+v, want the rig at %s", arg, r, rigPath)
+		}
+	}
+
+	t.Chdir(rigPath)
+	if r, err := getRigArg("."); err != nil || r.Path != rigPath {
+		t.Errorf("getRigArg(.) = %v, %v; want the rig at %s", r, err, rigPath)
+	}
+
+	if _, err := getRigArg(notRig); err == nil || !strings.Contains(err.Error(), "is not a rig directory") {
+		t.Errorf("getRigArg(%q) error = %v, want 'is not a rig directory'", notRig, err)
+	}
+	if _, err := getRigArg("./missing"); err == nil {
+		t.Error("getRigArg(./missing) should fail")
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var rigVerifyCmd = &cobra.Command{
+	Use:   "verify <rig>",
+	Short: "Check the rig's git repository for corruption",
+	Long: `Check the integrity of a rig's git repository.
+
+After a crash, the rig's repo or one of its worktrees can be left corrupted,
+and every later operation then fails in confusing ways. This runs git fsck
+on the rig repo and checks each worktree, reporting specific problems with
+a suggested fix:
+- Corrupt or missing objects
+- Dangling worktrees (directory deleted, entry left behind)
+- Worktrees whose HEAD no longer resolves
+
+Exits 0 if the repository is sound, 1 if problems were found.
+
+Examples:
+  gt rig verify gastown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRigVerify,
+}
+
+func init() {
+	rigCmd.AddCommand(rigVerifyCmd)
+}
+
+func runRigVerify(cmd *cobra.Command, args []string) error {
+	_, r, err := getRig(args[0])
+	if err != nil {
+		return err
+	}
+
+	g := rigRepoGit(r)
+	g.SetEnv(r.GitEnv())
+
+	fmt.Printf("Verifying %s...\n", style.Bold.Render(r.Name))
+	err = g.Verify()
+	var integrityErr *git.RepoIntegrityError
+	if !errors.As(err, &integrityErr) {
+		if err != nil {
+			return fmt.Errorf("verifying repository: %w", err)
+		}
+		fmt.Printf("%s Repository is sound\n", style.Success.Render("✓"))
+		return nil
+	}
+
+	for _, p := range integrityErr.Problems {
+		fmt.Printf("  %s [%s] %s\n", style.Error.Render("✗"), p.Kind, p.Detail)
+		fmt.Printf("      %s\n", style.Dim.Render("fix: "+p.Hint))
+	}
+	fmt.Printf("\n%s %d problem(s) found\n", style.Error.Render("✗"), len(integrityErr.Problems))
+	return NewSilentExit(1)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -149,7 +150,7 @@ func GetRoleWithContext(cwd, townRoot string) (RoleInfo, error) {
 	// Determine authoritative role
 	if envRole != "" {
 		// Parse env role - it might be simple ("mayor") or compound ("gastown/witness")
-		parsedRole, rig, polecat := parseRoleString(envRole)
+		parsedRole, rig, polecat := parseRoleString(envRole, townRoot)
 		info.Role = parsedRole
 		info.Rig = rig
 		info.Polecat = polecat
@@ -189,7 +190,8 @@ func GetRoleWithContext(cwd, townRoot string) (RoleInfo, error) {
 }
 
 // parseRoleString parses a role string like "mayor", "gastown/witness", or "gastown/polecats/alpha".
-func parseRoleString(s string) (Role, string, string) {
+// townRoot is used to recognize the rig's custom worker roots ("gastown/bots/alpha").
+func parseRoleString(s, townRoot string) (Role, string, string) {
 	s = strings.TrimSpace(s)
 
 	// Simple roles
@@ -214,22 +216,40 @@ func parseRoleString(s string) (Role, string, string) {
 		return RoleWitness, rig, ""
 	case "refinery":
 		return RoleRefinery, rig, ""
-	case "polecats":
-		if len(parts) >= 3 {
-			return RolePolecat, rig, parts[2]
-		}
-		return RolePolecat, rig, ""
-	case "crew":
-		if len(parts) >= 3 {
-			return RoleCrew, rig, parts[2]
-		}
-		return RoleCrew, rig, ""
 	default:
+		if role, ok := workerRole(townRoot, rig, parts[1]); ok {
+			if len(parts) >= 3 {
+				return role, rig, parts[2]
+			}
+			return role, rig, ""
+		}
 		// Might be rig/polecatName format
 		return RolePolecat, rig, parts[1]
 	}
 }
 
+// workerRole maps a rig subdirectory to the role of the worktrees under it:
+// crew for crew/, polecat for polecats/ and for any other worker root the
+// rig configures (see rig.WorkerRoots).
+func workerRole(townRoot, rigName, dir string) (Role, bool) {
+	switch dir {
+	case "polecats":
+		return RolePolecat, true
+	case "crew":
+		return RoleCrew, true
+	}
+	if townRoot == "" || rigName == "" {
+		return "", false
+	}
+	r := &rig.Rig{Name: rigName, Path: filepath.Join(townRoot, rigName)}
+	for _, root := range r.WorkerRoots() {
+		if root == filepath.Join(r.Path, dir) {
+			return RolePolecat, true
+		}
+	}
+	return "", false
+}
+
 // ActorString returns the actor identity string for beads attribution.
 // Format matches beads created_by convention:
 //   - Simple roles: "mayor", "deacon"
@@ -267,32 +287,36 @@ func (info RoleInfo) ActorString() string {
 }
 
 // getRoleHome returns the canonical home directory for a role.
-func getRoleHome(role Role, rig, polecat, townRoot string) string {
+func getRoleHome(role Role, rigName, polecat, townRoot string) string {
 	switch role {
 	case RoleMayor:
 		return filepath.Join(townRoot, "mayor")
 	case RoleDeacon:
 		return filepath.Join(townRoot, "deacon")
 	case RoleWitness:
-		if rig == "" {
+		if rigName == "" {
 			return ""
 		}
-		return filepath.Join(townRoot, rig, "witness", "rig")
+		return filepath.Join(townRoot, rigName, "witness", "rig")
 	case RoleRefinery:
-		if rig == "" {
+		if rigName == "" {
 			return ""
 		}
-		return filepath.Join(townRoot, rig, "refinery", "rig")
+		return filepath.Join(townRoot, rigName, "refinery", "rig")
 	case RolePolecat:
-		if rig == "" || polecat == "" {
+		if rigName == "" || polecat == "" {
 			return ""
 		}
-		return filepath.Join(townRoot, rig, "polecats", polecat)
+		r := &rig.Rig{Name: rigName, Path: filepath.Join(townRoot, rigName)}
+		if dir := r.WorkerDir(polecat); dir != "" {
+			return dir
+		}
+		return filepath.Join(r.PolecatsDir(), polecat)
 	case RoleCrew:
-		if rig == "" || polecat == "" {
+		if rigName == "" || polecat == "" {
 			return ""
 		}
-		return filepath.Join(townRoot, rig, "crew", polecat)
+		return filepath.Join(townRoot, rigName, "crew", polecat)
 	default:
 		return ""
 	}
@@ -348,7 +372,7 @@ func runRoleHome(cmd *cobra.Command, args []string) error {
 
 	if len(args) > 0 {
 		// Explicit role provided
-		role, rig, polecat = parseRoleString(args[0])
+		role, rig, polecat = parseRoleString(args[0], townRoot)
 
 		// Override with flags if provided
 		if roleRig != "" {
@@ -406,7 +430,7 @@ func runRoleDetect(cmd *cobra.Command, args []string) error {
 	// Check if env var disagrees
 	envRole := os.Getenv(EnvGTRole)
 	if envRole != "" {
-		parsedRole, _, _ := parseRoleString(envRole)
+		parsedRole, _, _ := parseRoleString(envRole, townRoot)
 		if parsedRole != ctx.Role {
 			fmt.Println()
 			fmt.Printf("%s\n", style.Bold.Render("⚠️  Mismatch with $GT_ROLE"))
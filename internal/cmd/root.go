@@ -3,11 +3,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
 )
 
+// envFlag is the global --env flag.
+var envFlag string
+
 var rootCmd = &cobra.Command{
 	Use:     "gt",
 	Short:   "Gas Town - Multi-agent workspace manager",
@@ -16,7 +21,31 @@ var rootCmd = &cobra.Command{
 
 It coordinates agent spawning, work distribution, and communication
 across distributed teams of AI agents working on shared codebases.`,
-	PersistentPreRunE: checkBeadsDependency,
+	PersistentPreRunE: persistentPreRun,
+}
+
+// persistentPreRun runs before every command: selecting the --env config
+// overlay, the beads check, then opening the --out file.
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	if err := applyEnvFlag(); err != nil {
+		return err
+	}
+	if err := checkBeadsDependency(cmd, args); err != nil {
+		return err
+	}
+	return openStructuredOutput()
+}
+
+// applyEnvFlag exports --env as $GASTOWN_ENV, so config loading here and in
+// every process gt starts (agents, the daemon) picks the same overlay.
+func applyEnvFlag() error {
+	if envFlag == "" {
+		return nil
+	}
+	if err := config.ValidateEnv(envFlag); err != nil {
+		return fmt.Errorf("--env: %w", err)
+	}
+	return os.Setenv(config.EnvVar, envFlag)
 }
 
 // Commands that don't require beads to be installed/checked.
@@ -45,13 +74,18 @@ func checkBeadsDependency(cmd *cobra.Command, args []string) error {
 // Execute runs the root command and returns an exit code.
 // The caller (main) should call os.Exit with this code.
 func Execute() int {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if closeErr := closeStructuredOutput(); closeErr != nil && err == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", closeErr)
+		return 1
+	}
+	if err != nil {
 		// Check for silent exit (scripting commands that signal status via exit code)
 		if code, ok := IsSilentExit(err); ok {
 			return code
 		}
 		// Other errors already printed by cobra
-		return 1
+		return exitCodeForError(err)
 	}
 	return 0
 }
@@ -86,8 +120,13 @@ func init() {
 	rootCmd.SetHelpCommandGroupID(GroupDiag)
 	rootCmd.SetCompletionCommandGroupID(GroupConfig)
 
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	// Global flags
+	rootCmd.PersistentFlags().StringVar(&rigRootFlag, "rig-root", "",
+		"Directory containing the rigs (default: town of the current directory, or $"+RigRootEnv+")")
+	rootCmd.PersistentFlags().StringVar(&outFlag, "out", "",
+		"Write structured output (--json) to this file instead of stdout")
+	rootCmd.PersistentFlags().StringVar(&envFlag, "env", "",
+		"Environment whose rig config overlay (config.<env>.json) to apply (default: $"+config.EnvVar+")")
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
@@ -639,7 +639,7 @@ func runSessionCheck(cmd *cobra.Command, args []string) error {
 	totalCrashed := 0
 
 	for _, r := range rigs {
-		polecatsDir := filepath.Join(r.Path, "polecats")
+		polecatsDir := r.PolecatsDir()
 		entries, err := os.ReadDir(polecatsDir)
 		if err != nil {
 			continue // Rig might not have polecats
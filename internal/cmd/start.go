@@ -249,6 +249,8 @@ func startRigAgents(t *tmux.Tmux, townRoot string) {
 		if err := refineryMgr.Start(false); err != nil {
 			if errors.Is(err, refinery.ErrAlreadyRunning) {
 				fmt.Printf("  %s %s refinery already running\n", style.Dim.Render("○"), r.Name)
+			} else if errors.Is(err, refinery.ErrRigPaused) {
+				fmt.Printf("  %s %s refinery skipped (rig paused)\n", style.Dim.Render("○"), r.Name)
 			} else {
 				fmt.Printf("  %s %s refinery failed: %v\n", style.Dim.Render("○"), r.Name, err)
 			}
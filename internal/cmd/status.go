@@ -1,14 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -145,15 +144,23 @@ func runStatusWatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("interval must be positive, got %d", statusInterval)
 	}
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	defer signal.Stop(sigChan)
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	refreshes := 0
+
+	return runInterruptible(func(ctx context.Context) error {
+		return statusWatchLoop(ctx, cmd, args, isTTY, &refreshes)
+	}, func() {
+		if isTTY {
+			fmt.Printf("Stopped after %d refresh(es).\n", refreshes)
+		}
+	})
+}
 
+// statusWatchLoop redraws status every statusInterval seconds until ctx is done.
+func statusWatchLoop(ctx context.Context, cmd *cobra.Command, args []string, isTTY bool, refreshes *int) error {
 	ticker := time.NewTicker(time.Duration(statusInterval) * time.Second)
 	defer ticker.Stop()
 
-	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
-
 	for {
 		if isTTY {
 			fmt.Print("\033[H\033[2J") // ANSI: cursor home + clear screen
@@ -170,13 +177,11 @@ func runStatusWatch(cmd *cobra.Command, args []string) error {
 		if err := runStatusOnce(cmd, args); err != nil {
 			fmt.Printf("Error: %v\n", err)
 		}
+		*refreshes++
 
 		select {
-		case <-sigChan:
-			if isTTY {
-				fmt.Println("\nStopped.")
-			}
-			return nil
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-ticker.C:
 		}
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -141,8 +142,10 @@ func runUp(cmd *cobra.Command, args []string) error {
 
 		mgr := refinery.NewManager(r)
 		if err := mgr.Start(false); err != nil {
-			if err == refinery.ErrAlreadyRunning {
+			if errors.Is(err, refinery.ErrAlreadyRunning) {
 				printStatus(fmt.Sprintf("Refinery (%s)", rigName), true, mgr.SessionName())
+			} else if errors.Is(err, refinery.ErrRigPaused) {
+				printStatus(fmt.Sprintf("Refinery (%s)", rigName), true, "skipped (rig paused)")
 			} else {
 				printStatus(fmt.Sprintf("Refinery (%s)", rigName), false, err.Error())
 				allOK = false
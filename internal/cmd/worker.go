@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var workerMRJSON bool
+
+var workerCmd = &cobra.Command{
+	Use:     "worker",
+	GroupID: GroupAgents,
+	Short:   "Inspect a worker (polecat or crew) and its work",
+	RunE:    requireSubcommand,
+	Long: `Inspect a worker - a polecat or crew member - and its work in a rig.
+
+These are worker-centric views that complement the MR-centric 'gt mq'
+commands.`,
+}
+
+var workerMRCmd = &cobra.Command{
+	Use:   "mr <rig> <worker>",
+	Short: "Show a worker's open merge requests and whether they can merge",
+	Long: `Show the open merge requests submitted by a worker, with their status
+and the refinery's readiness checklist (as in 'gt mq status').
+
+This answers "what's the status of my submission?" without looking up the
+MR ID first. The worker name is matched case-insensitively against the MR's
+worker field.
+
+Examples:
+  gt worker mr greenplace Toast
+  gt worker mr greenplace dave --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runWorkerMR,
+}
+
+func init() {
+	workerMRCmd.Flags().BoolVar(&workerMRJSON, "json", false, "Output as JSON")
+
+	workerCmd.AddCommand(workerMRCmd)
+	rootCmd.AddCommand(workerCmd)
+}
+
+// WorkerMR is one of a worker's MRs in gt worker mr JSON output.
+type WorkerMR struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Branch     string `json:"branch,omitempty"`
+	Target     string `json:"target,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	AgeSeconds int64  `json:"age_seconds"`
+
+	// Merging is set if the refinery is merging the MR right now.
+	Merging bool `json:"merging,omitempty"`
+	Draft   bool `json:"draft,omitempty"`
+
+	// Readiness is the refinery's merge checklist for the MR
+	Readiness *refinery.Readiness `json:"readiness,omitempty"`
+}
+
+func runWorkerMR(cmd *cobra.Command, args []string) error {
+	rigName, worker := args[0], args[1]
+
+	mgr, r, rigName, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	b := beads.New(r.BeadsPath())
+	issues, err := b.List(beads.ListOptions{Type: "merge-request", Priority: -1})
+	if err != nil {
+		return beadsQueryError("querying merge queue", err, r.BeadsPath())
+	}
+
+	merging := make(map[string]bool)
+	if ref, err := mgr.Status(); err == nil {
+		for _, id := range ref.Merging() {
+			merging[id] = true
+		}
+	}
+
+	var mrs []WorkerMR
+	for _, issue := range workerMRs(issues, worker) {
+		mr := WorkerMR{
+			ID:         issue.ID,
+			Title:      issue.Title,
+			Status:     issue.Status,
+			CreatedAt:  issue.CreatedAt,
+			AgeSeconds: newMRListItem(issue).AgeSeconds,
+			Merging:    merging[issue.ID],
+		}
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			mr.Branch = fields.Branch
+			mr.Target = fields.Target
+			mr.Draft = fields.Draft
+		}
+		// Best-effort, as in gt mq status: the MR is still listed without it
+		if readiness, err := mgr.Readiness(issue.ID); err == nil {
+			mr.Readiness = readiness
+		}
+		mrs = append(mrs, mr)
+	}
+
+	if workerMRJSON {
+		return outputJSON(mrs)
+	}
+
+	fmt.Printf("%s Merge requests from %s in '%s':\n", style.Bold.Render("📋"), worker, rigName)
+	if len(mrs) == 0 {
+		fmt.Printf("\n  %s\n", style.Dim.Render("(no open merge requests)"))
+		return nil
+	}
+	for _, mr := range mrs {
+		fmt.Printf("\n%s %s\n", style.Bold.Render(mr.ID), mr.Title)
+		status := formatStatus(mr.Status)
+		switch {
+		case mr.Merging:
+			status = style.Bold.Render("▶ merging")
+		case mr.Draft:
+			status += style.Dim.Render(" (draft)")
+		}
+		fmt.Printf("   State:  %s   Age: %s\n", status, formatMRAge(mr.CreatedAt))
+		if mr.Branch != "" {
+			target := mr.Target
+			if target == "" {
+				target = "(default)"
+			}
+			fmt.Printf("   Branch: %s → %s\n", mr.Branch, target)
+		}
+		printMRReadiness(mr.Readiness)
+	}
+	return nil
+}
+
+// workerMRs returns the MRs in issues submitted by worker (matched
+// case-insensitively), leaving out closed ones.
+func workerMRs(issues []*beads.Issue, worker string) []*beads.Issue {
+	var mrs []*beads.Issue
+	for _, issue := range issues {
+		if issue.Status == "closed" {
+			continue
+		}
+		fields := beads.ParseMRFields(issue)
+		if fields == nil || !strings.EqualFold(fields.Worker, worker) {
+			continue
+		}
+		mrs = append(mrs, issue)
+	}
+	return mrs
+}
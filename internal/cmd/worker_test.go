@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestWorkerMRs(t *testing.T) {
+	issues := []*beads.Issue{
+		{ID: "gt-mr-open", Status: "open", Description: "branch: polecat/Toast/gt-a\nworker: Toast"},
+		{ID: "gt-mr-active", Status: "in_progress", Description: "branch: polecat/Toast/gt-b\nworker: Toast"},
+		{ID: "gt-mr-closed", Status: "closed", Description: "branch: polecat/Toast/gt-c\nworker: Toast"},
+		{ID: "gt-mr-other", Status: "open", Description: "branch: polecat/Nux/gt-d\nworker: Nux"},
+		{ID: "gt-mr-nofields", Status: "open"},
+	}
+
+	got := workerMRs(issues, "toast")
+	var ids []string
+	for _, issue := range got {
+		ids = append(ids, issue.ID)
+	}
+	if len(ids) != 2 || ids[0] != "gt-mr-open" || ids[1] != "gt-mr-active" {
+		t.Errorf("workerMRs(toast) = %v, want [gt-mr-open gt-mr-active]", ids)
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrInvalidHotBranch indicates a hot_branches pattern that can't be parsed.
+var ErrInvalidHotBranch = errors.New("invalid hot_branches pattern")
+
+// HotBranches are branch patterns whose MRs the refinery always treats as
+// top priority, whatever their own priority. Patterns are globs as in
+// path.Match ("main", "release/*"); * does not cross a slash.
+type HotBranches []string
+
+// ParseHotBranches validates hot_branches patterns.
+func ParseHotBranches(patterns []string) (HotBranches, error) {
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" {
+			return nil, fmt.Errorf("%w: empty pattern", ErrInvalidHotBranch)
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidHotBranch, p, err)
+		}
+	}
+	return HotBranches(patterns), nil
+}
+
+// Match reports whether any of branches matches a hot pattern. Empty
+// branches never match.
+func (h HotBranches) Match(branches ...string) bool {
+	for _, branch := range branches {
+		if branch == "" {
+			continue
+		}
+		for _, p := range h {
+			if ok, _ := path.Match(p, branch); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseHotBranches_Invalid(t *testing.T) {
+	for _, p := range []string{"", "  ", "release/[", "main\\"} {
+		if _, err := ParseHotBranches([]string{"main", p}); !errors.Is(err, ErrInvalidHotBranch) {
+			t.Errorf("ParseHotBranches(%q) = %v, want ErrInvalidHotBranch", p, err)
+		}
+	}
+}
+
+func TestHotBranches_Match(t *testing.T) {
+	hot, err := ParseHotBranches([]string{"main", "release/*"})
+	if err != nil {
+		t.Fatalf("ParseHotBranches: %v", err)
+	}
+
+	tests := []struct {
+		branches []string
+		want     bool
+	}{
+		{[]string{"main"}, true},
+		{[]string{"release/1.2"}, true},
+		{[]string{"polecat/Toast", "release/1.2"}, true},
+		{[]string{"release/1.2/hotfix"}, false},
+		{[]string{"polecat/Toast", "develop"}, false},
+		{[]string{""}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := hot.Match(tt.branches...); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.branches, got, tt.want)
+		}
+	}
+
+	if HotBranches(nil).Match("main") {
+		t.Error("empty HotBranches should match nothing")
+	}
+}
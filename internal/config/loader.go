@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
 )
 
@@ -140,9 +142,10 @@ func validateRigsConfig(c *RigsConfig) error {
 	return nil
 }
 
-// LoadRigConfig loads and validates a rig configuration file.
+// LoadRigConfig loads and validates a rig configuration file, with the
+// active environment's overlay merged in (see ReadWithOverlay).
 func LoadRigConfig(path string) (*RigConfig, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally, not from user input
+	data, _, err := ReadWithOverlay(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
@@ -217,6 +220,87 @@ func validateRigSettings(c *RigSettings) error {
 // ErrInvalidOnConflict indicates an invalid on_conflict strategy.
 var ErrInvalidOnConflict = errors.New("invalid on_conflict strategy")
 
+// ParseLoopInterval parses a refinery loop_interval, rejecting values below
+// MinLoopInterval.
+func ParseLoopInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid loop_interval: %w", err)
+	}
+	if d < MinLoopInterval {
+		return 0, fmt.Errorf("invalid loop_interval: %s is below the minimum of %s", d, MinLoopInterval)
+	}
+	return d, nil
+}
+
+// ParseMRTimeout parses a refinery mr_timeout. "0" disables the timeout;
+// negative values are rejected.
+func ParseMRTimeout(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mr_timeout: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid mr_timeout: %s is negative", d)
+	}
+	return d, nil
+}
+
+// ValidateSignaturePolicy checks a merge queue signature_policy. Empty is
+// valid and means off.
+func ValidateSignaturePolicy(policy string) error {
+	switch policy {
+	case "", SignaturePolicyOff, SignaturePolicyBlock, SignaturePolicyReject:
+		return nil
+	}
+	return fmt.Errorf("invalid signature_policy %q: want '%s', '%s' or '%s'",
+		policy, SignaturePolicyOff, SignaturePolicyBlock, SignaturePolicyReject)
+}
+
+// ValidateMergedIssueStatus checks a merge queue merged_issue_status
+// against the statuses beads accepts. Empty is valid and means closed.
+func ValidateMergedIssueStatus(status string) error {
+	if status != "" && !beads.ValidStatus(status) {
+		return fmt.Errorf("invalid merged_issue_status %q: want one of %s", status, strings.Join(beads.Statuses, ", "))
+	}
+	return nil
+}
+
+// ValidateMergedIssueLabels checks merge queue merged_issue_labels.
+func ValidateMergedIssueLabels(labels []string) error {
+	for _, l := range labels {
+		if strings.TrimSpace(l) == "" {
+			return fmt.Errorf("invalid merged_issue_labels: empty label")
+		}
+	}
+	return nil
+}
+
+// MaxPushRetries caps merge_queue.push_retries, so a dead remote fails an
+// MR in minutes rather than stalling the queue.
+const MaxPushRetries = 10
+
+// ValidatePushRetries checks a merge queue push_retries.
+func ValidatePushRetries(n int) error {
+	if n < 0 || n > MaxPushRetries {
+		return fmt.Errorf("invalid push_retries %d: must be between 0 and %d", n, MaxPushRetries)
+	}
+	return nil
+}
+
+// mrIDPrefixPattern is what an mr_id_prefix may look like: lowercase
+// letters, digits and inner hyphens, as in bead ID prefixes.
+var mrIDPrefixPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidateMRIDPrefix checks a merge queue mr_id_prefix. Empty is valid and
+// leaves MR IDs to beads.
+func ValidateMRIDPrefix(prefix string) error {
+	if prefix != "" && !mrIDPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid mr_id_prefix %q: use lowercase letters, digits and hyphens (e.g. \"acme\")", prefix)
+	}
+	return nil
+}
+
 // validateMergeQueueConfig validates a MergeQueueConfig.
 func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	// Validate on_conflict strategy
@@ -281,10 +365,23 @@ func LoadRigSettings(path string) (*RigSettings, error) {
 	if err := validateRigSettings(&settings); err != nil {
 		return nil, err
 	}
+	warnUnknownRigSettingsKeys(path, data)
 
 	return &settings, nil
 }
 
+// warnUnknownRigSettingsKeys warns about misspelled or unsupported keys in
+// a rig settings file, at the top level and in its merge_queue section.
+func warnUnknownRigSettingsKeys(path string, data []byte) {
+	WarnUnknownKeys(path, "", data, RigSettings{})
+	var raw struct {
+		MergeQueue json.RawMessage `json:"merge_queue"`
+	}
+	if err := json.Unmarshal(data, &raw); err == nil && raw.MergeQueue != nil {
+		WarnUnknownKeys(path, "merge_queue", raw.MergeQueue, MergeQueueConfig{})
+	}
+}
+
 // SaveRigSettings saves rig settings to a file.
 func SaveRigSettings(path string, settings *RigSettings) error {
 	if err := validateRigSettings(settings); err != nil {
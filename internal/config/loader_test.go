@@ -1661,3 +1661,59 @@ func TestLookupAgentConfigWithRigSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMRIDPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "acme", "gt2", "acme-east"} {
+		if err := ValidateMRIDPrefix(prefix); err != nil {
+			t.Errorf("ValidateMRIDPrefix(%q) = %v, want nil", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"Acme", "acme-", "-acme", "acme_mr", "ac me", "acme--east"} {
+		if err := ValidateMRIDPrefix(prefix); err == nil {
+			t.Errorf("ValidateMRIDPrefix(%q) = nil, want error", prefix)
+		}
+	}
+}
+
+func TestValidateSignaturePolicy(t *testing.T) {
+	for _, policy := range []string{"", "off", "block", "reject"} {
+		if err := ValidateSignaturePolicy(policy); err != nil {
+			t.Errorf("ValidateSignaturePolicy(%q) = %v, want nil", policy, err)
+		}
+	}
+	for _, policy := range []string{"Block", "warn", "on"} {
+		if err := ValidateSignaturePolicy(policy); err == nil {
+			t.Errorf("ValidateSignaturePolicy(%q) = nil, want error", policy)
+		}
+	}
+}
+
+func TestValidateMergedIssueStatus(t *testing.T) {
+	for _, status := range []string{"", "closed", "deferred", "in_progress"} {
+		if err := ValidateMergedIssueStatus(status); err != nil {
+			t.Errorf("ValidateMergedIssueStatus(%q) = %v, want nil", status, err)
+		}
+	}
+	for _, status := range []string{"Closed", "merged", "pending deploy"} {
+		if err := ValidateMergedIssueStatus(status); err == nil {
+			t.Errorf("ValidateMergedIssueStatus(%q) = nil, want error", status)
+		}
+	}
+	if err := ValidateMergedIssueLabels([]string{"pending-deploy", " "}); err == nil {
+		t.Error("ValidateMergedIssueLabels should reject an empty label")
+	}
+}
+
+func TestParseMRTimeout(t *testing.T) {
+	for in, want := range map[string]time.Duration{"30m": 30 * time.Minute, "0": 0, "90s": 90 * time.Second} {
+		got, err := ParseMRTimeout(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMRTimeout(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"-5m", "soon", ""} {
+		if _, err := ParseMRTimeout(in); err == nil {
+			t.Errorf("ParseMRTimeout(%q) = nil error, want error", in)
+		}
+	}
+}
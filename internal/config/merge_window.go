@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMergeWindow indicates a merge_window entry that can't be parsed.
+var ErrInvalidMergeWindow = errors.New("invalid merge_window")
+
+// MergeWindow is the set of times the refinery may merge, in local time.
+// An empty window is always open.
+type MergeWindow []WindowRange
+
+// WindowRange is one merge_window entry: a daily time range, optionally
+// limited to some weekdays. A range whose end is not after its start
+// crosses midnight ("22:00-06:00"); its early hours belong to the day
+// after each listed day.
+type WindowRange struct {
+	Days  [7]bool       // Indexed by time.Weekday
+	Start time.Duration // Offset from midnight
+	End   time.Duration // Offset from midnight
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseMergeWindow parses merge_window entries such as "09:00-17:00",
+// "Mon-Fri 09:00-17:00" or "Sat,Sun 10:00-12:00". A time is inside the
+// window if it is inside any entry.
+func ParseMergeWindow(specs []string) (MergeWindow, error) {
+	window := make(MergeWindow, 0, len(specs))
+	for _, spec := range specs {
+		r, err := parseWindowRange(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidMergeWindow, spec, err)
+		}
+		window = append(window, r)
+	}
+	return window, nil
+}
+
+func parseWindowRange(spec string) (WindowRange, error) {
+	var r WindowRange
+	fields := strings.Fields(spec)
+	var days, hours string
+	switch len(fields) {
+	case 1:
+		hours = fields[0]
+		for d := range r.Days {
+			r.Days[d] = true
+		}
+	case 2:
+		days, hours = fields[0], fields[1]
+		if err := parseWindowDays(days, &r.Days); err != nil {
+			return r, err
+		}
+	default:
+		return r, fmt.Errorf("want [days] HH:MM-HH:MM")
+	}
+
+	start, end, ok := strings.Cut(hours, "-")
+	if !ok {
+		return r, fmt.Errorf("want a time range HH:MM-HH:MM")
+	}
+	var err error
+	if r.Start, err = parseClock(start); err != nil {
+		return r, err
+	}
+	if r.End, err = parseClock(end); err != nil {
+		return r, err
+	}
+	if r.Start == r.End {
+		return r, fmt.Errorf("empty time range")
+	}
+	return r, nil
+}
+
+// parseWindowDays parses "Mon", "Mon-Fri" or "Sat,Sun" (ranges may wrap,
+// e.g. "Fri-Mon").
+func parseWindowDays(spec string, days *[7]bool) error {
+	for _, part := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdays[strings.ToLower(from)]
+		if !ok {
+			return fmt.Errorf("unknown day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdays[strings.ToLower(to)]; !ok {
+				return fmt.Errorf("unknown day %q", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// parseClock parses HH:MM (00:00-24:00) as an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time %q: want HH:MM", s)
+	}
+	if h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// Strings renders the window as merge_window entries, the inverse of
+// ParseMergeWindow.
+func (w MergeWindow) Strings() []string {
+	specs := make([]string, len(w))
+	for i, r := range w {
+		specs[i] = r.String()
+	}
+	return specs
+}
+
+// String renders the range as a merge_window entry, e.g. "Mon-Fri 09:00-17:00".
+// Days are listed Monday first; every day is left out.
+func (r WindowRange) String() string {
+	hours := formatClock(r.Start) + "-" + formatClock(r.End)
+	var parts []string
+	week := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	for i := 0; i < len(week); {
+		if !r.Days[week[i]] {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(week) && r.Days[week[j+1]] {
+			j++
+		}
+		part := week[i].String()[:3]
+		if j > i {
+			part += "-" + week[j].String()[:3]
+		}
+		parts = append(parts, part)
+		i = j + 1
+	}
+	if len(parts) == 1 && parts[0] == "Mon-Sun" {
+		return hours
+	}
+	return strings.Join(parts, ",") + " " + hours
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Open reports whether t falls inside the window.
+func (w MergeWindow) Open(t time.Time) bool {
+	if len(w) == 0 {
+		return true
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	day := t.Weekday()
+	yesterday := (day + 6) % 7
+	for _, r := range w {
+		if r.Start < r.End {
+			if r.Days[day] && offset >= r.Start && offset < r.End {
+				return true
+			}
+			continue
+		}
+		// Crosses midnight
+		if (r.Days[day] && offset >= r.Start) || (r.Days[yesterday] && offset < r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpen returns the next time at or after t that the window opens, to
+// the minute, or the zero time if it never opens within a week.
+func (w MergeWindow) NextOpen(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	for i := 0; i <= 7*24*60; i++ {
+		candidate := t.Add(time.Duration(i) * time.Minute)
+		if w.Open(candidate) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
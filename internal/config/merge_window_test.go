@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMergeWindow_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"9-17",
+		"09:00",
+		"09:00-09:00",
+		"25:00-26:00",
+		"Mon-Fry 09:00-17:00",
+		"Mon Tue 09:00-17:00",
+	} {
+		if _, err := ParseMergeWindow([]string{spec}); !errors.Is(err, ErrInvalidMergeWindow) {
+			t.Errorf("ParseMergeWindow(%q) = %v, want ErrInvalidMergeWindow", spec, err)
+		}
+	}
+}
+
+func TestMergeWindow_Open(t *testing.T) {
+	window, err := ParseMergeWindow([]string{"Mon-Fri 09:00-17:00", "Sat 22:00-02:00"})
+	if err != nil {
+		t.Fatalf("ParseMergeWindow: %v", err)
+	}
+
+	// 2026-01-05 is a Monday
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 1, day, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"monday morning", at(5, 9, 0), true},
+		{"monday before opening", at(5, 8, 59), false},
+		{"friday closing time", at(9, 17, 0), false},
+		{"saturday afternoon", at(10, 14, 0), false},
+		{"saturday night", at(10, 23, 30), true},
+		{"past midnight into sunday", at(11, 1, 0), true},
+		{"sunday after the late window", at(11, 2, 0), false},
+	}
+	for _, tt := range tests {
+		if got := window.Open(tt.t); got != tt.want {
+			t.Errorf("%s: Open(%s) = %v, want %v", tt.name, tt.t.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+
+	if next := window.NextOpen(at(10, 14, 0)); !next.Equal(at(10, 22, 0)) {
+		t.Errorf("NextOpen(Sat 14:00) = %s, want Sat 22:00", next.Format("Mon 15:04"))
+	}
+	if !MergeWindow(nil).Open(at(10, 14, 0)) {
+		t.Error("empty window should always be open")
+	}
+}
+
+func TestMergeWindow_Strings(t *testing.T) {
+	specs := []string{"09:00-17:00", "Mon-Fri 09:00-17:00", "Sat,Sun 22:00-02:00", "Mon,Wed 00:00-24:00"}
+	window, err := ParseMergeWindow(specs)
+	if err != nil {
+		t.Fatalf("ParseMergeWindow: %v", err)
+	}
+	got := window.Strings()
+	want := []string{"09:00-17:00", "Mon-Fri 09:00-17:00", "Sat-Sun 22:00-02:00", "Mon,Wed 00:00-24:00"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Strings()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// The rendered form parses back to the same window
+	again, err := ParseMergeWindow(got)
+	if err != nil {
+		t.Fatalf("ParseMergeWindow(%q): %v", got, err)
+	}
+	for i := range window {
+		if again[i] != window[i] {
+			t.Errorf("round trip of %q = %+v, want %+v", specs[i], again[i], window[i])
+		}
+	}
+}
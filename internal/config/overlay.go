@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// EnvVar selects the environment (e.g. "staging") whose overlay is merged
+// over a rig's config.json. gt's --env flag sets it for the command and
+// everything it starts.
+const EnvVar = "GASTOWN_ENV"
+
+// ErrInvalidEnv indicates an environment name that can't name an overlay.
+var ErrInvalidEnv = errors.New("invalid environment name")
+
+var envNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateEnv checks an environment name: letters, digits, '-' and '_',
+// so it can't reach outside the rig directory.
+func ValidateEnv(env string) error {
+	if !envNameRe.MatchString(env) {
+		return fmt.Errorf("%w: %q (use letters, digits, '-' and '_')", ErrInvalidEnv, env)
+	}
+	return nil
+}
+
+// ActiveEnv returns the environment selected by $GASTOWN_ENV, or "" if none.
+func ActiveEnv() string {
+	return strings.TrimSpace(os.Getenv(EnvVar))
+}
+
+// OverlayPath returns the overlay file for the config at path in env:
+// config.json becomes config.<env>.json, next to it.
+func OverlayPath(path, env string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// ReadWithOverlay reads the config file at path and, if an environment is
+// active and has an overlay file, merges the overlay over it (see MergeJSON).
+// It also returns the overlay applied, or "" if none. A rig without an
+// overlay for the active environment just uses its base config. Errors
+// reading path are returned as-is, so os.IsNotExist still works.
+func ReadWithOverlay(path string) ([]byte, string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return nil, "", err
+	}
+
+	env := ActiveEnv()
+	if env == "" {
+		return data, "", nil
+	}
+	if err := ValidateEnv(env); err != nil {
+		return nil, "", fmt.Errorf("$%s: %w", EnvVar, err)
+	}
+
+	overlayPath := OverlayPath(path, env)
+	overlay, err := os.ReadFile(overlayPath) //nolint:gosec // G304: env is validated above
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, "", nil
+		}
+		return nil, "", fmt.Errorf("reading overlay: %w", err)
+	}
+
+	merged, err := MergeJSON(data, overlay)
+	if err != nil {
+		return nil, "", fmt.Errorf("merging %s over %s: %w", overlayPath, path, err)
+	}
+	return merged, overlayPath, nil
+}
+
+// MergeJSON deep-merges the JSON object overlay over base. Objects (nested
+// sections like merge_queue, and maps like env or operators) are merged key
+// by key; any other overlay value, arrays included, replaces the base value
+// whole; an overlay null removes the key. Both must be JSON objects. The
+// result has sorted keys, so the same inputs always give the same bytes.
+func MergeJSON(base, overlay []byte) ([]byte, error) {
+	b, err := decodeObject(base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	o, err := decodeObject(overlay)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: %w", err)
+	}
+	return json.Marshal(mergeObjects(b, o))
+}
+
+// decodeObject decodes a JSON object, keeping numbers exact.
+func decodeObject(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, errors.New("not a JSON object")
+	}
+	return obj, nil
+}
+
+// mergeObjects merges overlay into base in place and returns it.
+func mergeObjects(base, overlay map[string]any) map[string]any {
+	for key, ov := range overlay {
+		if ov == nil {
+			delete(base, key)
+			continue
+		}
+		bobj, bok := base[key].(map[string]any)
+		oobj, ook := ov.(map[string]any)
+		if bok && ook {
+			base[key] = mergeObjects(bobj, oobj)
+			continue
+		}
+		base[key] = ov
+	}
+	return base
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeJSON(t *testing.T) {
+	base := `{
+		"name": "gastown",
+		"git_url": "git@dev.example.com:gastown.git",
+		"env": {"GIT_TRACE": "0", "REGION": "dev"},
+		"clone_args": ["--depth=1", "--jobs=4"],
+		"merge_queue": {"poll_interval": "30s", "run_tests": true, "retry_flaky_tests": 2},
+		"build_root": "build"
+	}`
+	overlay := `{
+		"git_url": "git@prod.example.com:gastown.git",
+		"env": {"REGION": "prod", "CI": "1"},
+		"clone_args": ["--filter=blob:none"],
+		"merge_queue": {"poll_interval": "5m"},
+		"build_root": null
+	}`
+
+	got, err := MergeJSON([]byte(base), []byte(overlay))
+	if err != nil {
+		t.Fatalf("MergeJSON: %v", err)
+	}
+	want := `{"clone_args":["--filter=blob:none"],` +
+		`"env":{"CI":"1","GIT_TRACE":"0","REGION":"prod"},` +
+		`"git_url":"git@prod.example.com:gastown.git",` +
+		`"merge_queue":{"poll_interval":"5m","retry_flaky_tests":2,"run_tests":true},` +
+		`"name":"gastown"}`
+	if string(got) != want {
+		t.Errorf("MergeJSON =\n%s\nwant\n%s", got, want)
+	}
+
+	// Deterministic: the same inputs always give the same bytes
+	for i := 0; i < 10; i++ {
+		again, _ := MergeJSON([]byte(base), []byte(overlay))
+		if string(again) != string(got) {
+			t.Fatalf("MergeJSON not deterministic:\n%s\n%s", got, again)
+		}
+	}
+
+	if _, err := MergeJSON([]byte(base), []byte(`["not", "an", "object"]`)); err == nil {
+		t.Error("MergeJSON with an array overlay succeeded, want error")
+	}
+	if _, err := MergeJSON([]byte(base), []byte(`null`)); err == nil {
+		t.Error("MergeJSON with a null overlay succeeded, want error")
+	}
+}
+
+func TestReadWithOverlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"name":"gastown","git_url":"dev"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.staging.json"), []byte(`{"git_url":"staging"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		env         string
+		want        string
+		wantOverlay string
+	}{
+		{"", `{"name":"gastown","git_url":"dev"}`, ""},
+		{"staging", `{"git_url":"staging","name":"gastown"}`, filepath.Join(dir, "config.staging.json")},
+		{"prod", `{"name":"gastown","git_url":"dev"}`, ""}, // no overlay: base as-is
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.env)
+			data, overlay, err := ReadWithOverlay(path)
+			if err != nil {
+				t.Fatalf("ReadWithOverlay: %v", err)
+			}
+			if string(data) != tt.want || overlay != tt.wantOverlay {
+				t.Errorf("ReadWithOverlay = %s, %q; want %s, %q", data, overlay, tt.want, tt.wantOverlay)
+			}
+		})
+	}
+
+	t.Setenv(EnvVar, "../other")
+	if _, _, err := ReadWithOverlay(path); !errors.Is(err, ErrInvalidEnv) {
+		t.Errorf("ReadWithOverlay with env ../other = %v, want ErrInvalidEnv", err)
+	}
+
+	t.Setenv(EnvVar, "")
+	if _, _, err := ReadWithOverlay(filepath.Join(dir, "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("ReadWithOverlay of missing file = %v, want not-exist error", err)
+	}
+}
+
+func TestLoadRigConfig_Overlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"type":"rig","version":1,"name":"gastown","git_url":"dev"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.prod.json"), []byte(`{"name":null}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The merged config is validated like any other: dropping the name fails
+	t.Setenv(EnvVar, "prod")
+	if _, err := LoadRigConfig(path); !errors.Is(err, ErrMissingField) {
+		t.Errorf("LoadRigConfig with overlay removing name = %v, want ErrMissingField", err)
+	}
+}
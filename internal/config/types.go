@@ -372,6 +372,17 @@ const (
 	OnConflictAutoRebase = "auto_rebase"
 )
 
+// SignaturePolicy constants.
+const (
+	SignaturePolicyOff    = "off"
+	SignaturePolicyBlock  = "block"
+	SignaturePolicyReject = "reject"
+)
+
+// MinLoopInterval is the shortest allowed loop_interval, so a
+// misconfigured rig can't hammer git and beads.
+const MinLoopInterval = 5 * time.Second
+
 // DefaultMergeQueueConfig returns a MergeQueueConfig with sensible defaults.
 func DefaultMergeQueueConfig() *MergeQueueConfig {
 	return &MergeQueueConfig{
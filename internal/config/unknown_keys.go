@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// warnedKeys remembers the unknown keys already warned about, so a config
+// loaded many times in one process (e.g. by the refinery loop) warns once.
+var warnedKeys sync.Map
+
+// UnknownKeys returns the top-level keys of the JSON object data that no
+// json tag of the struct v (or one of extra) accepts, sorted. Like
+// encoding/json, keys match case-insensitively. Data that isn't a JSON
+// object has no unknown keys.
+func UnknownKeys(data []byte, v any, extra ...string) []string {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil
+	}
+	known := append(jsonFieldNames(reflect.TypeOf(v)), extra...)
+
+	var unknown []string
+	for key := range obj {
+		if !containsFold(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// WarnUnknownKeys prints a warning to stderr for each key UnknownKeys finds
+// in data, read from path (section names the nested object, e.g.
+// "merge_queue", or is empty for the top level). Unknown keys are only
+// warned about, never rejected, so configs written by newer versions still
+// load; the warning suggests the known key a typo most likely meant.
+func WarnUnknownKeys(path, section string, data []byte, v any, extra ...string) {
+	known := append(jsonFieldNames(reflect.TypeOf(v)), extra...)
+	for _, key := range UnknownKeys(data, v, extra...) {
+		name := key
+		if section != "" {
+			name = section + "." + key
+		}
+		if _, seen := warnedKeys.LoadOrStore(path+"\x00"+name, true); seen {
+			continue
+		}
+		msg := fmt.Sprintf("Warning: %s: unknown key %q is ignored", path, name)
+		if guess := closestKey(key, known); guess != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", guess)
+		}
+		fmt.Fprintln(os.Stderr, msg)
+	}
+}
+
+// jsonFieldNames returns the JSON object keys a struct type decodes.
+func jsonFieldNames(t reflect.Type) []string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestKey returns the known key within edit distance 2 of key, or ""
+// if there is none.
+func closestKey(key string, known []string) string {
+	best, bestDist := "", 3
+	for _, k := range known {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	data := []byte(`{"type": "rig", "Name": "gastown", "git_ur": "x", "merge_queue": {}, "zzz": 1}`)
+
+	got := UnknownKeys(data, RigConfig{})
+	if want := []string{"git_ur", "merge_queue", "zzz"}; !slices.Equal(got, want) {
+		t.Errorf("UnknownKeys = %v, want %v", got, want)
+	}
+	got = UnknownKeys(data, &RigConfig{}, "merge_queue")
+	if want := []string{"git_ur", "zzz"}; !slices.Equal(got, want) {
+		t.Errorf("UnknownKeys with extra = %v, want %v", got, want)
+	}
+	if got := UnknownKeys([]byte(`[1, 2]`), RigConfig{}); got != nil {
+		t.Errorf("UnknownKeys(non-object) = %v, want nil", got)
+	}
+
+	// Engine options belong in the rig's config.json, not settings: flag them
+	settingsMQ := []byte(`{"run_tests": true, "merge_window": ["Mon-Fri 09:00-17:00"], "batch_size": 3}`)
+	if got, want := UnknownKeys(settingsMQ, MergeQueueConfig{}), []string{"batch_size", "merge_window"}; !slices.Equal(got, want) {
+		t.Errorf("UnknownKeys(settings merge_queue) = %v, want %v", got, want)
+	}
+}
+
+func TestClosestKey(t *testing.T) {
+	known := []string{"git_url", "local_repo", "name"}
+	tests := map[string]string{
+		"git_ur":    "git_url",
+		"GIT_URL2":  "git_url",
+		"localrepo": "local_repo",
+		"colour":    "",
+	}
+	for key, want := range tests {
+		if got := closestKey(key, known); got != want {
+			t.Errorf("closestKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadRigSettings_WarnsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"type": "rig-settings", "version": 1, "themee": {}, "merge_queue": {"enabled": true, "run_test": false}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("write settings: %v", err)
+	}
+
+	stderr := captureStderr(t, func() {
+		if _, err := LoadRigSettings(path); err != nil {
+			t.Fatalf("LoadRigSettings: %v", err)
+		}
+	})
+	for _, want := range []string{`unknown key "themee" is ignored (did you mean "theme"?)`, `"merge_queue.run_test" is ignored (did you mean "run_tests"?)`} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr %q should contain %q", stderr, want)
+		}
+	}
+
+	// Loading again doesn't repeat the warnings.
+	if again := captureStderr(t, func() { _, _ = LoadRigSettings(path) }); again != "" {
+		t.Errorf("second load warned again: %q", again)
+	}
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = orig }()
+
+	fn()
+	w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
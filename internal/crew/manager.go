@@ -132,15 +132,20 @@ func (m *Manager) Add(name string, createBranch bool) (*CrewWorker, error) {
 	}
 
 	// Clone the rig repo
+	cloneArgs, err := m.rig.CloneArgs()
+	if err != nil {
+		return nil, err
+	}
+	cloneGit := m.git.WithCloneArgs(cloneArgs)
 	if m.rig.LocalRepo != "" {
-		if err := m.git.CloneWithReference(m.rig.GitURL, crewPath, m.rig.LocalRepo); err != nil {
+		if err := cloneGit.CloneWithReference(m.rig.GitURL, crewPath, m.rig.LocalRepo); err != nil {
 			fmt.Printf("Warning: could not clone with local repo reference: %v\n", err)
-			if err := m.git.Clone(m.rig.GitURL, crewPath); err != nil {
+			if err := cloneGit.Clone(m.rig.GitURL, crewPath); err != nil {
 				return nil, fmt.Errorf("cloning rig: %w", err)
 			}
 		}
 	} else {
-		if err := m.git.Clone(m.rig.GitURL, crewPath); err != nil {
+		if err := cloneGit.Clone(m.rig.GitURL, crewPath); err != nil {
 			return nil, fmt.Errorf("cloning rig: %w", err)
 		}
 	}
@@ -380,10 +380,7 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// WaitForClaudeReady, and crucially - startup/propulsion nudges (GUPP).
 	// It returns ErrAlreadyRunning if Claude is already running in tmux.
-	r := &rig.Rig{
-		Name: rigName,
-		Path: filepath.Join(d.config.TownRoot, rigName),
-	}
+	r := d.rigFor(rigName)
 	mgr := witness.NewManager(r)
 
 	if err := mgr.Start(false); err != nil {
@@ -419,10 +416,7 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// WaitForClaudeReady, and crucially - startup/propulsion nudges (GUPP).
 	// It returns ErrAlreadyRunning if Claude is already running in tmux.
-	r := &rig.Rig{
-		Name: rigName,
-		Path: filepath.Join(d.config.TownRoot, rigName),
-	}
+	r := d.rigFor(rigName)
 	mgr := refinery.NewManager(r)
 
 	if err := mgr.Start(false); err != nil {
@@ -459,6 +453,14 @@ func (d *Daemon) getKnownRigs() []string {
 	return rigs
 }
 
+// rigFor returns the named rig of the town.
+func (d *Daemon) rigFor(rigName string) *rig.Rig {
+	return &rig.Rig{
+		Name: rigName,
+		Path: filepath.Join(d.config.TownRoot, rigName),
+	}
+}
+
 // isRigOperational checks if a rig is in an operational state.
 // Returns true if the rig can have agents auto-started.
 // Returns false (with reason) if the rig is parked, docked, or has auto_restart blocked/disabled.
@@ -667,7 +669,7 @@ func (d *Daemon) checkPolecatSessionHealth() {
 // checkRigPolecatHealth checks polecat session health for a specific rig.
 func (d *Daemon) checkRigPolecatHealth(rigName string) {
 	// Get polecat directories for this rig
-	polecatsDir := filepath.Join(d.config.TownRoot, rigName, "polecats")
+	polecatsDir := d.rigFor(rigName).PolecatsDir()
 	polecats, err := listPolecatWorktrees(polecatsDir)
 	if err != nil {
 		return // No polecats directory - rig might not have polecats
@@ -753,7 +755,7 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	}
 
 	// Determine working directory
-	workDir := filepath.Join(d.config.TownRoot, rigName, "polecats", polecatName)
+	workDir := filepath.Join(d.rigFor(rigName).PolecatsDir(), polecatName)
 
 	// Verify the worktree exists
 	if _, err := os.Stat(workDir); os.IsNotExist(err) {
@@ -427,7 +427,7 @@ func (d *Daemon) getWorkDir(config *beads.RoleConfig, parsed *ParsedIdentity) st
 	case "crew":
 		return filepath.Join(d.config.TownRoot, parsed.RigName, "crew", parsed.AgentName)
 	case "polecat":
-		return filepath.Join(d.config.TownRoot, parsed.RigName, "polecats", parsed.AgentName)
+		return filepath.Join(d.rigFor(parsed.RigName).PolecatsDir(), parsed.AgentName)
 	default:
 		return ""
 	}
@@ -252,3 +252,27 @@ func TestBeadsMessage_Serialization(t *testing.T) {
 		t.Errorf("From mismatch")
 	}
 }
+
+func TestGetWorkDir_PolecatWorkerRoot(t *testing.T) {
+	d, cleanup := testDaemonWithTown(t, "test-town")
+	defer cleanup()
+	parsed := &ParsedIdentity{RoleType: "polecat", RigName: "gastown", AgentName: "Nux"}
+
+	if got, want := d.getWorkDir(nil, parsed), filepath.Join(d.config.TownRoot, "gastown", "polecats", "Nux"); got != want {
+		t.Errorf("getWorkDir() = %q, want %q", got, want)
+	}
+
+	// Polecats on another disk (worker_root)
+	external := t.TempDir()
+	rigPath := filepath.Join(d.config.TownRoot, "gastown")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `{"type":"rig","name":"gastown","worker_root":"` + external + `"}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.getWorkDir(nil, parsed), filepath.Join(external, "Nux"); got != want {
+		t.Errorf("getWorkDir() with worker_root = %q, want %q", got, want)
+	}
+}
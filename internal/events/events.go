@@ -62,6 +62,10 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// TypeRejectForced records an MR rejected past its state checks
+	// (gt mq reject --force), with who forced it as the actor.
+	TypeRejectForced = "reject_forced"
 )
 
 // EventsFile is the name of the raw events log.
@@ -74,7 +78,11 @@ var mutex sync.Mutex
 // The event is appended to ~/gt/.events.jsonl.
 // Returns nil if logging fails (events are best-effort).
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
-	event := Event{
+	return write(newEvent(eventType, actor, payload, visibility))
+}
+
+func newEvent(eventType, actor string, payload map[string]interface{}, visibility string) Event {
+	return Event{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		Source:     "gt",
 		Type:       eventType,
@@ -82,7 +90,6 @@ func Log(eventType, actor string, payload map[string]interface{}, visibility str
 		Payload:    payload,
 		Visibility: visibility,
 	}
-	return write(event)
 }
 
 // LogFeed is a convenience wrapper for feed-visible events.
@@ -95,6 +102,15 @@ func LogAudit(eventType, actor string, payload map[string]interface{}) error {
 	return Log(eventType, actor, payload, VisibilityAudit)
 }
 
+// LogAuditAt writes an audit-only event to the given town's events log,
+// for callers acting on a rig that may not be in the cwd's town.
+func LogAuditAt(townRoot, eventType, actor string, payload map[string]interface{}) error {
+	if townRoot == "" {
+		return nil
+	}
+	return writeTo(townRoot, newEvent(eventType, actor, payload, VisibilityAudit))
+}
+
 // write appends an event to the events file.
 func write(event Event) error {
 	// Find town root
@@ -103,7 +119,11 @@ func write(event Event) error {
 		// Silently ignore - we're not in a Gas Town workspace
 		return nil
 	}
+	return writeTo(townRoot, event)
+}
 
+// writeTo appends an event to the events file in townRoot.
+func writeTo(townRoot string, event Event) error {
 	eventsPath := filepath.Join(townRoot, EventsFile)
 
 	// Marshal event to JSON
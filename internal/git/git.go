@@ -3,12 +3,17 @@ package git
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Common errors
@@ -17,12 +22,159 @@ var (
 	ErrMergeConflict  = errors.New("merge conflict")
 	ErrAuthFailure    = errors.New("authentication failed")
 	ErrRebaseConflict = errors.New("rebase conflict")
+	ErrWorkerDirty    = errors.New("worktree has uncommitted changes")
+	ErrRepoCorrupt    = errors.New("repository integrity check failed")
+	ErrInvalidBranch  = errors.New("invalid branch name")
+	ErrBranchNotFound = errors.New("branch not found")
+	ErrWorktreeLocked = errors.New("worktree is locked")
+	ErrNetwork        = errors.New("transient network error")
 )
 
+// NetworkError is a git failure caused by the network or a briefly
+// unavailable remote (timeouts, dropped connections, DNS, HTTP 5xx), which
+// may succeed if retried. It matches ErrNetwork with errors.Is. Rejections
+// by the remote (non-fast-forward, hooks, permissions) are not
+// NetworkErrors: retrying them can't help.
+type NetworkError struct {
+	Op     string // git subcommand, e.g. "push"
+	Stderr string
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("git %s: %s", e.Op, e.Stderr)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return ErrNetwork
+}
+
+// Stderr fragments of git failures a retry may fix. Anything else,
+// including an unrecognized failure, is treated as permanent.
+var networkErrorPatterns = []string{
+	"could not resolve host",
+	"temporary failure in name resolution",
+	"connection timed out",
+	"operation timed out",
+	"connection reset",
+	"connection refused",
+	"failed to connect to",
+	"couldn't connect to server",
+	"connection closed by remote host",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rpc failed",
+	"gnutls_handshake() failed",
+	"ssl_connect",
+	"ssl_read",
+	"the requested url returned error: 429",
+	"the requested url returned error: 500",
+	"the requested url returned error: 502",
+	"the requested url returned error: 503",
+	"the requested url returned error: 504",
+}
+
+// Stderr fragments of a remote refusing a push; these win over network
+// patterns, since a rejected push may also report a hung-up remote.
+var rejectionPatterns = []string{
+	"[rejected]",
+	"[remote rejected]",
+	"non-fast-forward",
+	"fetch first",
+	"hook declined",
+	"permission denied",
+	"protected branch",
+}
+
+// isNetworkError reports whether git's stderr describes a transient
+// network failure rather than a rejection.
+func isNetworkError(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, p := range rejectionPatterns {
+		if strings.Contains(lower, p) {
+			return false
+		}
+	}
+	for _, p := range networkErrorPatterns {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeConflictError is a merge conflict naming the conflicting files. It
+// matches ErrMergeConflict with errors.Is.
+type MergeConflictError struct {
+	Files []string
+}
+
+func (e *MergeConflictError) Error() string {
+	if len(e.Files) == 0 {
+		return ErrMergeConflict.Error()
+	}
+	return fmt.Sprintf("%s in %s", ErrMergeConflict, strings.Join(e.Files, ", "))
+}
+
+func (e *MergeConflictError) Unwrap() error {
+	return ErrMergeConflict
+}
+
+// WorkerDirtyError reports the files that kept a sync from running.
+// It matches ErrWorkerDirty with errors.Is.
+type WorkerDirtyError struct {
+	Files []string
+}
+
+func (e *WorkerDirtyError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrWorkerDirty, strings.Join(e.Files, ", "))
+}
+
+func (e *WorkerDirtyError) Unwrap() error {
+	return ErrWorkerDirty
+}
+
+// RepoProblem is one integrity problem found by Verify.
+type RepoProblem struct {
+	Kind   string // "objects" or "worktree"
+	Detail string
+	Hint   string // Suggested remediation
+}
+
+// RepoIntegrityError lists the problems found by Verify.
+// It matches ErrRepoCorrupt with errors.Is.
+type RepoIntegrityError struct {
+	Problems []RepoProblem
+}
+
+func (e *RepoIntegrityError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("%s: %s", ErrRepoCorrupt, e.Problems[0].Detail)
+	}
+	return fmt.Sprintf("%s: %d problems, first: %s", ErrRepoCorrupt, len(e.Problems), e.Problems[0].Detail)
+}
+
+func (e *RepoIntegrityError) Unwrap() error {
+	return ErrRepoCorrupt
+}
+
 // Git wraps git operations for a working directory.
+//
+// A Git holds no per-operation state, so one instance may be shared across
+// goroutines once configured. SetEnv is the only mutator; call it before
+// sharing, or derive per-use copies with WithContext/WithTranscript. Git
+// itself does not serialize commands: callers that change shared repo state
+// (worktrees, branches) concurrently must coordinate, as polecat.Manager does.
 type Git struct {
 	workDir string
-	gitDir  string // Optional: explicit git directory (for bare repos)
+	gitDir  string   // Optional: explicit git directory (for bare repos)
+	env     []string // Optional: extra KEY=VALUE pairs for git invocations
+	ctx     context.Context
+	// Optional: receives each command with its full output, for diagnostics
+	transcript io.Writer
+	// Optional: extra raw flags for git clone (rig config clone_args)
+	cloneArgs []string
+	// Optional: receives git clone's progress output as it happens
+	progress io.Writer
 }
 
 // NewGit creates a new Git wrapper for the given directory.
@@ -37,6 +189,218 @@ func NewGitWithDir(gitDir, workDir string) *Git {
 	return &Git{gitDir: gitDir, workDir: workDir}
 }
 
+// SetEnv sets extra environment variables for git invocations (e.g., per-rig
+// GIT_CONFIG_* overrides or credential helper settings used by push hooks).
+// They are added on top of the inherited environment, never replacing it.
+func (g *Git) SetEnv(env map[string]string) {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	g.env = nil
+	for _, k := range keys {
+		g.env = append(g.env, k+"="+env[k])
+	}
+}
+
+// WithContext returns a copy of g whose git subprocesses are killed when ctx
+// is cancelled (e.g., on Ctrl-C during a long fetch or push).
+func (g *Git) WithContext(ctx context.Context) *Git {
+	c := *g
+	c.ctx = ctx
+	return &c
+}
+
+// WithTranscript returns a copy of g that writes every command it runs, with
+// its complete stdout/stderr and exit status, to w. Errors returned to callers
+// stay short; the transcript keeps the detail needed to diagnose them.
+func (g *Git) WithTranscript(w io.Writer) *Git {
+	c := *g
+	c.transcript = w
+	return &c
+}
+
+// WithCloneArgs returns a copy of g whose clones pass args to git clone,
+// ahead of the built-in flags. Validate them with ValidateCloneArgs first.
+func (g *Git) WithCloneArgs(args []string) *Git {
+	c := *g
+	c.cloneArgs = append([]string(nil), args...)
+	return &c
+}
+
+// WithProgress returns a copy of g whose clones stream git's progress output
+// (counting, receiving and resolving objects) to w while they run. By default
+// progress is discarded; git only reports it when asked, so a nil w keeps
+// clones quiet.
+func (g *Git) WithProgress(w io.Writer) *Git {
+	c := *g
+	c.progress = w
+	return &c
+}
+
+// cloneCommand builds a git clone command: configured clone args, then args.
+// Its stderr is collected in stderr for error reporting and, with
+// WithProgress, also streamed to the progress writer.
+func (g *Git) cloneCommand(stderr *bytes.Buffer, args ...string) *exec.Cmd {
+	full := append([]string{"clone"}, g.cloneArgs...)
+	if g.progress != nil {
+		full = append(full, "--progress")
+	}
+	cmd := g.command(append(full, args...)...)
+	cmd.Stderr = stderr
+	if g.progress != nil {
+		cmd.Stderr = io.MultiWriter(stderr, g.progress)
+	}
+	return cmd
+}
+
+// Clone flags allowed in clone_args, by full name, and whether each takes a
+// value. Anything else is refused: git accepts unique abbreviations of long
+// flags (--upl for --upload-pack) and bundled short flags (-qu<cmd>), so a
+// deny-list can't keep out flags that change the rig layout or run commands.
+var allowedCloneArgs = map[string]bool{
+	"--filter":           true,
+	"--depth":            true,
+	"--jobs":             true,
+	"--shallow-since":    true,
+	"--shallow-exclude":  true,
+	"--config":           true,
+	"--single-branch":    false,
+	"--no-single-branch": false,
+	"--no-tags":          false,
+	"--sparse":           false,
+	"--no-hardlinks":     false,
+	"--quiet":            false,
+}
+
+// Config keys allowed via clone_args --config: transfer and checkout tuning
+// only. Keys that run commands (core.sshCommand, credential.*.helper),
+// pull in other config (include.path) or enable transports can't be set.
+var allowedCloneConfig = []string{
+	"core.autocrlf", "core.eol", "core.longpaths", "core.symlinks",
+	"core.compression", "core.preloadindex", "core.untrackedcache",
+	"checkout.workers", "fetch.parallel", "submodule.fetchjobs",
+	"pack.threads", "index.version", "feature.manyfiles",
+	"http.version", "http.postbuffer", "http.lowspeedlimit", "http.lowspeedtime",
+}
+
+// ValidateCloneArgs checks extra git clone flags (rig config clone_args).
+// They are passed to git as-is, so each must be a single long flag, in
+// --flag or --flag=value form, from a fixed allow-list (--filter, --depth,
+// --jobs, --single-branch, --no-tags, ...). --config may only set
+// allow-listed keys, e.g. --config=core.autocrlf=false.
+func ValidateCloneArgs(args []string) error {
+	for i, arg := range args {
+		if strings.ContainsAny(arg, "\x00\n\r") {
+			return fmt.Errorf("clone_args[%d] %q: contains control characters", i, arg)
+		}
+		if !strings.HasPrefix(arg, "--") {
+			return fmt.Errorf("clone_args[%d] %q: only long flags are allowed; join values with '=' (e.g. --jobs=4)", i, arg)
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		takesValue, ok := allowedCloneArgs[name]
+		switch {
+		case !ok:
+			return fmt.Errorf("clone_args[%d] %q: %s is not an allowed clone flag", i, arg, name)
+		case takesValue && !hasValue:
+			return fmt.Errorf("clone_args[%d] %q: give the value with '=' (e.g. %s=...)", i, arg, name)
+		case !takesValue && hasValue:
+			return fmt.Errorf("clone_args[%d] %q: %s takes no value", i, arg, name)
+		case name != "--config":
+			continue
+		}
+
+		key, _, _ := strings.Cut(value, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			return fmt.Errorf("clone_args[%d] %q: give the config as --config=key=value", i, arg)
+		}
+		if !slices.Contains(allowedCloneConfig, key) {
+			return fmt.Errorf("clone_args[%d] %q: setting %s is not allowed", i, arg, key)
+		}
+	}
+	return nil
+}
+
+// ValidateBranchName checks name against git's ref naming rules (as in
+// git check-ref-format --branch), so names built from user input fail with
+// a clear error instead of an obscure one from git. Returns an error
+// wrapping ErrInvalidBranch naming the rule broken.
+func ValidateBranchName(name string) error {
+	invalid := func(why string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidBranch, name, why)
+	}
+	switch {
+	case name == "" || name == "@":
+		return invalid("name is empty")
+	case strings.HasPrefix(name, "-"):
+		return invalid("cannot start with '-'")
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") || strings.Contains(name, "//"):
+		return invalid("cannot start or end with '/' or contain '//'")
+	case strings.HasSuffix(name, "."):
+		return invalid("cannot end with '.'")
+	case strings.Contains(name, ".."):
+		return invalid("cannot contain '..'")
+	case strings.Contains(name, "@{"):
+		return invalid("cannot contain '@{'")
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return invalid("cannot contain control characters")
+		}
+		if strings.ContainsRune(" ~^:?*[\\", r) {
+			return invalid(fmt.Sprintf("cannot contain %q", r))
+		}
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return invalid("path components cannot start with '.'")
+		}
+		if strings.HasSuffix(part, ".lock") {
+			return invalid("path components cannot end with '.lock'")
+		}
+	}
+	return nil
+}
+
+// record writes a finished command to the transcript, if one is set.
+func (g *Git) record(args []string, stdout, stderr string, err error) {
+	if g.transcript == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(g.transcript, "$ git %s\n", strings.Join(args, " "))
+	if stdout != "" {
+		_, _ = fmt.Fprintln(g.transcript, strings.TrimRight(stdout, "\n"))
+	}
+	if stderr != "" {
+		_, _ = fmt.Fprintln(g.transcript, strings.TrimRight(stderr, "\n"))
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(g.transcript, "(%v)\n", err)
+	}
+}
+
+// command builds a git command with any extra environment applied.
+func (g *Git) command(args ...string) *exec.Cmd {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if g.ctx != nil {
+		// Once ctx ends and git is killed, don't wait on children (ssh)
+		// that still hold its output open.
+		cmd.WaitDelay = time.Second
+	}
+	if len(g.env) > 0 {
+		cmd.Env = append(os.Environ(), g.env...)
+	}
+	return cmd
+}
+
 // WorkDir returns the working directory for this Git instance.
 func (g *Git) WorkDir() string {
 	return g.workDir
@@ -55,7 +419,7 @@ func (g *Git) run(args ...string) (string, error) {
 		args = append([]string{"--git-dir=" + g.gitDir}, args...)
 	}
 
-	cmd := exec.Command("git", args...)
+	cmd := g.command(args...)
 	if g.workDir != "" {
 		cmd.Dir = g.workDir
 	}
@@ -65,6 +429,7 @@ func (g *Git) run(args ...string) (string, error) {
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
+	g.record(args, stdout.String(), stderr.String(), err)
 	if err != nil {
 		return "", g.wrapError(err, stderr.String(), args)
 	}
@@ -89,6 +454,14 @@ func (g *Git) wrapError(err error, stderr string, args []string) error {
 	if strings.Contains(stderr, "needs merge") || strings.Contains(stderr, "rebase in progress") {
 		return ErrRebaseConflict
 	}
+	if strings.Contains(stderr, "missing but locked worktree") {
+		first, _, _ := strings.Cut(stderr, "\n")
+		return fmt.Errorf("%w: %s", ErrWorktreeLocked, strings.TrimPrefix(first, "fatal: "))
+	}
+
+	if isNetworkError(stderr) {
+		return &NetworkError{Op: args[0], Stderr: stderr}
+	}
 
 	if stderr != "" {
 		return fmt.Errorf("git %s: %s", args[0], stderr)
@@ -98,9 +471,8 @@ func (g *Git) wrapError(err error, stderr string, args []string) error {
 
 // Clone clones a repository to the destination.
 func (g *Git) Clone(url, dest string) error {
-	cmd := exec.Command("git", "clone", url, dest)
 	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := g.cloneCommand(&stderr, url, dest)
 	if err := cmd.Run(); err != nil {
 		return g.wrapError(err, stderr.String(), []string{"clone", url})
 	}
@@ -115,9 +487,8 @@ func (g *Git) Clone(url, dest string) error {
 // CloneWithReference clones a repository using a local repo as an object reference.
 // This saves disk by sharing objects without changing remotes.
 func (g *Git) CloneWithReference(url, dest, reference string) error {
-	cmd := exec.Command("git", "clone", "--reference-if-able", reference, url, dest)
 	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := g.cloneCommand(&stderr, "--reference-if-able", reference, url, dest)
 	if err := cmd.Run(); err != nil {
 		return g.wrapError(err, stderr.String(), []string{"clone", "--reference-if-able", url})
 	}
@@ -132,15 +503,48 @@ func (g *Git) CloneWithReference(url, dest, reference string) error {
 // CloneBare clones a repository as a bare repo (no working directory).
 // This is used for the shared repo architecture where all worktrees share a single git database.
 func (g *Git) CloneBare(url, dest string) error {
-	cmd := exec.Command("git", "clone", "--bare", url, dest)
 	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := g.cloneCommand(&stderr, "--bare", url, dest)
 	if err := cmd.Run(); err != nil {
 		return g.wrapError(err, stderr.String(), []string{"clone", "--bare", url})
 	}
 	return nil
 }
 
+// CloneLocal creates an independent full checkout of this repository at dest.
+// The clone is made from the local repository (typically the rig's shared bare
+// repo), so it is fast and reuses objects already on disk, but unlike a
+// worktree it has its own .git and shares no worktree state. The clone's
+// origin is pointed at this repository's origin URL so fetches and pushes go
+// upstream. Useful for disposable build environments such as isolated CI.
+func (g *Git) CloneLocal(dest string) error {
+	src := g.gitDir
+	if src == "" {
+		src = g.workDir
+	}
+
+	cmd := g.command("clone", "--local", src, dest)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return g.wrapError(err, stderr.String(), []string{"clone", "--local", src})
+	}
+
+	// Point origin upstream rather than at the local source repo
+	if url, err := g.RemoteURL("origin"); err == nil && url != "" {
+		if _, err := NewGit(dest).run("remote", "set-url", "origin", url); err != nil {
+			return fmt.Errorf("setting origin URL: %w", err)
+		}
+	}
+
+	// Configure hooks path for Gas Town clones
+	if err := configureHooksPath(dest); err != nil {
+		return err
+	}
+	// Configure sparse checkout to exclude .claude/ from source repo
+	return ConfigureSparseCheckout(dest)
+}
+
 // configureHooksPath sets core.hooksPath to use the repo's .githooks directory
 // if it exists. This ensures Gas Town agents use the pre-push hook that blocks
 // pushes to non-main branches (internal PRs are not allowed).
@@ -162,9 +566,8 @@ func configureHooksPath(repoPath string) error {
 
 // CloneBareWithReference clones a bare repository using a local repo as an object reference.
 func (g *Git) CloneBareWithReference(url, dest, reference string) error {
-	cmd := exec.Command("git", "clone", "--bare", "--reference-if-able", reference, url, dest)
 	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+	cmd := g.cloneCommand(&stderr, "--bare", "--reference-if-able", reference, url, dest)
 	if err := cmd.Run(); err != nil {
 		return g.wrapError(err, stderr.String(), []string{"clone", "--bare", "--reference-if-able", url})
 	}
@@ -195,7 +598,69 @@ func (g *Git) Pull(remote, branch string) error {
 	return err
 }
 
-// Push pushes to the remote branch.
+// Sync rebases the current branch onto the remote branch (git pull --rebase).
+// A dirty worktree makes the rebase abort partway, so it is checked first and
+// reported as a *WorkerDirtyError listing the files. With autostash, local
+// changes are stashed around the rebase instead (--autostash).
+//
+// Sync runs in g's working directory, so it works the same for a full clone
+// (crew, mayor) and a linked worktree (polecats). A Git with no working
+// directory (the rig's bare repo) cannot be synced.
+func (g *Git) Sync(remote, branch string, autostash bool) error {
+	if g.workDir == "" {
+		return fmt.Errorf("sync needs a working tree, not the bare repo %s", g.gitDir)
+	}
+
+	args := []string{"pull", "--rebase"}
+	if autostash {
+		args = append(args, "--autostash")
+	} else {
+		files, err := g.DirtyFiles()
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			return &WorkerDirtyError{Files: files}
+		}
+	}
+
+	args = append(args, remote)
+	if branch != "" {
+		args = append(args, branch)
+	}
+	_, err := g.run(args...)
+	return err
+}
+
+// DirtyFiles returns the paths reported by git status --porcelain: modified,
+// staged and untracked files. Renames are reported by their new path.
+func (g *Git) DirtyFiles() ([]string, error) {
+	out, err := g.run("status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 3 {
+			continue
+		}
+		// The first line may have lost its leading space to output trimming,
+		// so take everything after the two-character status code and trim.
+		file := strings.TrimSpace(line[2:])
+		if i := strings.Index(file, " -> "); i >= 0 {
+			file = file[i+4:]
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Push pushes to the remote branch. A failure the network may have caused
+// is a *NetworkError (errors.Is ErrNetwork); callers may retry it.
 func (g *Git) Push(remote, branch string, force bool) error {
 	args := []string{"push", remote, branch}
 	if force {
@@ -330,6 +795,12 @@ func (g *Git) RemoteURL(remote string) (string, error) {
 	return g.run("remote", "get-url", remote)
 }
 
+// RemoteReachable checks that the remote answers by listing its HEAD.
+func (g *Git) RemoteReachable(remote string) error {
+	_, err := g.run("ls-remote", remote, "HEAD")
+	return err
+}
+
 // Remotes returns the list of configured remote names.
 func (g *Git) Remotes() ([]string, error) {
 	out, err := g.run("remote")
@@ -417,7 +888,7 @@ func (g *Git) CheckConflicts(source, target string) ([]string, error) {
 // runMergeCheck runs a git merge command and returns error info from both stdout and stderr.
 // This is needed because git merge outputs CONFLICT info to stdout.
 func (g *Git) runMergeCheck(args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
+	cmd := g.command(args...)
 	cmd.Dir = g.workDir
 
 	var stdout, stderr bytes.Buffer
@@ -425,6 +896,7 @@ func (g *Git) runMergeCheck(args ...string) (string, error) {
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
+	g.record(args, stdout.String(), stderr.String(), err)
 	if err != nil {
 		// Check stdout for CONFLICT message (git sends it there)
 		stdoutStr := stdout.String()
@@ -438,6 +910,49 @@ func (g *Git) runMergeCheck(args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// MergeTreeConflicts returns the files that would conflict merging source
+// into target, without touching the index or working tree (git merge-tree
+// --write-tree, git 2.38+). None means the merge is clean.
+func (g *Git) MergeTreeConflicts(source, target string) ([]string, error) {
+	args := []string{"merge-tree", "--write-tree", "--name-only", "--no-messages", target, source}
+	if g.gitDir != "" {
+		args = append([]string{"--git-dir=" + g.gitDir}, args...)
+	}
+	cmd := g.command(args...)
+	if g.workDir != "" {
+		cmd.Dir = g.workDir
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	g.record(args, stdout.String(), stderr.String(), err)
+	if err == nil {
+		return nil, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return nil, g.wrapError(err, stderr.String(), args)
+	}
+
+	// Exit status 1: conflicts. The first line is the tree, then the files.
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	var conflicts []string
+	for _, line := range lines[1:] {
+		if line != "" {
+			conflicts = append(conflicts, line)
+		}
+	}
+	return conflicts, nil
+}
+
+// MergeBase returns the best common ancestor of two commits.
+func (g *Git) MergeBase(a, b string) (string, error) {
+	return g.run("merge-base", a, b)
+}
+
 // getConflictingFiles returns the list of files with merge conflicts.
 func (g *Git) getConflictingFiles() ([]string, error) {
 	// git diff --name-only --diff-filter=U shows unmerged files
@@ -541,6 +1056,12 @@ func (g *Git) ResetBranch(name, ref string) error {
 	return err
 }
 
+// ResetHard resets the current branch, index and working tree to ref.
+func (g *Git) ResetHard(ref string) error {
+	_, err := g.run("reset", "--hard", ref)
+	return err
+}
+
 // Rev returns the commit hash for the given ref.
 func (g *Git) Rev(ref string) (string, error) {
 	return g.run("rev-parse", ref)
@@ -748,6 +1269,184 @@ func (g *Git) WorktreeRemove(path string, force bool) error {
 	return err
 }
 
+// WorktreeMove moves a worktree to a new path.
+// Worktree metadata holds absolute paths, so moves must go through git
+// rather than os.Rename.
+func (g *Git) WorktreeMove(oldPath, newPath string) error {
+	_, err := g.run("worktree", "move", oldPath, newPath)
+	return err
+}
+
+// StaleWorktreeLock reports whether path is registered as a worktree that
+// is locked but no longer exists on disk: what an interrupted 'worktree add'
+// leaves behind. Git refuses to create a worktree there until it is cleared.
+func (g *Git) StaleWorktreeLock(path string) (bool, error) {
+	out, err := g.run("worktree", "list", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	want := resolveWorktreePath(path)
+	var current string
+	for _, line := range strings.Split(out, "\n") {
+		if wt, ok := strings.CutPrefix(line, "worktree "); ok {
+			current = resolveWorktreePath(wt)
+			continue
+		}
+		if current == want && (line == "locked" || strings.HasPrefix(line, "locked ")) {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// ClearStaleWorktreeLock unlocks and prunes the registration of a missing,
+// locked worktree at path, so a worktree can be created there again. A
+// worktree that still exists on disk is left alone.
+func (g *Git) ClearStaleWorktreeLock(path string) error {
+	stale, err := g.StaleWorktreeLock(path)
+	if err != nil || !stale {
+		return err
+	}
+	if _, err := g.run("worktree", "unlock", path); err != nil {
+		return err
+	}
+	_, err = g.run("worktree", "prune")
+	return err
+}
+
+// resolveWorktreePath cleans a worktree path and resolves symlinks in its
+// parent (the worktree itself may be missing) so paths compare equal to
+// the ones git reports.
+func resolveWorktreePath(path string) string {
+	path = filepath.Clean(path)
+	if parent, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(parent, filepath.Base(path))
+	}
+	return path
+}
+
+// SetWorktreeHooksPath points this worktree's git hooks at dir
+// (core.hooksPath), so shared policy hooks (commit-msg, pre-push) run for
+// every commit and push made in it. The setting is scoped to this worktree:
+// other worktrees of the repo, such as the refinery's, keep their hooks.
+func (g *Git) SetWorktreeHooksPath(dir string) error {
+	if err := g.enableWorktreeConfig(); err != nil {
+		return fmt.Errorf("enabling per-worktree config: %w", err)
+	}
+	_, err := g.run("config", "--worktree", "core.hooksPath", dir)
+	return err
+}
+
+// enableWorktreeConfig turns on extensions.worktreeConfig for the repo, so
+// settings can be scoped to one worktree with 'git config --worktree'. As
+// git requires, a bare repo's core.bare is first moved to its own
+// config.worktree: left in the shared config, it would make every
+// worktree bare once the extension is on.
+func (g *Git) enableWorktreeConfig() error {
+	if enabled, _ := g.run("config", "--bool", "extensions.worktreeConfig"); enabled == "true" {
+		return nil
+	}
+	commonDir, err := g.run("rev-parse", "--git-common-dir")
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(g.workDir, commonDir)
+	}
+	shared := filepath.Join(commonDir, "config")
+
+	bare, _ := g.run("config", "--file", shared, "--bool", "core.bare")
+	if bare == "true" {
+		if _, err := g.run("config", "--file", filepath.Join(commonDir, "config.worktree"), "core.bare", "true"); err != nil {
+			return err
+		}
+	}
+	if _, err := g.run("config", "--file", shared, "extensions.worktreeConfig", "true"); err != nil {
+		return err
+	}
+	if bare == "true" {
+		if _, err := g.run("config", "--file", shared, "--unset", "core.bare"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameBranch renames a local branch.
+func (g *Git) RenameBranch(oldName, newName string) error {
+	_, err := g.run("branch", "-m", oldName, newName)
+	return err
+}
+
+// WorkerCreateFromBranch creates a worker worktree at workerPath on an
+// existing branch, rather than a new one as WorktreeAdd does. A branch that
+// only exists on origin (e.g. pushed from elsewhere) is fetched and checked
+// out as a local branch tracking it. Returns ErrBranchNotFound if the
+// branch exists neither locally nor on origin.
+func (g *Git) WorkerCreateFromBranch(workerPath, branch string) error {
+	if err := ValidateBranchName(branch); err != nil {
+		return err
+	}
+
+	exists, err := g.BranchExists(branch)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branch, branch)
+		if _, err := g.run("fetch", "origin", refspec); err != nil {
+			return fmt.Errorf("%w: %s is not local and could not be fetched from origin: %v", ErrBranchNotFound, branch, err)
+		}
+	}
+
+	// Without -b, git checks out the local branch, or creates one tracking
+	// origin/<branch> when only the remote branch exists.
+	return g.WorktreeAddExisting(workerPath, branch)
+}
+
+// WorkerRename moves a worker's worktree from oldPath to newPath and renames
+// its branch to match. The worker names are the base names of the paths; a
+// branch of the form polecat/<old> or polecat/<old>-<suffix> becomes
+// polecat/<new> or polecat/<new>-<suffix>. Other branches are left alone.
+func (g *Git) WorkerRename(oldPath, newPath string) error {
+	branch, err := NewGit(oldPath).CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("reading worker branch: %w", err)
+	}
+
+	newBranch := RenamedWorkerBranch(branch, filepath.Base(oldPath), filepath.Base(newPath))
+	if newBranch != branch {
+		if err := g.RenameBranch(branch, newBranch); err != nil {
+			return fmt.Errorf("renaming branch %s: %w", branch, err)
+		}
+	}
+
+	if err := g.WorktreeMove(oldPath, newPath); err != nil {
+		// Put the branch back so the worker is left consistent
+		if newBranch != branch {
+			_ = g.RenameBranch(newBranch, branch)
+		}
+		return fmt.Errorf("moving worktree: %w", err)
+	}
+	return nil
+}
+
+// RenamedWorkerBranch returns the branch name a worker branch takes after the
+// worker is renamed from oldName to newName. Branches that don't belong to
+// oldName are returned unchanged.
+func RenamedWorkerBranch(branch, oldName, newName string) string {
+	prefix := "polecat/" + oldName
+	if branch == prefix {
+		return "polecat/" + newName
+	}
+	if strings.HasPrefix(branch, prefix+"-") || strings.HasPrefix(branch, prefix+"/") {
+		return "polecat/" + newName + strings.TrimPrefix(branch, prefix)
+	}
+	return branch
+}
+
 // WorktreePrune removes worktree entries for deleted paths.
 func (g *Git) WorktreePrune() error {
 	_, err := g.run("worktree", "prune")
@@ -798,6 +1497,101 @@ func (g *Git) WorktreeList() ([]Worktree, error) {
 	return worktrees, nil
 }
 
+// Verify checks the repository's integrity: its object store (git fsck)
+// and its worktrees. Returns a *RepoIntegrityError listing every problem
+// found, or nil if the repository is sound.
+func (g *Git) Verify() error {
+	problems := g.verifyObjects()
+
+	out, err := g.run("worktree", "list", "--porcelain")
+	if err != nil {
+		problems = append(problems, RepoProblem{
+			Kind:   "worktree",
+			Detail: fmt.Sprintf("cannot list worktrees: %v", err),
+			Hint:   "check the repository's worktrees/ admin directory",
+		})
+	} else {
+		problems = append(problems, verifyWorktrees(out)...)
+	}
+
+	if len(problems) > 0 {
+		return &RepoIntegrityError{Problems: problems}
+	}
+	return nil
+}
+
+// verifyObjects runs git fsck and reports each corrupt or missing object.
+func (g *Git) verifyObjects() []RepoProblem {
+	const hint = "fetch the objects again ('git fetch --refetch origin') or re-clone the rig repo"
+
+	args := []string{"fsck", "--no-dangling", "--no-progress"}
+	if g.gitDir != "" {
+		args = append([]string{"--git-dir=" + g.gitDir}, args...)
+	}
+	cmd := g.command(args...)
+	if g.workDir != "" {
+		cmd.Dir = g.workDir
+	}
+	out, err := cmd.CombinedOutput()
+	g.record(args, string(out), "", err)
+
+	var problems []RepoProblem
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		for _, prefix := range []string{"error", "fatal", "missing", "broken", "bad"} {
+			if strings.HasPrefix(line, prefix) {
+				problems = append(problems, RepoProblem{Kind: "objects", Detail: line, Hint: hint})
+				break
+			}
+		}
+	}
+	if err != nil && len(problems) == 0 {
+		problems = append(problems, RepoProblem{Kind: "objects", Detail: fmt.Sprintf("git fsck: %v", err), Hint: hint})
+	}
+	return problems
+}
+
+// verifyWorktrees checks each worktree in `git worktree list --porcelain`
+// output: dangling entries (directory gone) and worktrees whose HEAD no
+// longer resolves.
+func verifyWorktrees(porcelain string) []RepoProblem {
+	var problems []RepoProblem
+	for _, block := range strings.Split(porcelain, "\n\n") {
+		var path, prunable string
+		bare := false
+		for _, line := range strings.Split(strings.TrimSpace(block), "\n") {
+			switch {
+			case strings.HasPrefix(line, "worktree "):
+				path = strings.TrimPrefix(line, "worktree ")
+			case line == "bare":
+				bare = true
+			case line == "prunable" || strings.HasPrefix(line, "prunable "):
+				prunable = strings.TrimSpace(strings.TrimPrefix(line, "prunable"))
+			}
+		}
+		if path == "" || bare {
+			continue
+		}
+
+		if prunable != "" {
+			problems = append(problems, RepoProblem{
+				Kind:   "worktree",
+				Detail: fmt.Sprintf("dangling worktree %s (%s)", path, prunable),
+				Hint:   "run 'git worktree prune' in the rig repo",
+			})
+			continue
+		}
+		if _, err := NewGit(path).run("rev-parse", "--verify", "HEAD"); err != nil {
+			problems = append(problems, RepoProblem{
+				Kind:   "worktree",
+				Detail: fmt.Sprintf("worktree %s is broken: %v", path, err),
+				Hint:   "remove and recreate it (for a polecat: 'gt polecat nuke' then re-add)",
+			})
+		}
+	}
+	return problems
+}
+
 // BranchCreatedDate returns the date when a branch was created.
 // This uses the committer date of the first commit on the branch.
 // Returns date in YYYY-MM-DD format.
@@ -835,6 +1629,71 @@ func (g *Git) BranchCreatedDate(branch string) (string, error) {
 	return out, nil
 }
 
+// CommitSignature is a commit's signature as git verifies it.
+type CommitSignature struct {
+	// Code is git's verification result (%G?): G good, U good but of
+	// unknown validity, B bad, X expired signature, Y expired key,
+	// R revoked key, E unable to check (e.g. missing key), N unsigned.
+	Code        string
+	Signer      string // %GS
+	Key         string // %GK: key ID (GPG) or fingerprint (SSH)
+	Fingerprint string // %GF
+}
+
+// Good reports whether the signature verified, whatever the key's trust.
+func (s *CommitSignature) Good() bool {
+	return s.Code == "G" || s.Code == "U"
+}
+
+// Describe returns a short human-readable verification result.
+func (s *CommitSignature) Describe() string {
+	switch s.Code {
+	case "G":
+		return "good signature"
+	case "U":
+		return "good signature, key of unknown validity"
+	case "B":
+		return "bad signature"
+	case "X":
+		return "good signature, expired"
+	case "Y":
+		return "good signature, key expired"
+	case "R":
+		return "good signature, key revoked"
+	case "E":
+		return "signature can't be checked (missing key or verifier config?)"
+	case "N", "":
+		return "unsigned"
+	default:
+		return "unknown signature status " + s.Code
+	}
+}
+
+// CommitSignature verifies the signature on the commit at ref, using the
+// repo's gpg/ssh verification config (gpg keyring, gpg.ssh.allowedSignersFile).
+// An unsigned commit is not an error: its Code is "N".
+func (g *Git) CommitSignature(ref string) (*CommitSignature, error) {
+	out, err := g.run("log", "-1", "--format=%G?%x00%GS%x00%GK%x00%GF", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitSignature(out), nil
+}
+
+// parseCommitSignature parses CommitSignature's log output.
+func parseCommitSignature(out string) *CommitSignature {
+	parts := strings.SplitN(out, "\x00", 4)
+	for len(parts) < 4 {
+		parts = append(parts, "")
+	}
+	return &CommitSignature{
+		Code:        strings.TrimSpace(parts[0]),
+		Signer:      parts[1],
+		Key:         parts[2],
+		Fingerprint: strings.TrimSpace(parts[3]),
+	}
+}
+
 // CommitsAhead returns the number of commits that branch has ahead of base.
 // For example, CommitsAhead("main", "feature") returns how many commits
 // are on feature that are not on main.
@@ -1,9 +1,12 @@
 package git
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -88,6 +91,331 @@ func TestCloneWithReferenceCreatesAlternates(t *testing.T) {
 	}
 }
 
+func TestCloneLocalFromBareRepo(t *testing.T) {
+	src := initTestRepo(t)
+	tmp := t.TempDir()
+	bare := filepath.Join(tmp, "repo.git")
+	dst := filepath.Join(tmp, "checkout")
+
+	if err := exec.Command("git", "clone", "--bare", src, bare).Run(); err != nil {
+		t.Fatalf("clone bare: %v", err)
+	}
+	_ = exec.Command("git", "--git-dir="+bare, "remote", "set-url", "origin", "https://example.com/repo.git").Run()
+
+	g := NewGitWithDir(bare, "")
+	if err := g.CloneLocal(dst); err != nil {
+		t.Fatalf("CloneLocal: %v", err)
+	}
+
+	// Independent clone: its own .git directory, not a worktree link file
+	info, err := os.Stat(filepath.Join(dst, ".git"))
+	if err != nil || !info.IsDir() {
+		t.Fatalf("expected .git directory in clone: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "README.md")); err != nil {
+		t.Errorf("expected checked-out README.md: %v", err)
+	}
+
+	url, err := NewGit(dst).RemoteURL("origin")
+	if err != nil {
+		t.Fatalf("RemoteURL: %v", err)
+	}
+	if url != "https://example.com/repo.git" {
+		t.Errorf("origin = %q, want upstream URL", url)
+	}
+}
+
+func TestSetEnvAugmentsGitEnvironment(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	g.SetEnv(map[string]string{
+		"GIT_CONFIG_COUNT":   "1",
+		"GIT_CONFIG_KEY_0":   "gastown.injected",
+		"GIT_CONFIG_VALUE_0": "yes",
+	})
+
+	// Injected config is visible, and the inherited environment (PATH, HOME)
+	// still lets git run normally.
+	got, err := g.run("config", "--get", "gastown.injected")
+	if err != nil {
+		t.Fatalf("config --get: %v", err)
+	}
+	if got != "yes" {
+		t.Errorf("gastown.injected = %q, want yes", got)
+	}
+	if _, err := g.CurrentBranch(); err != nil {
+		t.Errorf("CurrentBranch with extra env: %v", err)
+	}
+
+	// Without SetEnv the override is absent
+	if _, err := NewGit(dir).run("config", "--get", "gastown.injected"); err == nil {
+		t.Error("expected gastown.injected to be unset without SetEnv")
+	}
+}
+
+func TestSyncDirtyWorktree(t *testing.T) {
+	origin := initTestRepo(t)
+	clone := filepath.Join(t.TempDir(), "clone")
+	if err := NewGit(origin).Clone(origin, clone); err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	g := NewGit(clone)
+	for _, kv := range [][2]string{{"user.email", "test@test.com"}, {"user.name", "Test User"}} {
+		if _, err := g.run("config", kv[0], kv[1]); err != nil {
+			t.Fatalf("git config: %v", err)
+		}
+	}
+	branch, err := g.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(clone, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(clone, "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.Sync("origin", branch, false)
+	if !errors.Is(err, ErrWorkerDirty) {
+		t.Fatalf("Sync() = %v, want ErrWorkerDirty", err)
+	}
+	var dirty *WorkerDirtyError
+	if !errors.As(err, &dirty) {
+		t.Fatalf("Sync() error %T is not *WorkerDirtyError", err)
+	}
+	want := map[string]bool{"README.md": true, "notes.txt": true}
+	if len(dirty.Files) != len(want) {
+		t.Fatalf("dirty files = %v, want README.md and notes.txt", dirty.Files)
+	}
+	for _, f := range dirty.Files {
+		if !want[f] {
+			t.Errorf("unexpected dirty file %q", f)
+		}
+	}
+
+	// Autostash lets the sync proceed and restores the local changes
+	if err := g.Sync("origin", branch, true); err != nil {
+		t.Fatalf("Sync(autostash) = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(clone, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# Changed\n" {
+		t.Errorf("README.md = %q, want local change preserved", data)
+	}
+}
+
+func TestSyncLayouts(t *testing.T) {
+	origin := initTestRepo(t)
+	branch, err := NewGit(origin).CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+
+	// Shared clone (crew, mayor) and a linked worktree off it (polecats)
+	clone := filepath.Join(t.TempDir(), "clone")
+	if err := NewGit(origin).Clone(origin, clone); err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	worktree := filepath.Join(t.TempDir(), "Toast")
+	if err := NewGit(clone).WorktreeAdd(worktree, "polecat/Toast"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+
+	// Upstream moves on
+	if err := os.WriteFile(filepath.Join(origin, "upstream.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	og := NewGit(origin)
+	if err := og.Add("upstream.txt"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := og.Commit("upstream change"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	if err := NewGitWithDir(filepath.Join(clone, ".git"), "").Sync("origin", branch, false); err == nil {
+		t.Error("Sync() without a working tree succeeded, want error")
+	}
+
+	for name, dir := range map[string]string{"clone": clone, "worktree": worktree} {
+		t.Run(name, func(t *testing.T) {
+			if err := NewGit(dir).Sync("origin", branch, false); err != nil {
+				t.Fatalf("Sync() = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "upstream.txt")); err != nil {
+				t.Errorf("upstream change not synced: %v", err)
+			}
+		})
+	}
+}
+
+func TestWorkerRename(t *testing.T) {
+	repo := initTestRepo(t)
+	g := NewGit(repo)
+	polecats := filepath.Join(t.TempDir(), "polecats")
+	oldPath := filepath.Join(polecats, "Toast")
+	newPath := filepath.Join(polecats, "Nux")
+
+	if err := g.WorktreeAdd(oldPath, "polecat/Toast-abc123"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+
+	if err := g.WorkerRename(oldPath, newPath); err != nil {
+		t.Fatalf("WorkerRename: %v", err)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old worktree path still exists: %v", err)
+	}
+	branch, err := NewGit(newPath).CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch in moved worktree: %v", err)
+	}
+	if branch != "polecat/Nux-abc123" {
+		t.Errorf("branch = %q, want polecat/Nux-abc123", branch)
+	}
+}
+
+// leaveStaleWorktreeLock simulates an interrupted 'worktree add': the
+// worktree at path is registered and locked, but gone from disk.
+func leaveStaleWorktreeLock(t *testing.T, g *Git, path, branch string) {
+	t.Helper()
+	if err := g.WorktreeAdd(path, branch); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	if _, err := g.run("worktree", "lock", "--reason", "initializing", path); err != nil {
+		t.Fatalf("worktree lock: %v", err)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStaleWorktreeLock(t *testing.T) {
+	g := NewGit(initTestRepo(t))
+	path := filepath.Join(t.TempDir(), "Toast")
+	leaveStaleWorktreeLock(t, g, path, "polecat/Toast-1")
+
+	// The raw git failure is recognized
+	err := g.WorktreeAdd(path, "polecat/Toast-2")
+	if !errors.Is(err, ErrWorktreeLocked) {
+		t.Fatalf("WorktreeAdd over a stale lock = %v, want ErrWorktreeLocked", err)
+	}
+
+	stale, err := g.StaleWorktreeLock(path)
+	if err != nil || !stale {
+		t.Fatalf("StaleWorktreeLock = %v, %v; want true", stale, err)
+	}
+
+	// Recovery: clear the lock and the add goes through
+	if err := g.ClearStaleWorktreeLock(path); err != nil {
+		t.Fatalf("ClearStaleWorktreeLock: %v", err)
+	}
+	if stale, _ := g.StaleWorktreeLock(path); stale {
+		t.Error("lock still reported after clearing")
+	}
+	if err := g.WorktreeAdd(path, "polecat/Toast-3"); err != nil {
+		t.Fatalf("WorktreeAdd after clearing: %v", err)
+	}
+
+	// A live worktree, locked or not, is never stale
+	if _, err := g.run("worktree", "lock", path); err != nil {
+		t.Fatalf("worktree lock: %v", err)
+	}
+	if err := g.ClearStaleWorktreeLock(path); err != nil {
+		t.Fatalf("ClearStaleWorktreeLock(live): %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("live worktree was touched: %v", err)
+	}
+}
+
+func TestWorkerCreateFromBranch(t *testing.T) {
+	// A "remote" with a branch that was pushed from elsewhere
+	remoteDir := t.TempDir()
+	cmd := exec.Command("git", "init", "--bare")
+	cmd.Dir = remoteDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("git init --bare: %v", err)
+	}
+	elsewhere := initTestRepo(t)
+	for _, args := range [][]string{
+		{"remote", "add", "origin", remoteDir},
+		{"push", "origin", "HEAD:refs/heads/feature/remote-only"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = elsewhere
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	repo := initTestRepo(t)
+	g := NewGit(repo)
+	cmd = exec.Command("git", "remote", "add", "origin", remoteDir)
+	cmd.Dir = repo
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("git remote add: %v", err)
+	}
+	cmd = exec.Command("git", "branch", "polecat/Toast-abc123")
+	cmd.Dir = repo
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("git branch: %v", err)
+	}
+	polecats := t.TempDir()
+
+	// Local branch: checked out as-is
+	localPath := filepath.Join(polecats, "Toast")
+	if err := g.WorkerCreateFromBranch(localPath, "polecat/Toast-abc123"); err != nil {
+		t.Fatalf("WorkerCreateFromBranch(local): %v", err)
+	}
+	if branch, _ := NewGit(localPath).CurrentBranch(); branch != "polecat/Toast-abc123" {
+		t.Errorf("local worker branch = %q, want polecat/Toast-abc123", branch)
+	}
+
+	// Remote-only branch: fetched and tracked
+	remotePath := filepath.Join(polecats, "Nux")
+	if err := g.WorkerCreateFromBranch(remotePath, "feature/remote-only"); err != nil {
+		t.Fatalf("WorkerCreateFromBranch(remote): %v", err)
+	}
+	if branch, _ := NewGit(remotePath).CurrentBranch(); branch != "feature/remote-only" {
+		t.Errorf("remote worker branch = %q, want feature/remote-only", branch)
+	}
+	cmd = exec.Command("git", "rev-parse", "--abbrev-ref", "feature/remote-only@{upstream}")
+	cmd.Dir = remotePath
+	if out, err := cmd.Output(); err != nil || strings.TrimSpace(string(out)) != "origin/feature/remote-only" {
+		t.Errorf("upstream = %q (%v), want origin/feature/remote-only", out, err)
+	}
+
+	// Missing everywhere
+	err := g.WorkerCreateFromBranch(filepath.Join(polecats, "Slit"), "feature/nowhere")
+	if !errors.Is(err, ErrBranchNotFound) {
+		t.Errorf("WorkerCreateFromBranch(missing) = %v, want ErrBranchNotFound", err)
+	}
+}
+
+func TestRenamedWorkerBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"polecat/Toast", "polecat/Nux"},
+		{"polecat/Toast-abc123", "polecat/Nux-abc123"},
+		{"polecat/Toast/gt-xyz", "polecat/Nux/gt-xyz"},
+		{"polecat/Toaster", "polecat/Toaster"},
+		{"feature/Toast", "feature/Toast"},
+	}
+	for _, tt := range tests {
+		if got := RenamedWorkerBranch(tt.branch, "Toast", "Nux"); got != tt.want {
+			t.Errorf("RenamedWorkerBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
 func TestCurrentBranch(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -234,6 +562,74 @@ func TestRev(t *testing.T) {
 	}
 }
 
+func TestCommitSignature_Unsigned(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	sig, err := g.CommitSignature("HEAD")
+	if err != nil {
+		t.Fatalf("CommitSignature: %v", err)
+	}
+	if sig.Code != "N" || sig.Good() {
+		t.Errorf("CommitSignature = %+v, want unsigned (N)", sig)
+	}
+}
+
+func TestCommitSignature_SSH(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	dir := initTestRepo(t)
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatalf("read public key: %v", err)
+	}
+	allowed := filepath.Join(keyDir, "allowed_signers")
+	if err := os.WriteFile(allowed, []byte("test@test.com "+string(pub)), 0644); err != nil {
+		t.Fatalf("write allowed signers: %v", err)
+	}
+	for _, kv := range [][2]string{
+		{"gpg.format", "ssh"},
+		{"user.signingkey", key},
+		{"gpg.ssh.allowedSignersFile", allowed},
+	} {
+		cmd := exec.Command("git", "config", kv[0], kv[1])
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git config %s: %v\n%s", kv[0], err, out)
+		}
+	}
+	cmd := exec.Command("git", "commit", "--allow-empty", "-S", "-m", "signed")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("signed commit: %v\n%s", err, out)
+	}
+
+	sig, err := NewGit(dir).CommitSignature("HEAD")
+	if err != nil {
+		t.Fatalf("CommitSignature: %v", err)
+	}
+	if sig.Code != "G" || !strings.HasPrefix(sig.Key, "SHA256:") {
+		t.Errorf("CommitSignature = %+v, want a good signature with an SSH key fingerprint", sig)
+	}
+}
+
+func TestParseCommitSignature(t *testing.T) {
+	sig := parseCommitSignature("G\x00Alice <alice@example.com>\x00ABCDEF0123456789\x00AAAABBBBCCCCDDDDABCDEF0123456789")
+	want := CommitSignature{Code: "G", Signer: "Alice <alice@example.com>", Key: "ABCDEF0123456789", Fingerprint: "AAAABBBBCCCCDDDDABCDEF0123456789"}
+	if *sig != want {
+		t.Errorf("parseCommitSignature = %+v, want %+v", *sig, want)
+	}
+	if sig := parseCommitSignature("N"); sig.Code != "N" || sig.Describe() != "unsigned" {
+		t.Errorf("parseCommitSignature(N) = %+v (%s), want unsigned", sig, sig.Describe())
+	}
+}
+
 func TestFetchBranch(t *testing.T) {
 	// Create a "remote" repo
 	remoteDir := t.TempDir()
@@ -387,3 +783,292 @@ func TestCheckConflicts_WithConflict(t *testing.T) {
 		t.Error("expected clean working directory after CheckConflicts")
 	}
 }
+
+func TestVerify(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	if err := g.Verify(); err != nil {
+		t.Fatalf("Verify() on a sound repo = %v, want nil", err)
+	}
+
+	// Dangling worktree: directory deleted behind git's back
+	wt := filepath.Join(t.TempDir(), "wt")
+	if err := g.WorktreeAdd(wt, "feature"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	if err := os.RemoveAll(wt); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt object: truncate the README blob
+	blob, err := g.run("rev-parse", "HEAD:README.md")
+	if err != nil {
+		t.Fatalf("rev-parse: %v", err)
+	}
+	objPath := filepath.Join(dir, ".git", "objects", blob[:2], blob[2:])
+	if err := os.Chmod(objPath, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(objPath, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.Verify()
+	if !errors.Is(err, ErrRepoCorrupt) {
+		t.Fatalf("Verify() = %v, want ErrRepoCorrupt", err)
+	}
+	var integrity *RepoIntegrityError
+	if !errors.As(err, &integrity) {
+		t.Fatalf("Verify() error %T is not *RepoIntegrityError", err)
+	}
+	kinds := map[string]bool{}
+	for _, p := range integrity.Problems {
+		kinds[p.Kind] = true
+		if p.Hint == "" {
+			t.Errorf("problem %q has no hint", p.Detail)
+		}
+	}
+	if !kinds["objects"] || !kinds["worktree"] {
+		t.Errorf("Verify() problems = %+v, want both objects and worktree problems", integrity.Problems)
+	}
+}
+
+func TestCloneArgs(t *testing.T) {
+	src := initTestRepo(t)
+	g := NewGit(t.TempDir()).WithCloneArgs([]string{"--config=gastown.cloned=yes", "--depth=1"})
+
+	cmd := g.cloneCommand(&bytes.Buffer{}, "--bare", src, "dest")
+	want := []string{"git", "clone", "--config=gastown.cloned=yes", "--depth=1", "--bare", src, "dest"}
+	if strings.Join(cmd.Args, " ") != strings.Join(want, " ") {
+		t.Errorf("clone invocation = %v, want %v", cmd.Args, want)
+	}
+
+	// The args reach git: the clone carries the config they set
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := g.Clone("file://"+src, dest); err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if got, err := NewGit(dest).run("config", "gastown.cloned"); err != nil || got != "yes" {
+		t.Errorf("clone config gastown.cloned = %q, %v; want yes", got, err)
+	}
+}
+
+func TestCloneProgress(t *testing.T) {
+	src := initTestRepo(t)
+
+	// Quiet by default: no --progress, nothing streamed
+	cmd := NewGit(t.TempDir()).cloneCommand(&bytes.Buffer{}, src, "dest")
+	if strings.Contains(strings.Join(cmd.Args, " "), "--progress") {
+		t.Errorf("clone invocation = %v, want no --progress", cmd.Args)
+	}
+
+	var progress bytes.Buffer
+	g := NewGit(t.TempDir()).WithProgress(&progress)
+	dest := filepath.Join(t.TempDir(), "clone")
+	if err := g.CloneBare("file://"+src, dest); err != nil {
+		t.Fatalf("CloneBare: %v", err)
+	}
+	if !strings.Contains(progress.String(), "objects") {
+		t.Errorf("progress = %q, want git's object counts", progress.String())
+	}
+
+	// Failures still report git's error, not just the progress
+	err := g.Clone("file://"+filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "x"))
+	if err == nil || !strings.Contains(err.Error(), "does not appear to be a git repository") {
+		t.Errorf("Clone of missing repo error = %v, want git's message", err)
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"fatal: unable to access 'https://github.com/x/y.git/': Could not resolve host: github.com", true},
+		{"ssh: connect to host github.com port 22: Connection timed out\nfatal: Could not read from remote repository.", true},
+		{"error: RPC failed; HTTP 502 curl 22 The requested URL returned error: 502\nfatal: the remote end hung up unexpectedly", true},
+		{"fatal: unable to access 'http://127.0.0.1:1/x.git/': Failed to connect to 127.0.0.1 port 1: Couldn't connect to server", true},
+		{" ! [rejected]        main -> main (non-fast-forward)\nerror: failed to push some refs", false},
+		{" ! [remote rejected] main -> main (pre-receive hook declined)\nfatal: the remote end hung up unexpectedly", false},
+		{"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", false},
+		{"error: src refspec nope does not match any", false},
+	}
+	for _, tt := range tests {
+		if got := isNetworkError(tt.stderr); got != tt.want {
+			t.Errorf("isNetworkError(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+
+	err := NewGit(t.TempDir()).wrapError(errors.New("exit status 128"), tests[0].stderr, []string{"push", "origin", "main"})
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) || !errors.Is(err, ErrNetwork) || netErr.Op != "push" {
+		t.Errorf("wrapError(network failure) = %#v, want *NetworkError for push", err)
+	}
+}
+
+func TestValidateCloneArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"--filter=blob:none", "--jobs=4", "--config=core.autocrlf=false", "--single-branch"}, false},
+		{[]string{"--jobs", "4"}, true},                    // separate value
+		{[]string{"--bare"}, true},                         // changes the layout
+		{[]string{"--separate-git-dir=/tmp/x"}, true},      // changes the layout
+		{[]string{"--upload-pack=touch /tmp/pwned"}, true}, // runs a command
+		{[]string{"-utouch"}, true},                        // short form
+		{[]string{"--config=core.sshCommand=evil"}, true},  // config runs a command
+		{[]string{"-ccore.fsmonitor=evil"}, true},          // short config form
+		{[]string{"--config=protocol.ext.allow=always"}, true},
+		{[]string{"--filter=blob:none\n--bare"}, true}, // control characters
+		{[]string{"--upl=/bin/false"}, true},           // abbreviation of --upload-pack
+		{[]string{"--sep=/tmp/x"}, true},               // abbreviation of --separate-git-dir
+		{[]string{"-qu/bin/false"}, true},              // bundled short flags
+		{[]string{"--depth"}, true},                    // value missing
+		{[]string{"--no-tags=yes"}, true},              // takes no value
+		{[]string{"--config=core.askPass=/bin/false"}, true},
+		{[]string{"--config=credential.https://example.com.helper=!evil"}, true},
+		{[]string{"--config=include.path=/tmp/evil.cfg"}, true}, // can set core.sshCommand
+		{[]string{"--config=gastown.cloned=yes"}, true},         // keys are allow-listed
+		{[]string{"--config=http.postBuffer=524288000", "--depth=1", "--no-tags"}, false},
+	}
+	for _, tt := range tests {
+		err := ValidateCloneArgs(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCloneArgs(%q) = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateBranchName(t *testing.T) {
+	names := []string{
+		"polecat/Toast-abc", "polecat/Tōast-abc", "polecat/Nux.v2-abc", "release/1.2",
+		"", "polecat/Max Rockatansky-abc", "polecat/.hidden-abc", "a..b", "feature.",
+		"topic.lock", "a//b", "/lead", "trail/", "a:b", "a~1", "a^b", "a?b", "a*b", "a[b",
+		"a\\b", "a@{1}", "-flag", "tab\tname",
+	}
+	for _, name := range names {
+		// git itself is the reference for which names are valid
+		want := exec.Command("git", "check-ref-format", "--branch", name).Run() == nil
+		err := ValidateBranchName(name)
+		if (err == nil) != want {
+			t.Errorf("ValidateBranchName(%q) = %v, git check-ref-format says valid=%v", name, err, want)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidBranch) {
+			t.Errorf("ValidateBranchName(%q) error %v does not wrap ErrInvalidBranch", name, err)
+		}
+	}
+}
+
+func TestMergeTreeConflicts(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+	head, _ := g.Rev("HEAD")
+
+	commit := func(branch, content string) {
+		t.Helper()
+		if err := g.Checkout(branch); err != nil {
+			t.Fatalf("Checkout %s: %v", branch, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		if err := g.Add("README.md"); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := g.Commit("change on " + branch); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+	}
+	for _, b := range []string{"clean", "conflicting"} {
+		if err := g.CreateBranch(b); err != nil {
+			t.Fatalf("CreateBranch %s: %v", b, err)
+		}
+	}
+	commit("conflicting", "# Feature changes\n")
+	commit(mainBranch, "# Main changes\n")
+
+	if base, err := g.MergeBase("conflicting", mainBranch); err != nil || base != head {
+		t.Errorf("MergeBase() = %s, %v; want %s", base, err, head)
+	}
+	if conflicts, err := g.MergeTreeConflicts("clean", mainBranch); err != nil || len(conflicts) != 0 {
+		t.Errorf("MergeTreeConflicts(clean) = %v, %v; want none", conflicts, err)
+	}
+	conflicts, err := g.MergeTreeConflicts("conflicting", mainBranch)
+	if err != nil {
+		t.Fatalf("MergeTreeConflicts: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "README.md" {
+		t.Errorf("MergeTreeConflicts(conflicting) = %v, want [README.md]", conflicts)
+	}
+
+	// Nothing was touched
+	if status, _ := g.Status(); !status.Clean {
+		t.Errorf("worktree not clean after MergeTreeConflicts: %+v", status)
+	}
+}
+
+func TestSetWorktreeHooksPath(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@test.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@test.com")
+
+	bare := filepath.Join(t.TempDir(), ".repo.git")
+	if out, err := exec.Command("git", "clone", "--bare", initTestRepo(t), bare).CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+	repoGit := NewGitWithDir(bare, "")
+	polecats := t.TempDir()
+	hooked, plain := filepath.Join(polecats, "Toast"), filepath.Join(polecats, "Nux")
+	if err := repoGit.WorktreeAdd(hooked, "polecat/Toast"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	if err := repoGit.WorktreeAdd(plain, "polecat/Nux"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+
+	// A policy hook that refuses every commit
+	hooks := t.TempDir()
+	if err := os.WriteFile(filepath.Join(hooks, "pre-commit"), []byte("#!/bin/sh\necho policy >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewGit(hooked).SetWorktreeHooksPath(hooks); err != nil {
+		t.Fatalf("SetWorktreeHooksPath: %v", err)
+	}
+	// Idempotent once the extension is on
+	if err := NewGit(hooked).SetWorktreeHooksPath(hooks); err != nil {
+		t.Fatalf("SetWorktreeHooksPath again: %v", err)
+	}
+
+	gitOut := func(dir string, args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(out)), err
+	}
+	if got, _ := gitOut(hooked, "config", "core.hooksPath"); got != hooks {
+		t.Errorf("hooked worktree core.hooksPath = %q, want %q", got, hooks)
+	}
+	if got, _ := gitOut(plain, "config", "core.hooksPath"); got != "" {
+		t.Errorf("other worktree core.hooksPath = %q, want unset", got)
+	}
+	for _, dir := range []string{hooked, plain} {
+		if got, _ := gitOut(dir, "rev-parse", "--is-bare-repository"); got != "false" {
+			t.Errorf("%s is bare after enabling worktree config", filepath.Base(dir))
+		}
+	}
+	if got, _ := gitOut(bare, "rev-parse", "--is-bare-repository"); got != "true" {
+		t.Errorf("shared repo is-bare = %q, want true", got)
+	}
+
+	if out, err := gitOut(hooked, "commit", "--allow-empty", "-m", "blocked"); err == nil {
+		t.Errorf("commit in hooked worktree succeeded, want pre-commit hook to refuse it: %s", out)
+	}
+	if out, err := gitOut(plain, "commit", "--allow-empty", "-m", "allowed"); err != nil {
+		t.Errorf("commit in other worktree: %v\n%s", err, out)
+	}
+}
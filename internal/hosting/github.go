@@ -0,0 +1,166 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// gitHub reads pull requests from the GitHub REST API.
+type gitHub struct {
+	*client
+	repo string // owner/name
+}
+
+type ghPull struct {
+	Number    int    `json:"number"`
+	HTMLURL   string `json:"html_url"`
+	State     string `json:"state"`
+	Merged    bool   `json:"merged"`
+	Draft     bool   `json:"draft"`
+	Mergeable *bool  `json:"mergeable"` // null while GitHub computes it
+	Head      struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+type ghReview struct {
+	State string `json:"state"`
+	User  struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// PullRequest returns the most recent pull request whose head is branch.
+func (g *gitHub) PullRequest(ctx context.Context, branch string) (*PullRequest, error) {
+	owner, _, _ := strings.Cut(g.repo, "/")
+	var found []ghPull
+	q := url.Values{"head": {owner + ":" + branch}, "state": {"all"}, "per_page": {"1"}}
+	if err := g.getJSON(ctx, "/repos/"+g.repo+"/pulls?"+q.Encode(), &found); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w %s in %s", ErrNoPullRequest, branch, g.repo)
+	}
+
+	// The list omits mergeable; the single-PR endpoint computes it.
+	var pull ghPull
+	if err := g.getJSON(ctx, fmt.Sprintf("/repos/%s/pulls/%d", g.repo, found[0].Number), &pull); err != nil {
+		return nil, err
+	}
+	pr := &PullRequest{
+		Provider:  ProviderGitHub,
+		Number:    pull.Number,
+		URL:       pull.HTMLURL,
+		State:     pull.State,
+		Draft:     pull.Draft,
+		Mergeable: MergeableUnknown,
+	}
+	if pull.Merged {
+		pr.State = "merged"
+	}
+	if pull.Mergeable != nil {
+		pr.Mergeable = MergeableConflict
+		if *pull.Mergeable {
+			pr.Mergeable = MergeableReady
+		}
+	}
+
+	var reviews []ghReview
+	if err := g.getJSON(ctx, fmt.Sprintf("/repos/%s/pulls/%d/reviews?per_page=100", g.repo, pull.Number), &reviews); err != nil {
+		return nil, err
+	}
+	pr.Approvals, pr.ChangesRequested = ghReviewVerdict(reviews)
+
+	ci, err := g.ciStatus(ctx, pull.Head.SHA)
+	if err != nil {
+		return nil, err
+	}
+	pr.CI = ci
+	return pr, nil
+}
+
+// ghReviewVerdict counts approvals from each reviewer's latest deciding
+// review; comments don't change an earlier approval or change request.
+func ghReviewVerdict(reviews []ghReview) (approvals int, changesRequested bool) {
+	latest := make(map[string]string)
+	for _, r := range reviews { // oldest first
+		switch r.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[r.User.Login] = r.State
+		}
+	}
+	for _, state := range latest {
+		switch state {
+		case "APPROVED":
+			approvals++
+		case "CHANGES_REQUESTED":
+			changesRequested = true
+		}
+	}
+	return approvals, changesRequested
+}
+
+// ciStatus combines the check runs and commit statuses on sha.
+func (g *gitHub) ciStatus(ctx context.Context, sha string) (string, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := g.getJSON(ctx, "/repos/"+g.repo+"/commits/"+sha+"/check-runs?per_page=100", &runs); err != nil {
+		return "", err
+	}
+	var combined struct {
+		State    string `json:"state"`
+		Statuses []struct {
+			State string `json:"state"`
+		} `json:"statuses"`
+	}
+	if err := g.getJSON(ctx, "/repos/"+g.repo+"/commits/"+sha+"/status", &combined); err != nil {
+		return "", err
+	}
+
+	var results []string
+	for _, run := range runs.CheckRuns {
+		switch {
+		case run.Status != "completed":
+			results = append(results, CIPending)
+		case run.Conclusion == "success" || run.Conclusion == "skipped" || run.Conclusion == "neutral":
+			results = append(results, CIPass)
+		default: // failure, cancelled, timed_out, action_required, stale
+			results = append(results, CIFail)
+		}
+	}
+	if len(combined.Statuses) > 0 {
+		switch combined.State {
+		case "success":
+			results = append(results, CIPass)
+		case "pending":
+			results = append(results, CIPending)
+		default: // failure, error
+			results = append(results, CIFail)
+		}
+	}
+	return worstCI(results), nil
+}
+
+// worstCI reduces individual results to one: any failure fails, then any
+// pending is pending; no results at all is none.
+func worstCI(results []string) string {
+	if len(results) == 0 {
+		return CINone
+	}
+	status := CIPass
+	for _, r := range results {
+		switch r {
+		case CIFail:
+			return CIFail
+		case CIPending:
+			status = CIPending
+		}
+	}
+	return status
+}
@@ -0,0 +1,95 @@
+package hosting
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// gitLab reads merge requests from the GitLab REST API (v4).
+type gitLab struct {
+	*client
+	project string // group/name path
+}
+
+type glMergeRequest struct {
+	IID                 int    `json:"iid"`
+	WebURL              string `json:"web_url"`
+	State               string `json:"state"` // opened, closed, locked or merged
+	Draft               bool   `json:"draft"`
+	HasConflicts        bool   `json:"has_conflicts"`
+	DetailedMergeStatus string `json:"detailed_merge_status"`
+	HeadPipeline        *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"`
+}
+
+// PullRequest returns the most recent merge request from branch.
+func (g *gitLab) PullRequest(ctx context.Context, branch string) (*PullRequest, error) {
+	base := "/projects/" + url.PathEscape(g.project) + "/merge_requests"
+	var found []glMergeRequest
+	q := url.Values{"source_branch": {branch}, "order_by": {"created_at"}, "sort": {"desc"}, "per_page": {"1"}}
+	if err := g.getJSON(ctx, base+"?"+q.Encode(), &found); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%w %s in %s", ErrNoPullRequest, branch, g.project)
+	}
+
+	// The list omits the head pipeline; the single-MR endpoint has it.
+	var mr glMergeRequest
+	if err := g.getJSON(ctx, fmt.Sprintf("%s/%d", base, found[0].IID), &mr); err != nil {
+		return nil, err
+	}
+	var approvals struct {
+		ApprovedBy []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"approved_by"`
+	}
+	if err := g.getJSON(ctx, fmt.Sprintf("%s/%d/approvals", base, mr.IID), &approvals); err != nil {
+		return nil, err
+	}
+
+	pr := &PullRequest{
+		Provider:  ProviderGitLab,
+		Number:    mr.IID,
+		URL:       mr.WebURL,
+		State:     mr.State,
+		Draft:     mr.Draft,
+		CI:        CINone,
+		Approvals: len(approvals.ApprovedBy),
+		Mergeable: MergeableUnknown,
+	}
+	switch mr.State {
+	case "opened":
+		pr.State = "open"
+	case "locked":
+		pr.State = "closed"
+	}
+	switch {
+	case mr.HasConflicts:
+		pr.Mergeable = MergeableConflict
+	case mr.DetailedMergeStatus == "mergeable":
+		pr.Mergeable = MergeableReady
+	}
+	if mr.HeadPipeline != nil {
+		pr.CI = glPipelineCI(mr.HeadPipeline.Status)
+	}
+	return pr, nil
+}
+
+// glPipelineCI maps a GitLab pipeline status to a CI status.
+func glPipelineCI(status string) string {
+	switch status {
+	case "success", "skipped":
+		return CIPass
+	case "failed", "canceled":
+		return CIFail
+	case "":
+		return CINone
+	default: // created, pending, running, preparing, scheduled, manual, ...
+		return CIPending
+	}
+}
@@ -0,0 +1,172 @@
+// Package hosting fetches live pull request data (CI status, approvals,
+// mergeability) from a rig's hosting provider, GitHub or GitLab.
+package hosting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Supported providers.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// CI status of a pull request's head commit.
+const (
+	CIPass    = "pass"
+	CIFail    = "fail"
+	CIPending = "pending"
+	CINone    = "none" // no checks or pipeline ran
+)
+
+// Whether a pull request can merge as the provider sees it.
+const (
+	MergeableReady    = "ready"
+	MergeableConflict = "conflict"
+	MergeableUnknown  = "unknown" // not computed yet, or blocked for another reason
+)
+
+// DefaultTimeout bounds each request to the provider.
+const DefaultTimeout = 10 * time.Second
+
+var (
+	// ErrNotConfigured means the rig has no hosting section: use local data only.
+	ErrNotConfigured = errors.New("no hosting provider configured")
+
+	// ErrUnknownProvider means hosting.provider is not github or gitlab.
+	ErrUnknownProvider = errors.New("unknown hosting provider")
+
+	// ErrNoToken means the environment variable holding the API token is unset.
+	ErrNoToken = errors.New("hosting API token not set")
+
+	// ErrUnauthorized means the provider rejected the token (401/403).
+	ErrUnauthorized = errors.New("hosting provider rejected the API token")
+
+	// ErrNoPullRequest means the provider has no pull request for the branch.
+	ErrNoPullRequest = errors.New("no pull request for branch")
+)
+
+// Config is a rig's hosting provider integration (the "hosting" section of
+// the rig's config.json). The token itself is never stored in config: it is
+// read from the environment variable named by TokenEnv.
+type Config struct {
+	Provider string `json:"provider"`            // "github" or "gitlab"
+	Repo     string `json:"repo"`                // owner/name on GitHub, project path on GitLab
+	BaseURL  string `json:"base_url,omitempty"`  // API root for GitHub Enterprise or self-hosted GitLab
+	TokenEnv string `json:"token_env,omitempty"` // env var holding the API token (default GITHUB_TOKEN / GITLAB_TOKEN)
+}
+
+// tokenEnv returns the environment variable the token is read from.
+func (c *Config) tokenEnv() string {
+	if c.TokenEnv != "" {
+		return c.TokenEnv
+	}
+	if strings.EqualFold(c.Provider, ProviderGitLab) {
+		return "GITLAB_TOKEN"
+	}
+	return "GITHUB_TOKEN"
+}
+
+// PullRequest is the provider's live view of a merge request's branch.
+type PullRequest struct {
+	Provider         string `json:"provider"`
+	Number           int    `json:"number"`
+	URL              string `json:"url"`
+	State            string `json:"state"` // open, closed or merged
+	Draft            bool   `json:"draft,omitempty"`
+	CI               string `json:"ci"` // pass, fail, pending or none
+	Approvals        int    `json:"approvals"`
+	ChangesRequested bool   `json:"changes_requested,omitempty"`
+	Mergeable        string `json:"mergeable"` // ready, conflict or unknown
+}
+
+// Provider looks up the pull request for a branch.
+type Provider interface {
+	PullRequest(ctx context.Context, branch string) (*PullRequest, error)
+}
+
+// New returns the provider client for cfg. A nil cfg returns
+// ErrNotConfigured; a missing token returns ErrNoToken.
+func New(cfg *Config) (Provider, error) {
+	if cfg == nil || cfg.Provider == "" {
+		return nil, ErrNotConfigured
+	}
+	if cfg.Repo == "" {
+		return nil, fmt.Errorf("hosting.repo is required for provider %q", cfg.Provider)
+	}
+	token := os.Getenv(cfg.tokenEnv())
+	if token == "" {
+		return nil, fmt.Errorf("%w: set $%s", ErrNoToken, cfg.tokenEnv())
+	}
+	c := &client{
+		http:  &http.Client{Timeout: DefaultTimeout},
+		base:  strings.TrimSuffix(cfg.BaseURL, "/"),
+		token: token,
+	}
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderGitHub:
+		if c.base == "" {
+			c.base = "https://api.github.com"
+		}
+		c.auth = func(req *http.Request, token string) {
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Accept", "application/vnd.github+json")
+		}
+		return &gitHub{client: c, repo: cfg.Repo}, nil
+	case ProviderGitLab:
+		if c.base == "" {
+			c.base = "https://gitlab.com/api/v4"
+		}
+		c.auth = func(req *http.Request, token string) {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}
+		return &gitLab{client: c, project: cfg.Repo}, nil
+	default:
+		return nil, fmt.Errorf("%w %q (want %s or %s)", ErrUnknownProvider, cfg.Provider, ProviderGitHub, ProviderGitLab)
+	}
+}
+
+// client is the HTTP plumbing shared by the providers.
+type client struct {
+	http  *http.Client
+	base  string
+	token string
+	auth  func(req *http.Request, token string)
+}
+
+// getJSON GETs base+path and decodes the JSON response into v.
+func (c *client) getJSON(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.auth(req, c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (%s)", ErrUnauthorized, resp.Status)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: decoding response: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package hosting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeAPI serves canned JSON bodies by request URI and records the
+// headers of the last request.
+func fakeAPI(t *testing.T, routes map[string]string) (*httptest.Server, *http.Header) {
+	t.Helper()
+	var last http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r.Header.Clone()
+		body, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.RequestURI())
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &last
+}
+
+func TestGitHubPullRequest(t *testing.T) {
+	srv, headers := fakeAPI(t, map[string]string{
+		"/repos/acme/widgets/pulls?head=acme%3Apolecat%2FNux%2Fgt-1&per_page=1&state=all": `[{"number":42}]`,
+		"/repos/acme/widgets/pulls/42": `{"number":42,"html_url":"https://github.com/acme/widgets/pull/42",
+			"state":"open","draft":false,"mergeable":true,"head":{"sha":"abc123"}}`,
+		"/repos/acme/widgets/pulls/42/reviews?per_page=100": `[
+			{"state":"CHANGES_REQUESTED","user":{"login":"ann"}},
+			{"state":"APPROVED","user":{"login":"ann"}},
+			{"state":"COMMENTED","user":{"login":"ann"}},
+			{"state":"APPROVED","user":{"login":"bob"}}]`,
+		"/repos/acme/widgets/commits/abc123/check-runs?per_page=100": `{"check_runs":[
+			{"status":"completed","conclusion":"success"},
+			{"status":"in_progress","conclusion":null}]}`,
+		"/repos/acme/widgets/commits/abc123/status": `{"state":"success","statuses":[{"state":"success"}]}`,
+	})
+	t.Setenv("GITHUB_TOKEN", "gh-secret")
+
+	p, err := New(&Config{Provider: "github", Repo: "acme/widgets", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	pr, err := p.PullRequest(context.Background(), "polecat/Nux/gt-1")
+	if err != nil {
+		t.Fatalf("PullRequest: %v", err)
+	}
+	want := PullRequest{
+		Provider:  ProviderGitHub,
+		Number:    42,
+		URL:       "https://github.com/acme/widgets/pull/42",
+		State:     "open",
+		CI:        CIPending,
+		Approvals: 2, // ann's comment after approving doesn't undo it
+		Mergeable: MergeableReady,
+	}
+	if *pr != want {
+		t.Errorf("PullRequest = %+v, want %+v", *pr, want)
+	}
+	if got := headers.Get("Authorization"); got != "Bearer gh-secret" {
+		t.Errorf("Authorization = %q, want bearer token", got)
+	}
+}
+
+func TestGitLabPullRequest(t *testing.T) {
+	srv, headers := fakeAPI(t, map[string]string{
+		"/projects/acme%2Fwidgets/merge_requests?order_by=created_at&per_page=1&sort=desc&source_branch=polecat%2FNux%2Fgt-1": `[{"iid":7}]`,
+		"/projects/acme%2Fwidgets/merge_requests/7": `{"iid":7,"web_url":"https://gitlab.com/acme/widgets/-/merge_requests/7",
+			"state":"opened","draft":true,"has_conflicts":true,"detailed_merge_status":"conflict",
+			"head_pipeline":{"status":"failed"}}`,
+		"/projects/acme%2Fwidgets/merge_requests/7/approvals": `{"approved_by":[{"user":{"username":"ann"}}]}`,
+	})
+	t.Setenv("GL_CI_TOKEN", "gl-secret")
+
+	p, err := New(&Config{Provider: "gitlab", Repo: "acme/widgets", BaseURL: srv.URL + "/", TokenEnv: "GL_CI_TOKEN"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	pr, err := p.PullRequest(context.Background(), "polecat/Nux/gt-1")
+	if err != nil {
+		t.Fatalf("PullRequest: %v", err)
+	}
+	want := PullRequest{
+		Provider:  ProviderGitLab,
+		Number:    7,
+		URL:       "https://gitlab.com/acme/widgets/-/merge_requests/7",
+		State:     "open",
+		Draft:     true,
+		CI:        CIFail,
+		Approvals: 1,
+		Mergeable: MergeableConflict,
+	}
+	if *pr != want {
+		t.Errorf("PullRequest = %+v, want %+v", *pr, want)
+	}
+	if got := headers.Get("PRIVATE-TOKEN"); got != "gl-secret" {
+		t.Errorf("PRIVATE-TOKEN = %q, want token from $GL_CI_TOKEN", got)
+	}
+}
+
+func TestPullRequest_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer srv.Close()
+	cfg := &Config{Provider: "github", Repo: "acme/widgets", BaseURL: srv.URL}
+
+	t.Setenv("GITHUB_TOKEN", "bad")
+	p, _ := New(cfg)
+	if _, err := p.PullRequest(context.Background(), "main"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("PullRequest with bad token = %v, want ErrUnauthorized", err)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "good")
+	p, _ = New(cfg)
+	if _, err := p.PullRequest(context.Background(), "main"); !errors.Is(err, ErrNoPullRequest) {
+		t.Errorf("PullRequest with no PR = %v, want ErrNoPullRequest", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN", "set")
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr error
+	}{
+		{"nil config", nil, ErrNotConfigured},
+		{"no provider", &Config{Repo: "acme/widgets"}, ErrNotConfigured},
+		{"unknown provider", &Config{Provider: "bitbucket", Repo: "acme/widgets", TokenEnv: "GITLAB_TOKEN"}, ErrUnknownProvider},
+		{"missing token", &Config{Provider: "github", Repo: "acme/widgets"}, ErrNoToken},
+		{"gitlab default token", &Config{Provider: "GitLab", Repo: "acme/widgets"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("New = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := New(&Config{Provider: "github"}); err == nil {
+		t.Error("New without repo succeeded, want error")
+	}
+}
@@ -5,10 +5,45 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
+var (
+	// ErrIDNotFound means no ID matches, exactly or as a prefix.
+	ErrIDNotFound = errors.New("no matching ID")
+
+	// ErrAmbiguousID means a prefix matches more than one ID.
+	ErrAmbiguousID = errors.New("ambiguous ID prefix")
+)
+
+// AmbiguousIDError reports a prefix matching several IDs. It unwraps to
+// ErrAmbiguousID.
+type AmbiguousIDError struct {
+	Prefix  string
+	Matches []string // sorted
+}
+
+func (e *AmbiguousIDError) Error() string {
+	shown := e.Matches
+	if len(shown) > 5 {
+		shown = shown[:5]
+	}
+	msg := fmt.Sprintf("%s: %q matches %d IDs (%s", ErrAmbiguousID, e.Prefix, len(e.Matches), strings.Join(shown, ", "))
+	if len(shown) < len(e.Matches) {
+		msg += ", ..."
+	}
+	return msg + "); give more characters"
+}
+
+func (e *AmbiguousIDError) Unwrap() error {
+	return ErrAmbiguousID
+}
+
 // GenerateMRID generates a merge request ID following the convention: <prefix>-mr-<hash>
 //
 // The hash is derived from the branch name + current timestamp + random bytes to ensure uniqueness.
@@ -47,3 +82,72 @@ func generateMRIDInternal(prefix, branch string, timestamp time.Time, randomByte
 
 	return fmt.Sprintf("%s-mr-%s", prefix, hashStr)
 }
+
+// ResolveID returns the ID in ids that arg names: an exact match, else the
+// only ID arg is a prefix of. Returns ErrIDNotFound if none match, or an
+// *AmbiguousIDError if arg is a prefix of several.
+func ResolveID(ids []string, arg string) (string, error) {
+	if arg == "" {
+		return "", ErrIDNotFound
+	}
+	var matches []string
+	for _, id := range ids {
+		if id == arg {
+			return id, nil
+		}
+		if strings.HasPrefix(id, arg) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", ErrIDNotFound
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", &AmbiguousIDError{Prefix: arg, Matches: matches}
+	}
+}
+
+// ShortIDs returns the display form of each of ids: the ID cut to width
+// characters, extended just far enough to stay a unique prefix among ids,
+// so ResolveID(ids, short) always gives the ID back. A width of 0 (or one
+// longer than an ID) keeps the whole ID.
+func ShortIDs(ids []string, width int) map[string]string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	sorted = slices.Compact(sorted)
+
+	short := make(map[string]string, len(sorted))
+	for i, id := range sorted {
+		if width <= 0 || len(id) <= width {
+			short[id] = id
+			continue
+		}
+		// In sorted order, the IDs sharing the longest prefix with id are
+		// its neighbours: one character past that prefix is unique.
+		n := width
+		for _, j := range []int{i - 1, i + 1} {
+			if j >= 0 && j < len(sorted) {
+				if l := commonPrefixLen(id, sorted[j]) + 1; l > n {
+					n = l
+				}
+			}
+		}
+		if n > len(id) {
+			n = len(id)
+		}
+		short[id] = id[:n]
+	}
+	return short
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -1,6 +1,7 @@
 package mq
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -141,3 +142,66 @@ func TestGenerateMRID_Uniqueness(t *testing.T) {
 		ids[id] = true
 	}
 }
+
+func TestResolveID(t *testing.T) {
+	ids := []string{"gastown-mr-abc123", "gastown-mr-abd456", "gastown-mr-xyz", "gastown-mr-xyz9"}
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{"gastown-mr-abc123", "gastown-mr-abc123", nil},
+		{"gastown-mr-abc", "gastown-mr-abc123", nil},
+		{"gastown-mr-xyz", "gastown-mr-xyz", nil}, // exact beats being a prefix of xyz9
+		{"gastown-mr-ab", "", ErrAmbiguousID},
+		{"gastown-mr-q", "", ErrIDNotFound},
+		{"", "", ErrIDNotFound},
+	}
+	for _, tt := range tests {
+		got, err := ResolveID(ids, tt.arg)
+		if got != tt.want || !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+			t.Errorf("ResolveID(%q) = %q, %v; want %q, %v", tt.arg, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	_, err := ResolveID(ids, "gastown-mr-")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 4 {
+		t.Fatalf("ResolveID(common prefix) = %v, want *AmbiguousIDError with 4 matches", err)
+	}
+	if !strings.Contains(err.Error(), "gastown-mr-abc123, gastown-mr-abd456") {
+		t.Errorf("error %q should list the candidates", err)
+	}
+}
+
+func TestShortIDs(t *testing.T) {
+	ids := []string{
+		"gastown-mr-abc123", "gastown-mr-abc124", // differ only in the last character
+		"gastown-mr-xyz", "gastown-mr-xyz9", // one is a prefix of the other
+		"gastown-mr-q00001",
+		"gt-mr-a1", // shorter than the width
+	}
+	short := ShortIDs(append(ids, ids[0], ids[4]), 12) // duplicates are ignored
+	want := map[string]string{
+		"gastown-mr-abc123": "gastown-mr-abc123",
+		"gastown-mr-abc124": "gastown-mr-abc124",
+		"gastown-mr-xyz":    "gastown-mr-xyz",
+		"gastown-mr-xyz9":   "gastown-mr-xyz9",
+		"gastown-mr-q00001": "gastown-mr-q",
+		"gt-mr-a1":          "gt-mr-a1",
+	}
+	for id, w := range want {
+		if short[id] != w {
+			t.Errorf("ShortIDs[%q] = %q, want %q", id, short[id], w)
+		}
+	}
+
+	// Every short form, at every width, resolves back to its ID
+	for width := 0; width <= 20; width++ {
+		for id, s := range ShortIDs(ids, width) {
+			if got, err := ResolveID(ids, s); err != nil || got != id {
+				t.Errorf("width %d: ResolveID(%q) = %q, %v; want %q", width, s, got, err, id)
+			}
+		}
+	}
+}
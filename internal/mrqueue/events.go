@@ -2,8 +2,10 @@
 package mrqueue
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -14,6 +16,8 @@ import (
 type EventType string
 
 const (
+	// EventQueued indicates an MR was submitted to the queue.
+	EventQueued EventType = "queued"
 	// EventMergeStarted indicates refinery began processing an MR.
 	EventMergeStarted EventType = "merge_started"
 	// EventMerged indicates an MR was successfully merged.
@@ -22,6 +26,8 @@ const (
 	EventMergeFailed EventType = "merge_failed"
 	// EventMergeSkipped indicates an MR was skipped (already merged, etc.).
 	EventMergeSkipped EventType = "merge_skipped"
+	// EventRejected indicates an MR was rejected by an operator.
+	EventRejected EventType = "rejected"
 )
 
 // Event represents a single MQ lifecycle event.
@@ -36,6 +42,10 @@ type Event struct {
 	Rig         string    `json:"rig,omitempty"`
 	MergeCommit string    `json:"merge_commit,omitempty"` // For merged events
 	Reason      string    `json:"reason,omitempty"`       // For failed/skipped events
+	Batch       []string  `json:"batch,omitempty"`        // MRs merged together, for batched merged events
+
+	// SupersededBy is the MR replacing a rejected one, for rejected events
+	SupersededBy string `json:"superseded_by,omitempty"`
 }
 
 // EventLogger handles writing MQ events to the event log.
@@ -91,6 +101,19 @@ func (l *EventLogger) LogEvent(event Event) error {
 	return nil
 }
 
+// LogQueued logs a queued event.
+func (l *EventLogger) LogQueued(mr *MR) error {
+	return l.LogEvent(Event{
+		Type:        EventQueued,
+		MRID:        mr.ID,
+		Branch:      mr.Branch,
+		Target:      mr.Target,
+		Worker:      mr.Worker,
+		SourceIssue: mr.SourceIssue,
+		Rig:         mr.Rig,
+	})
+}
+
 // LogMergeStarted logs a merge_started event.
 func (l *EventLogger) LogMergeStarted(mr *MR) error {
 	return l.LogEvent(Event{
@@ -106,6 +129,12 @@ func (l *EventLogger) LogMergeStarted(mr *MR) error {
 
 // LogMerged logs a merged event.
 func (l *EventLogger) LogMerged(mr *MR, mergeCommit string) error {
+	return l.LogMergedInBatch(mr, mergeCommit, nil)
+}
+
+// LogMergedInBatch logs a merged event for an MR merged together with the
+// batch MRs in one push. A nil batch logs a plain merged event.
+func (l *EventLogger) LogMergedInBatch(mr *MR, mergeCommit string, batch []string) error {
 	return l.LogEvent(Event{
 		Type:        EventMerged,
 		MRID:        mr.ID,
@@ -115,6 +144,7 @@ func (l *EventLogger) LogMerged(mr *MR, mergeCommit string) error {
 		SourceIssue: mr.SourceIssue,
 		Rig:         mr.Rig,
 		MergeCommit: mergeCommit,
+		Batch:       batch,
 	})
 }
 
@@ -150,3 +180,44 @@ func (l *EventLogger) LogMergeSkipped(mr *MR, reason string) error {
 func (l *EventLogger) LogPath() string {
 	return l.logPath
 }
+
+// ReadEvents returns the events logged at or after byte offset, and the
+// offset to read from next. A partially written last line is left for the
+// next read, and unparseable lines are skipped. If the log was truncated
+// below offset it is read again from the start.
+func (l *EventLogger) ReadEvents(offset int64) ([]Event, int64, error) {
+	f, err := os.Open(l.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, offset, fmt.Errorf("opening event log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, fmt.Errorf("reading event log: %w", err)
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("reading event log: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, offset, fmt.Errorf("reading event log: %w", err)
+	}
+
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	var events []Event
+	for _, line := range bytes.Split(data[:complete], []byte{'\n'}) {
+		var event Event
+		if len(line) == 0 || json.Unmarshal(line, &event) != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, offset + int64(complete), nil
+}
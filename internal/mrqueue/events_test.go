@@ -96,6 +96,49 @@ func TestEventLogger(t *testing.T) {
 	}
 }
 
+func TestEventLogger_ReadEvents(t *testing.T) {
+	logger := NewEventLogger(t.TempDir())
+	mr := &MR{ID: "mr-1", Branch: "polecat/Nux/gt-abc", Target: "main"}
+
+	// No log yet
+	events, offset, err := logger.ReadEvents(0)
+	if err != nil || len(events) != 0 || offset != 0 {
+		t.Fatalf("ReadEvents on missing log = %v, %d, %v", events, offset, err)
+	}
+
+	if err := logger.LogQueued(mr); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.LogMergeStarted(mr); err != nil {
+		t.Fatal(err)
+	}
+	events, offset, err = logger.ReadEvents(0)
+	if err != nil || len(events) != 2 || events[0].Type != EventQueued {
+		t.Fatalf("ReadEvents(0) = %v, %v; want queued and merge_started", events, err)
+	}
+
+	// A partially written line is held back until complete
+	f, err := os.OpenFile(logger.LogPath(), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"type":"merged","mr_id":"mr-1"`); err != nil {
+		t.Fatal(err)
+	}
+	events, next, err := logger.ReadEvents(offset)
+	if err != nil || len(events) != 0 || next != offset {
+		t.Fatalf("ReadEvents with partial line = %v, %d, %v; want nothing at %d", events, next, err, offset)
+	}
+	if _, err := f.WriteString("}\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+	events, _, err = logger.ReadEvents(offset)
+	if err != nil || len(events) != 1 || events[0].Type != EventMerged {
+		t.Errorf("ReadEvents after completing line = %v, %v; want merged", events, err)
+	}
+}
+
 func splitLines(s string) []string {
 	var lines []string
 	start := 0
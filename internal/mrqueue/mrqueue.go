@@ -13,6 +13,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/gofrs/flock"
 )
 
 // MR represents a merge request in the queue.
@@ -83,7 +85,7 @@ func generateID() string {
 	return fmt.Sprintf("mr-%d-%s", time.Now().Unix(), hex.EncodeToString(b))
 }
 
-// Submit adds a new MR to the queue.
+// Submit adds a new MR to the queue and logs a queued event.
 func (q *Queue) Submit(mr *MR) error {
 	if err := q.EnsureDir(); err != nil {
 		return fmt.Errorf("creating mq directory: %w", err)
@@ -106,6 +108,9 @@ func (q *Queue) Submit(mr *MR) error {
 		return fmt.Errorf("writing MR file: %w", err)
 	}
 
+	// Best-effort: the event log is for watchers (gt mq tail), not state
+	_ = NewEventLogger(filepath.Dir(q.dir)).LogQueued(mr)
+
 	return nil
 }
 
@@ -209,6 +214,9 @@ func (q *Queue) Remove(id string) error {
 	if os.IsNotExist(err) {
 		return nil // Already removed
 	}
+	if err == nil {
+		_ = os.Remove(filepath.Join(q.dir, id+".lock")) // claim lock, if any
+	}
 	return err
 }
 
@@ -235,14 +243,60 @@ func (q *Queue) Dir() string {
 
 // ClaimStaleTimeout is how long before a claimed MR is considered stale.
 // If a worker claims an MR but doesn't process it within this time,
-// another worker can reclaim it.
+// another worker can reclaim it. Workers holding an MR for longer (e.g. a
+// slow test run) keep their claim alive with Renew.
 const ClaimStaleTimeout = 10 * time.Minute
 
+// ClaimRenewInterval is how often a worker processing an MR should Renew
+// its claim, well inside ClaimStaleTimeout.
+const ClaimRenewInterval = ClaimStaleTimeout / 3
+
+// lockMR takes the per-MR lock serializing claim changes. Several refinery
+// workers may share the queue directory; each claim is a read-check-write
+// of the MR file, so without the lock two workers could both see an MR
+// unclaimed and both take it. The lock is held only for the update.
+func (q *Queue) lockMR(id string) (*flock.Flock, error) {
+	if err := q.EnsureDir(); err != nil {
+		return nil, fmt.Errorf("creating mq directory: %w", err)
+	}
+	fileLock := flock.New(filepath.Join(q.dir, id+".lock"))
+	if err := fileLock.Lock(); err != nil {
+		return nil, fmt.Errorf("locking MR %s: %w", id, err)
+	}
+	return fileLock, nil
+}
+
+// save writes an MR atomically (temp file, then rename), so readers never
+// see a partial file.
+func (q *Queue) save(path string, mr *MR) error {
+	data, err := json.MarshalIndent(mr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling MR: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath) // cleanup
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+	return nil
+}
+
 // Claim attempts to claim an MR for processing by a specific worker.
 // Returns nil if successful, ErrAlreadyClaimed if another worker has it,
 // or ErrNotFound if the MR doesn't exist.
-// Uses atomic file operations to prevent race conditions.
+// The check and the write happen under the MR's lock, so when workers race
+// for the same MR exactly one wins.
 func (q *Queue) Claim(id, workerID string) error {
+	fileLock, err := q.lockMR(id)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
 	path := filepath.Join(q.dir, id+".json")
 
 	// Read current state
@@ -268,28 +322,46 @@ func (q *Queue) Claim(id, workerID string) error {
 	mr.ClaimedBy = workerID
 	mr.ClaimedAt = &now
 
-	// Write atomically
-	data, err := json.MarshalIndent(mr, "", "  ")
+	return q.save(path, mr)
+}
+
+// Renew extends a worker's claim on an MR so it doesn't go stale while the
+// worker is still processing it. Returns ErrClaimLost if the MR is no
+// longer claimed by workerID (e.g. the claim went stale and another worker
+// took it over), or ErrNotFound if the MR is gone.
+func (q *Queue) Renew(id, workerID string) error {
+	fileLock, err := q.lockMR(id)
 	if err != nil {
-		return fmt.Errorf("marshaling MR: %w", err)
+		return err
 	}
+	defer func() { _ = fileLock.Unlock() }()
 
-	// Write to temp file first, then rename (atomic on most filesystems)
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("writing temp file: %w", err)
+	path := filepath.Join(q.dir, id+".json")
+	mr, err := q.load(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("loading MR: %w", err)
 	}
-	if err := os.Rename(tmpPath, path); err != nil {
-		_ = os.Remove(tmpPath) // cleanup
-		return fmt.Errorf("renaming temp file: %w", err)
+	if mr.ClaimedBy != workerID {
+		return ErrClaimLost
 	}
 
-	return nil
+	now := time.Now()
+	mr.ClaimedAt = &now
+	return q.save(path, mr)
 }
 
 // Release releases a claimed MR back to the queue.
 // Called when processing fails and the MR should be retried.
 func (q *Queue) Release(id string) error {
+	fileLock, err := q.lockMR(id)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
 	path := filepath.Join(q.dir, id+".json")
 
 	mr, err := q.load(path)
@@ -304,12 +376,7 @@ func (q *Queue) Release(id string) error {
 	mr.ClaimedBy = ""
 	mr.ClaimedAt = nil
 
-	data, err := json.MarshalIndent(mr, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling MR: %w", err)
-	}
-
-	return os.WriteFile(path, data, 0644)
+	return q.save(path, mr)
 }
 
 // ListUnclaimed returns MRs that are not claimed or have stale claims.
@@ -322,12 +389,7 @@ func (q *Queue) ListUnclaimed() ([]*MR, error) {
 
 	var unclaimed []*MR
 	for _, mr := range all {
-		if mr.ClaimedBy == "" {
-			unclaimed = append(unclaimed, mr)
-			continue
-		}
-		// Check if claim is stale
-		if mr.ClaimedAt != nil && time.Since(*mr.ClaimedAt) >= ClaimStaleTimeout {
+		if !mr.IsClaimed() {
 			unclaimed = append(unclaimed, mr)
 		}
 	}
@@ -335,6 +397,15 @@ func (q *Queue) ListUnclaimed() ([]*MR, error) {
 	return unclaimed, nil
 }
 
+// IsClaimed reports whether the MR has a live claim. Stale claims (older
+// than ClaimStaleTimeout) don't count: another worker may take them over.
+func (mr *MR) IsClaimed() bool {
+	if mr.ClaimedBy == "" {
+		return false
+	}
+	return mr.ClaimedAt == nil || time.Since(*mr.ClaimedAt) < ClaimStaleTimeout
+}
+
 // ListClaimedBy returns MRs claimed by a specific worker.
 func (q *Queue) ListClaimedBy(workerID string) ([]*MR, error) {
 	all, err := q.List()
@@ -356,13 +427,41 @@ func (q *Queue) ListClaimedBy(workerID string) ([]*MR, error) {
 var (
 	ErrNotFound       = fmt.Errorf("merge request not found")
 	ErrAlreadyClaimed = fmt.Errorf("merge request already claimed by another worker")
+	ErrClaimLost      = fmt.Errorf("merge request no longer claimed by this worker")
 )
 
 // SetBlockedBy marks an MR as blocked by a task (e.g., conflict resolution).
 // When the blocking task closes, the MR becomes ready for processing again.
 func (q *Queue) SetBlockedBy(mrID, taskID string) error {
-	path := filepath.Join(q.dir, mrID+".json")
+	return q.modify(mrID, func(mr *MR) { mr.BlockedBy = taskID })
+}
+
+// SetTarget changes the branch an MR merges into.
+func (q *Queue) SetTarget(mrID, target string) error {
+	return q.modify(mrID, func(mr *MR) { mr.Target = target })
+}
+
+// SetBranch points an MR at a renamed branch. If worker is non-empty, the
+// MR's worker is updated too.
+func (q *Queue) SetBranch(mrID, branch, worker string) error {
+	return q.modify(mrID, func(mr *MR) {
+		mr.Branch = branch
+		if worker != "" {
+			mr.Worker = worker
+		}
+	})
+}
 
+// modify applies fn to an MR under its lock and saves the result, so it
+// can't lose a concurrent Claim or Renew of the same MR.
+func (q *Queue) modify(mrID string, fn func(mr *MR)) error {
+	fileLock, err := q.lockMR(mrID)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	path := filepath.Join(q.dir, mrID+".json")
 	mr, err := q.load(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -371,14 +470,8 @@ func (q *Queue) SetBlockedBy(mrID, taskID string) error {
 		return fmt.Errorf("loading MR: %w", err)
 	}
 
-	mr.BlockedBy = taskID
-
-	data, err := json.MarshalIndent(mr, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshaling MR: %w", err)
-	}
-
-	return os.WriteFile(path, data, 0644)
+	fn(mr)
+	return q.save(path, mr)
 }
 
 // ClearBlockedBy removes the blocking task from an MR.
@@ -0,0 +1,169 @@
+package mrqueue
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_SetTarget(t *testing.T) {
+	q := New(t.TempDir())
+	mr := &MR{Branch: "polecat/Toast/gt-abc", Target: "main"}
+	if err := q.Submit(mr); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	if err := q.SetTarget(mr.ID, "integration/gt-epic"); err != nil {
+		t.Fatalf("SetTarget: %v", err)
+	}
+	got, err := q.Get(mr.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Target != "integration/gt-epic" || got.Branch != mr.Branch {
+		t.Errorf("after SetTarget: target %q branch %q, want integration/gt-epic and the branch unchanged", got.Target, got.Branch)
+	}
+
+	if err := q.SetTarget("mr-missing", "main"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetTarget(missing) = %v, want ErrNotFound", err)
+	}
+}
+
+// TestQueue_ClaimRace simulates several refineries sharing one queue and
+// claiming the same MR at once: exactly one may win.
+func TestQueue_ClaimRace(t *testing.T) {
+	dir := t.TempDir()
+	mr := &MR{Branch: "polecat/Toast/gt-abc", Target: "main"}
+	if err := New(dir).Submit(mr); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			// Each worker has its own Queue, as separate processes would
+			errs[i] = New(dir).Claim(mr.ID, fmt.Sprintf("refinery-%d", i))
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			if winner >= 0 {
+				t.Fatalf("refinery-%d and refinery-%d both claimed %s", winner, i, mr.ID)
+			}
+			winner = i
+		case !errors.Is(err, ErrAlreadyClaimed):
+			t.Errorf("refinery-%d: Claim = %v, want nil or ErrAlreadyClaimed", i, err)
+		}
+	}
+	if winner < 0 {
+		t.Fatal("no refinery claimed the MR")
+	}
+
+	got, err := New(dir).Get(mr.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := fmt.Sprintf("refinery-%d", winner); got.ClaimedBy != want {
+		t.Errorf("ClaimedBy = %q, want %q", got.ClaimedBy, want)
+	}
+}
+
+// TestQueue_SetBlockedByRace updates an MR from several queues at once
+// while a worker claims it: every write must land, none may be lost to a
+// read-modify-write race.
+func TestQueue_SetBlockedByRace(t *testing.T) {
+	dir := t.TempDir()
+	for round := 0; round < 20; round++ {
+		mr := &MR{Branch: "polecat/Toast/gt-abc", Target: "main"}
+		if err := New(dir).Submit(mr); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 3)
+		start := make(chan struct{})
+		for i, op := range []func(q *Queue) error{
+			func(q *Queue) error { return q.Claim(mr.ID, "refinery") },
+			func(q *Queue) error { return q.SetBlockedBy(mr.ID, "gt-task") },
+			func(q *Queue) error { return q.SetTarget(mr.ID, "integration/gt-epic") },
+		} {
+			wg.Add(1)
+			go func(i int, op func(q *Queue) error) {
+				defer wg.Done()
+				<-start
+				errs[i] = op(New(dir))
+			}(i, op)
+		}
+		close(start)
+		wg.Wait()
+		for i, err := range errs {
+			if err != nil {
+				t.Fatalf("round %d: op %d: %v", round, i, err)
+			}
+		}
+
+		got, err := New(dir).Get(mr.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.ClaimedBy != "refinery" || got.BlockedBy != "gt-task" || got.Target != "integration/gt-epic" {
+			t.Fatalf("round %d: claimed %q blocked %q target %q, want every update kept", round, got.ClaimedBy, got.BlockedBy, got.Target)
+		}
+	}
+}
+
+func TestQueue_Renew(t *testing.T) {
+	q := New(t.TempDir())
+	mr := &MR{Branch: "polecat/Toast/gt-abc", Target: "main"}
+	if err := q.Submit(mr); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := q.Claim(mr.ID, "refinery-a"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	// Age the claim to just short of stale, then renew it
+	claimed, err := q.Get(mr.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	old := time.Now().Add(-ClaimStaleTimeout + time.Minute)
+	claimed.ClaimedAt = &old
+	if err := q.save(filepath.Join(q.dir, mr.ID+".json"), claimed); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := q.Renew(mr.ID, "refinery-a"); err != nil {
+		t.Fatalf("Renew: %v", err)
+	}
+	got, err := q.Get(mr.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ClaimedAt == nil || time.Since(*got.ClaimedAt) > time.Minute {
+		t.Errorf("ClaimedAt = %v after Renew, want about now", got.ClaimedAt)
+	}
+
+	if err := q.Renew(mr.ID, "refinery-b"); !errors.Is(err, ErrClaimLost) {
+		t.Errorf("Renew by another worker = %v, want ErrClaimLost", err)
+	}
+	if err := q.Claim(mr.ID, "refinery-b"); !errors.Is(err, ErrAlreadyClaimed) {
+		t.Errorf("Claim of a renewed MR = %v, want ErrAlreadyClaimed", err)
+	}
+	if err := q.Renew("mr-missing", "refinery-a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Renew(missing) = %v, want ErrNotFound", err)
+	}
+}
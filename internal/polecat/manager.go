@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -23,6 +24,7 @@ var (
 	ErrPolecatNotFound   = errors.New("polecat not found")
 	ErrHasChanges        = errors.New("polecat has uncommitted changes")
 	ErrHasUncommittedWork = errors.New("polecat has uncommitted work")
+	ErrInvalidName       = errors.New("invalid polecat name")
 )
 
 // UncommittedWorkError provides details about uncommitted work.
@@ -40,6 +42,12 @@ func (e *UncommittedWorkError) Unwrap() error {
 }
 
 // Manager handles polecat lifecycle.
+//
+// A Manager is safe for concurrent use (e.g., provisioning several polecats in
+// parallel). Operations that change the shared repo's worktrees are
+// serialized per rig, across all Managers in the process: concurrent
+// `git worktree add/remove` calls contend for the repo's shared config and
+// ref locks and fail spuriously.
 type Manager struct {
 	rig      *rig.Rig
 	git      *git.Git
@@ -165,22 +173,36 @@ func (m *Manager) checkCleanupStatus(name string, status CleanupStatus, force bo
 func (m *Manager) repoBase() (*git.Git, error) {
 	// First check for shared bare repo (new architecture)
 	bareRepoPath := filepath.Join(m.rig.Path, ".repo.git")
+	var g *git.Git
 	if info, err := os.Stat(bareRepoPath); err == nil && info.IsDir() {
 		// Bare repo exists - use it
-		return git.NewGitWithDir(bareRepoPath, ""), nil
+		g = git.NewGitWithDir(bareRepoPath, "")
+	} else {
+		// Fall back to mayor/rig (legacy architecture)
+		mayorPath := filepath.Join(m.rig.Path, "mayor", "rig")
+		if _, err := os.Stat(mayorPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("no repo base found (neither .repo.git nor mayor/rig exists)")
+		}
+		g = git.NewGit(mayorPath)
 	}
+	g.SetEnv(m.rig.GitEnv())
+	return g, nil
+}
 
-	// Fall back to mayor/rig (legacy architecture)
-	mayorPath := filepath.Join(m.rig.Path, "mayor", "rig")
-	if _, err := os.Stat(mayorPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no repo base found (neither .repo.git nor mayor/rig exists)")
-	}
-	return git.NewGit(mayorPath), nil
+// repoLocks holds one mutex per rig path, serializing worktree changes.
+var repoLocks sync.Map // rig path -> *sync.Mutex
+
+// lockRepo locks the rig's repo for worktree changes and returns the unlock
+// function.
+func (m *Manager) lockRepo() func() {
+	mu, _ := repoLocks.LoadOrStore(m.rig.Path, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
 }
 
 // polecatDir returns the directory for a polecat.
 func (m *Manager) polecatDir(name string) string {
-	return filepath.Join(m.rig.Path, "polecats", name)
+	return filepath.Join(m.rig.PolecatsDir(), name)
 }
 
 // exists checks if a polecat exists.
@@ -189,9 +211,25 @@ func (m *Manager) exists(name string) bool {
 	return err == nil
 }
 
+// validateName checks that a polecat name is usable as a directory name and
+// in the polecat's branch name (polecat/<name>-<suffix>).
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	if strings.ContainsAny(name, "/\\") {
+		return fmt.Errorf("%w: %q contains path separators", ErrInvalidName, name)
+	}
+	if err := git.ValidateBranchName("polecat/" + name + "-x"); err != nil {
+		return fmt.Errorf("%w: %q cannot be used in a branch name: %v", ErrInvalidName, name, err)
+	}
+	return nil
+}
+
 // AddOptions configures polecat creation.
 type AddOptions struct {
 	HookBead string // Bead ID to set as hook_bead at spawn time (atomic assignment)
+	Force    bool   // Clear a stale worktree lock left by an interrupted add
 }
 
 // Add creates a new polecat as a git worktree from the repo base.
@@ -210,32 +248,38 @@ func (m *Manager) Add(name string) (*Polecat, error) {
 // This allows setting hook_bead atomically at creation time, avoiding
 // cross-beads routing issues when slinging work to new polecats.
 func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error) {
-	if m.exists(name) {
-		return nil, ErrPolecatExists
+	if err := validateName(name); err != nil {
+		return nil, err
 	}
 
-	polecatPath := m.polecatDir(name)
 	// Unique branch per run - prevents drift from stale branches
 	// Use base36 encoding for shorter branch names (8 chars vs 13 digits)
 	branchName := fmt.Sprintf("polecat/%s-%s", name, strconv.FormatInt(time.Now().UnixMilli(), 36))
 
-	// Create polecats directory if needed
-	polecatsDir := filepath.Join(m.rig.Path, "polecats")
-	if err := os.MkdirAll(polecatsDir, 0755); err != nil {
-		return nil, fmt.Errorf("creating polecats dir: %w", err)
+	if err := m.createWorktree(name, branchName, false, opts.Force); err != nil {
+		return nil, err
 	}
+	return m.finishAdd(name, branchName, opts), nil
+}
 
-	// Get the repo base (bare repo or mayor/rig)
-	repoGit, err := m.repoBase()
-	if err != nil {
-		return nil, fmt.Errorf("finding repo base: %w", err)
+// AddFromBranch creates a polecat whose worktree is on an existing branch
+// instead of a fresh one: a branch pushed from outside Gas Town, or the
+// branch of an MR whose worker was removed. A branch that only exists on
+// origin is fetched and tracked.
+func (m *Manager) AddFromBranch(name, branch string) (*Polecat, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
 	}
-
-	// Always create fresh branch - unique name guarantees no collision
-	// git worktree add -b polecat/<name>-<timestamp> <path>
-	if err := repoGit.WorktreeAdd(polecatPath, branchName); err != nil {
-		return nil, fmt.Errorf("creating worktree: %w", err)
+	if err := m.createWorktree(name, branch, true, false); err != nil {
+		return nil, err
 	}
+	return m.finishAdd(name, branch, AddOptions{}), nil
+}
+
+// finishAdd sets up shared beads and the agent bead for a polecat whose
+// worktree has just been created, and returns it.
+func (m *Manager) finishAdd(name, branchName string, opts AddOptions) *Polecat {
+	polecatPath := m.polecatDir(name)
 
 	// NOTE: We intentionally do NOT write to CLAUDE.md here.
 	// Gas Town context is injected ephemerally via SessionStart hook (gt prime).
@@ -257,7 +301,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 	// State starts as "spawning" - will be updated to "working" when Claude starts.
 	// HookBead is set atomically at creation time if provided (avoids cross-beads routing issues).
 	agentID := m.agentBeadID(name)
-	_, err = m.beads.CreateAgentBead(agentID, agentID, &beads.AgentFields{
+	_, err := m.beads.CreateAgentBead(agentID, agentID, &beads.AgentFields{
 		RoleType:   "polecat",
 		Rig:        m.rig.Name,
 		AgentState: "spawning",
@@ -272,7 +316,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 	// Return polecat with working state (transient model: polecats are spawned with work)
 	// State is derived from beads, not stored in state.json
 	now := time.Now()
-	polecat := &Polecat{
+	return &Polecat{
 		Name:      name,
 		Rig:       m.rig.Name,
 		State:     StateWorking, // Transient model: polecat spawns with work
@@ -281,8 +325,84 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
+}
+
+// createWorktree creates the polecat's worktree, on a fresh branch or, with
+// existing, on a branch that already exists. The existence check and
+// creation happen under the repo lock, so concurrent Adds of the same name
+// get ErrPolecatExists rather than a git error.
+//
+// A stale lock on the worktree path, left by an interrupted add, fails with
+// git.ErrWorktreeLocked before anything is created; with force it is
+// cleared and the add goes ahead.
+func (m *Manager) createWorktree(name, branchName string, existing, force bool) error {
+	defer m.lockRepo()()
+
+	if m.exists(name) {
+		return ErrPolecatExists
+	}
+
+	// Checked before creating anything: a bad hooks_dir fails the add
+	hooksDir, err := m.rig.HooksDir()
+	if err != nil {
+		return err
+	}
+
+	// Create polecats directory if needed
+	if err := os.MkdirAll(m.rig.PolecatsDir(), 0755); err != nil {
+		return fmt.Errorf("creating polecats dir: %w", err)
+	}
+
+	// Get the repo base (bare repo or mayor/rig)
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return fmt.Errorf("finding repo base: %w", err)
+	}
+
+	// Checked up front: a failed 'worktree add -b' still creates the branch
+	path := m.polecatDir(name)
+	if stale, err := repoGit.StaleWorktreeLock(path); err == nil && stale {
+		if !force {
+			return fmt.Errorf("%w: %s was left locked by an interrupted worktree add; "+
+				"retry with --force to clear it, or run 'git worktree unlock %s' and 'git worktree prune'",
+				git.ErrWorktreeLocked, path, path)
+		}
+		if err := repoGit.ClearStaleWorktreeLock(path); err != nil {
+			return fmt.Errorf("clearing stale worktree lock: %w", err)
+		}
+	}
+
+	if existing {
+		if err := repoGit.WorkerCreateFromBranch(path, branchName); err != nil {
+			return fmt.Errorf("creating worktree from %s: %w", branchName, err)
+		}
+	} else {
+		// Fresh branch - unique name guarantees no collision
+		// git worktree add -b polecat/<name>-<timestamp> <path>
+		if err := repoGit.WorktreeAdd(path, branchName); err != nil {
+			return fmt.Errorf("creating worktree: %w", err)
+		}
+	}
 
-	return polecat, nil
+	if err := applyHooksDir(path, hooksDir); err != nil {
+		_ = repoGit.WorktreeRemove(path, true)
+		return err
+	}
+	return nil
+}
+
+// applyHooksDir points a new worktree's git hooks at the rig's shared
+// hooks_dir, if one is configured, so policy hooks run for every worker.
+// Call with the repo lock held: the first call changes the shared repo
+// config (see git.SetWorktreeHooksPath).
+func applyHooksDir(path, hooksDir string) error {
+	if hooksDir == "" {
+		return nil
+	}
+	if err := git.NewGit(path).SetWorktreeHooksPath(hooksDir); err != nil {
+		return fmt.Errorf("setting hooks_dir: %w", err)
+	}
+	return nil
 }
 
 // Remove deletes a polecat worktree.
@@ -342,16 +462,19 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear bool) error {
 	}
 
 	// Try to remove as a worktree first (use force flag for worktree removal too)
+	unlock := m.lockRepo()
 	if err := repoGit.WorktreeRemove(polecatPath, force); err != nil {
 		// Fall back to direct removal if worktree removal fails
 		// (e.g., if this is an old-style clone, not a worktree)
 		if removeErr := os.RemoveAll(polecatPath); removeErr != nil {
+			unlock()
 			return fmt.Errorf("removing polecat dir: %w", removeErr)
 		}
 	}
 
 	// Prune any stale worktree entries (non-fatal: cleanup only)
 	_ = repoGit.WorktreePrune()
+	unlock()
 
 	// Release name back to pool if it's a pooled name (non-fatal: state file update)
 	m.namePool.Release(name)
@@ -369,6 +492,40 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear bool) error {
 	return nil
 }
 
+// Rename moves a polecat's worktree to newName and renames its branch to
+// match (polecat/<old>-<suffix> becomes polecat/<new>-<suffix>), through
+// git so the worktree metadata follows. Returns the branch before and after;
+// they are equal if the branch didn't belong to the old name. Open MRs for
+// the old branch are the caller's to update (refinery RenameMRBranch).
+func (m *Manager) Rename(oldName, newName string) (oldBranch, newBranch string, err error) {
+	if err := validateName(newName); err != nil {
+		return "", "", err
+	}
+	if !m.exists(oldName) {
+		return "", "", ErrPolecatNotFound
+	}
+	if m.exists(newName) {
+		return "", "", ErrPolecatExists
+	}
+
+	oldPath, newPath := m.polecatDir(oldName), m.polecatDir(newName)
+	oldBranch, err = git.NewGit(oldPath).CurrentBranch()
+	if err != nil {
+		return "", "", fmt.Errorf("reading polecat branch: %w", err)
+	}
+
+	repoGit, err := m.repoBase()
+	if err != nil {
+		return "", "", err
+	}
+	unlock := m.lockRepo()
+	defer unlock()
+	if err := repoGit.WorkerRename(oldPath, newPath); err != nil {
+		return "", "", err
+	}
+	return oldBranch, git.RenamedWorkerBranch(oldBranch, oldName, newName), nil
+}
+
 // AllocateName allocates a name from the name pool.
 // Returns a pooled name (polecat-01 through polecat-50) if available,
 // otherwise returns an overflow name (rigname-N).
@@ -426,6 +583,12 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		return nil, fmt.Errorf("finding repo base: %w", err)
 	}
 
+	// Checked before removing anything: a bad hooks_dir fails the repair
+	hooksDir, err := m.rig.HooksDir()
+	if err != nil {
+		return nil, err
+	}
+
 	// Check for uncommitted work unless forced
 	if !force {
 		status, err := polecatGit.CheckUncommittedWork()
@@ -442,6 +605,9 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		}
 	}
 
+	// Remove and recreate the worktree under the repo lock
+	defer m.lockRepo()()
+
 	// Remove the worktree (use force for git worktree removal)
 	if err := repoGit.WorktreeRemove(polecatPath, true); err != nil {
 		// Fall back to direct removal
@@ -472,6 +638,9 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	if err := repoGit.WorktreeAddFromRef(polecatPath, branchName, startPoint); err != nil {
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
+	if err := applyHooksDir(polecatPath, hooksDir); err != nil {
+		return nil, err
+	}
 
 	// NOTE: We intentionally do NOT write to CLAUDE.md here.
 	// Gas Town context is injected ephemerally via SessionStart hook (gt prime).
@@ -533,7 +702,7 @@ func (m *Manager) PoolStatus() (active int, names []string) {
 
 // List returns all polecats in the rig.
 func (m *Manager) List() ([]*Polecat, error) {
-	polecatsDir := filepath.Join(m.rig.Path, "polecats")
+	polecatsDir := m.rig.PolecatsDir()
 
 	entries, err := os.ReadDir(polecatsDir)
 	if err != nil {
@@ -724,9 +893,10 @@ func (m *Manager) loadFromBeads(name string) (*Polecat, error) {
 
 // setupSharedBeads creates a redirect file so the polecat uses the rig's shared .beads database.
 // This eliminates the need for git sync between polecat clones - all polecats share one database.
+// The polecat may live outside the rig (worker_root), so the redirect is
+// computed from the rig path rather than the town layout.
 func (m *Manager) setupSharedBeads(polecatPath string) error {
-	townRoot := filepath.Dir(m.rig.Path)
-	return beads.SetupRedirect(townRoot, polecatPath)
+	return beads.SetupRigRedirect(m.rig.Path, polecatPath)
 }
 
 // CleanupStaleBranches removes orphaned polecat branches that are no longer in use.
@@ -739,6 +909,7 @@ func (m *Manager) CleanupStaleBranches() (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("finding repo base: %w", err)
 	}
+	defer m.lockRepo()()
 
 	// List all polecat branches
 	branches, err := repoGit.ListBranches("polecat/*")
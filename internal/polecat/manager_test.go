@@ -1,9 +1,12 @@
 package polecat
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/steveyegge/gastown/internal/git"
@@ -125,6 +128,32 @@ func TestPolecatDir(t *testing.T) {
 	}
 }
 
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Toast", false},
+		{"Tōast", false},  // unicode is fine in refs
+		{"Nux.v2", false}, // a dot inside is fine
+		{"Max Rockatansky", true},
+		{".hidden", true}, // component starting with '.'
+		{"nux..2", true},  // '..'
+		{"nux/2", true},   // path separator
+		{"nux~1", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := validateName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateName(%q) = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidName) {
+			t.Errorf("validateName(%q) error %v does not wrap ErrInvalidName", tt.name, err)
+		}
+	}
+}
+
 func TestAssigneeID(t *testing.T) {
 	r := &rig.Rig{
 		Name: "test-rig",
@@ -279,3 +308,217 @@ func TestClearIssueWithoutAssignment(t *testing.T) {
 // We no longer write CLAUDE.md to worktrees - Gas Town context is injected
 // ephemerally via SessionStart hook (gt prime) to prevent leaking internal
 // architecture into project repos.
+
+// TestAddConcurrent provisions several polecats in parallel on one repo.
+// Run with -race to check the Manager's shared state.
+func TestAddConcurrent(t *testing.T) {
+	root := t.TempDir()
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = mayorRig
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	m := NewManager(&rig.Rig{Name: "test-rig", Path: root}, git.NewGit(root))
+
+	names := []string{"Toast", "Nux", "Furiosa", "Slit", "Capable", "Ace"}
+	errs := make(chan error, len(names)*2)
+	var wg sync.WaitGroup
+	for _, name := range names {
+		// Two Adds per name: exactly one should win
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				_, err := m.Add(name)
+				errs <- err
+			}(name)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	created, exists := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, ErrPolecatExists):
+			exists++
+		default:
+			t.Errorf("Add: %v", err)
+		}
+	}
+	if created != len(names) || exists != len(names) {
+		t.Errorf("created=%d exists=%d, want %d each", created, exists, len(names))
+	}
+
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(root, "polecats", name, ".git")); err != nil {
+			t.Errorf("worktree for %s missing: %v", name, err)
+		}
+	}
+}
+
+func TestAddFromBranch(t *testing.T) {
+	root := t.TempDir()
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial"},
+		{"branch", "polecat/Toast-abc123"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = mayorRig
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	m := NewManager(&rig.Rig{Name: "test-rig", Path: root}, git.NewGit(root))
+
+	p, err := m.AddFromBranch("Toast", "polecat/Toast-abc123")
+	if err != nil {
+		t.Fatalf("AddFromBranch: %v", err)
+	}
+	if p.Branch != "polecat/Toast-abc123" {
+		t.Errorf("Branch = %q, want the existing branch", p.Branch)
+	}
+	if branch, _ := git.NewGit(p.ClonePath).CurrentBranch(); branch != "polecat/Toast-abc123" {
+		t.Errorf("worktree branch = %q, want polecat/Toast-abc123", branch)
+	}
+
+	if _, err := m.AddFromBranch("Toast", "polecat/Toast-abc123"); !errors.Is(err, ErrPolecatExists) {
+		t.Errorf("second AddFromBranch = %v, want ErrPolecatExists", err)
+	}
+	if _, err := m.AddFromBranch("Nux", "polecat/Nux-missing"); !errors.Is(err, git.ErrBranchNotFound) {
+		t.Errorf("AddFromBranch(missing branch) = %v, want git.ErrBranchNotFound", err)
+	}
+}
+
+func TestAdd_StaleWorktreeLock(t *testing.T) {
+	root := t.TempDir()
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = mayorRig
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	m := NewManager(&rig.Rig{Name: "test-rig", Path: root}, git.NewGit(root))
+	p, err := m.Add("Toast")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// An interrupted add: registered and locked, but gone from disk
+	cmd := exec.Command("git", "worktree", "lock", "--reason", "initializing", p.ClonePath)
+	cmd.Dir = mayorRig
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree lock: %v\n%s", err, out)
+	}
+	if err := os.RemoveAll(p.ClonePath); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Add("Toast")
+	if !errors.Is(err, git.ErrWorktreeLocked) {
+		t.Fatalf("Add over a stale lock = %v, want git.ErrWorktreeLocked", err)
+	}
+	if !strings.Contains(err.Error(), "--force") {
+		t.Errorf("error %q should point at --force", err)
+	}
+
+	p, err = m.AddWithOptions("Toast", AddOptions{Force: true})
+	if err != nil {
+		t.Fatalf("AddWithOptions(Force): %v", err)
+	}
+	if _, err := os.Stat(p.ClonePath); err != nil {
+		t.Errorf("worktree not recreated: %v", err)
+	}
+}
+
+func TestAdd_HooksDir(t *testing.T) {
+	root := t.TempDir()
+	mayorRig := filepath.Join(root, "mayor", "rig")
+	if err := os.MkdirAll(filepath.Join(root, "hooks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(mayorRig, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = mayorRig
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeConfig := func(hooksDir string) {
+		t.Helper()
+		cfg := `{"type":"rig","version":1,"name":"test-rig","hooks_dir":"` + hooksDir + `"}`
+		if err := os.WriteFile(filepath.Join(root, "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hooksPath := func(dir string) string {
+		cmd := exec.Command("git", "config", "core.hooksPath")
+		cmd.Dir = dir
+		out, _ := cmd.Output()
+		return strings.TrimSpace(string(out))
+	}
+
+	m := NewManager(&rig.Rig{Name: "test-rig", Path: root}, git.NewGit(root))
+
+	// Relative to the rig; applied to the worker only, not the mayor clone
+	writeConfig("hooks")
+	p, err := m.Add("Toast")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if got, want := hooksPath(p.ClonePath), filepath.Join(root, "hooks"); got != want {
+		t.Errorf("worker core.hooksPath = %q, want %q", got, want)
+	}
+	if got := hooksPath(mayorRig); got != "" {
+		t.Errorf("mayor clone core.hooksPath = %q, want unset", got)
+	}
+
+	// A missing hooks_dir fails the add before a worktree is created
+	writeConfig("no-such-hooks")
+	if _, err := m.Add("Nux"); !errors.Is(err, rig.ErrHooksDir) {
+		t.Errorf("Add with missing hooks_dir = %v, want rig.ErrHooksDir", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "polecats", "Nux")); !os.IsNotExist(err) {
+		t.Errorf("worktree created despite bad hooks_dir: %v", err)
+	}
+}
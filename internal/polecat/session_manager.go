@@ -98,7 +98,7 @@ func (m *SessionManager) SessionName(polecat string) string {
 
 // polecatDir returns the working directory for a polecat.
 func (m *SessionManager) polecatDir(polecat string) string {
-	return filepath.Join(m.rig.Path, "polecats", polecat)
+	return filepath.Join(m.rig.PolecatsDir(), polecat)
 }
 
 // hasPolecat checks if the polecat exists in this rig.
@@ -136,7 +136,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 
 	// Ensure Claude settings exist in polecats/ (not polecats/<name>/) so we don't
 	// write into the source repo. Claude walks up the tree to find settings.
-	polecatsDir := filepath.Join(m.rig.Path, "polecats")
+	polecatsDir := m.rig.PolecatsDir()
 	if err := claude.EnsureSettingsForRole(polecatsDir, "polecat"); err != nil {
 		return fmt.Errorf("ensuring Claude settings: %w", err)
 	}
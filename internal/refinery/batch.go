@@ -0,0 +1,148 @@
+package refinery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// nextBatch picks the MRs to merge together from ready (in score order):
+// the first one plus up to size-1 more with the same target. With size
+// below 2 the batch is just the first MR.
+func nextBatch(ready []*mrqueue.MR, size int) []*mrqueue.MR {
+	if len(ready) == 0 {
+		return nil
+	}
+	batch := []*mrqueue.MR{ready[0]}
+	for _, mr := range ready[1:] {
+		if len(batch) >= size {
+			break
+		}
+		if mr.Target == ready[0].Target {
+			batch = append(batch, mr)
+		}
+	}
+	return batch
+}
+
+// mergeBatch merges every MR in batch into their shared target and pushes
+// once, running the tests once on the combined result. Each MR still gets
+// its own merge commit. If any merge fails or conflicts, or the tests or
+// push fail, the target is reset and ok is false: the caller falls back to
+// merging the MRs one at a time, which pins the failure on the right MR.
+func (e *Engineer) mergeBatch(ctx context.Context, batch []*mrqueue.MR) (results []ProcessResult, ok bool) {
+	target := batch[0].Target
+	ids := make([]string, len(batch))
+	for i, mr := range batch {
+		ids[i] = mr.ID
+	}
+	if err := e.rig.CheckBranchUnprotected(target); err != nil {
+		return nil, false // reported by the individual merges
+	}
+	if err := e.CheckMergeWindow(); err != nil {
+		return nil, false // reported by the individual merges
+	}
+
+	// A panic fails the batch, not the cycle: the MRs are then merged one
+	// at a time, which isolates the bad one
+	var ws *mergeWorkspace
+	var base string
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Panic while batch merging %s: %v\n%s", strings.Join(ids, ", "), r, debug.Stack())
+			if ws != nil {
+				_ = ws.plain.AbortMerge()
+				if base != "" {
+					_ = ws.plain.ResetHard(base)
+				}
+			}
+			results, ok = nil, false
+		}
+	}()
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Batch merging %d MRs into %s: %s\n", len(batch), target, strings.Join(ids, ", "))
+	ws, err := e.openMergeWorkspace(ctx, target, "batch", nil)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Batch abandoned: %v\n", err)
+		return nil, false
+	}
+	defer ws.close()
+	g := ws.git
+	base, err = g.Rev("HEAD")
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Batch abandoned: %v\n", err)
+		return nil, false
+	}
+
+	abandon := func(reason string) ([]ProcessResult, bool) {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Batch abandoned: %s; merging individually\n", reason)
+		// Not ctx-bound: the target must be restored even after cancellation
+		if err := ws.plain.ResetHard(base); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reset %s: %v\n", target, err)
+		}
+		return nil, false
+	}
+
+	results = make([]ProcessResult, len(batch))
+	for i, mr := range batch {
+		exists, err := g.BranchExists(mr.Branch)
+		if err != nil || !exists {
+			return abandon(fmt.Sprintf("branch %s not found locally", mr.Branch))
+		}
+		// Verify and merge the head as of now, as mergeAttempt does
+		head, err := g.Rev(mr.Branch)
+		if err != nil {
+			return abandon(fmt.Sprintf("failed to get branch SHA: %v", err))
+		}
+		if check := e.verifySignature(g, head); check != nil && !check.Trusted {
+			return abandon(fmt.Sprintf("%s: %s", mr.ID, check.Detail)) // failed individually
+		}
+		if merged, err := g.IsAncestor(head, "HEAD"); err == nil && merged {
+			results[i] = ProcessResult{Success: true, AlreadyMerged: true, MergeCommit: head}
+			continue
+		}
+
+		msg := RenderMergeMessage(e.config.MergeMessageTemplate, MergeMessageData{
+			MRID:        mr.ID,
+			SourceIssue: mr.SourceIssue,
+			Worker:      mr.Worker,
+			Branch:      mr.Branch,
+			Target:      mr.Target,
+		})
+		if err := g.MergeNoFF(head, msg); err != nil {
+			if errors.Is(err, git.ErrMergeConflict) {
+				_ = ws.plain.AbortMerge()
+				return abandon(fmt.Sprintf("%s conflicts with the batch", mr.ID))
+			}
+			return abandon(fmt.Sprintf("merging %s: %v", mr.ID, err))
+		}
+		commit, err := g.Rev("HEAD")
+		if err != nil {
+			return abandon(fmt.Sprintf("failed to get merge commit SHA: %v", err))
+		}
+		results[i] = ProcessResult{Success: true, MergeCommit: commit}
+	}
+
+	if e.config.RunTests && e.config.TestCommand != "" {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests on batch: %s\n", e.config.TestCommand)
+		if result := e.runTests(ctx, ws.dir, io.Discard); !result.Success {
+			return abandon("tests failed on the batch")
+		}
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing batch to origin/%s...\n", target)
+	if err := e.pushTarget(ctx, g, ws.push); err != nil {
+		return abandon(fmt.Sprintf("push failed: %v", err))
+	}
+
+	for i := range results {
+		results[i].Batch = ids
+	}
+	return results, true
+}
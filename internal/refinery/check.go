@@ -0,0 +1,63 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// MergeCheck is the outcome of a trial merge by CheckMerge.
+type MergeCheck struct {
+	Branch        string   `json:"branch"`
+	Target        string   `json:"target"`
+	TargetHead    string   `json:"target_head"` // the target commit the merge was tried against
+	Mergeable     bool     `json:"mergeable"`
+	AlreadyMerged bool     `json:"already_merged,omitempty"`
+	Conflicts     []string `json:"conflicts,omitempty"`
+}
+
+// CheckMerge tries merging branch into origin's tip of target in a
+// throwaway worktree, as the refinery would with isolate_merges, and
+// discards it. Neither the rig's checkout nor any branch is changed, so it
+// needs no processing lock. If the merge would conflict, the check is
+// returned with a *git.MergeConflictError listing the files. Git output
+// goes to log.
+func (e *Engineer) CheckMerge(ctx context.Context, branch, target string, log io.Writer) (*MergeCheck, error) {
+	check := &MergeCheck{Branch: branch, Target: target}
+
+	exists, err := e.branchRepo().WithContext(ctx).WithTranscript(log).BranchExists(branch)
+	if err != nil {
+		return nil, fmt.Errorf("checking branch %s: %w", branch, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", git.ErrBranchNotFound, branch)
+	}
+
+	ws, err := e.openMergeWorktree(ctx, target, "check", log)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.close()
+	g := ws.git
+
+	if check.TargetHead, err = g.Rev("HEAD"); err != nil {
+		return nil, fmt.Errorf("reading target head: %w", err)
+	}
+	if merged, err := g.IsAncestor(branch, "HEAD"); err == nil && merged {
+		check.Mergeable, check.AlreadyMerged = true, true
+		return check, nil
+	}
+
+	conflicts, err := g.CheckConflicts(branch, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("trial merge: %w", err)
+	}
+	if len(conflicts) > 0 {
+		check.Conflicts = conflicts
+		return check, &git.MergeConflictError{Files: conflicts}
+	}
+	check.Mergeable = true
+	return check, nil
+}
@@ -11,10 +11,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mrqueue"
@@ -51,8 +53,70 @@ type MergeQueueConfig struct {
 	// PollInterval is how often to check for new MRs.
 	PollInterval time.Duration `json:"poll_interval"`
 
+	// LoopInterval is the wait between 'gt mq process --loop' cycles.
+	// Zero uses PollInterval. See EffectiveLoopInterval.
+	LoopInterval time.Duration `json:"loop_interval"`
+
 	// MaxConcurrent is the maximum number of MRs to process concurrently.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// BatchSize is the most ready MRs with the same target merged together
+	// and pushed at once. 0 or 1 merges one MR at a time.
+	BatchSize int `json:"batch_size"`
+
+	// MergeWindow is when the refinery may merge. Empty is always open.
+	MergeWindow config.MergeWindow `json:"-"`
+
+	// HotBranches are branch patterns whose MRs are scored as HotPriority.
+	HotBranches config.HotBranches `json:"hot_branches"`
+
+	// NotifyOnMerge mails the worker when their MR lands, with the merge
+	// commit and target. Off by default.
+	NotifyOnMerge bool `json:"notify_on_merge"`
+
+	// ListIDWidth and ListBranchWidth are how many characters of MR IDs
+	// and branches 'gt mq list' shows; 0 never truncates.
+	ListIDWidth     int `json:"list_id_width"`
+	ListBranchWidth int `json:"list_branch_width"`
+
+	// MRIDPrefix is the prefix of new MR IDs (<prefix>-mr-<hash>). Empty
+	// leaves the ID to beads, using the rig's bead prefix.
+	MRIDPrefix string `json:"mr_id_prefix"`
+
+	// MergeMessageTemplate is the merge commit message, with {{mr_id}},
+	// {{source_issue}}, {{worker}}, {{branch}} and {{target}} placeholders.
+	// Empty uses the built-in "Merge <branch> into <target> (<issue>)" format.
+	MergeMessageTemplate string `json:"merge_message_template"`
+
+	// SignaturePolicy is what happens to an MR whose head commit isn't
+	// signed by a trusted key: config.SignaturePolicyOff (not checked),
+	// SignaturePolicyBlock (stays queued) or SignaturePolicyReject (fails).
+	SignaturePolicy string `json:"signature_policy"`
+
+	// TrustedSigningKeys are the accepted signing keys; empty accepts any
+	// signature git fully trusts. See SignatureTrusted.
+	TrustedSigningKeys []string `json:"trusted_signing_keys"`
+
+	// IsolateMerges merges each MR (or batch) in a throwaway worktree of the
+	// rig's repo, detached at the target's tip, instead of the rig's own
+	// checkout. The worktree is removed afterwards, merged or not.
+	IsolateMerges bool `json:"isolate_merges"`
+
+	// MRTimeout is the longest one MR (or batch) may take to merge before
+	// it is cancelled and failed, so a hung merge can't stall the queue.
+	// 0 means no limit.
+	MRTimeout time.Duration `json:"mr_timeout"`
+
+	// MergedIssueStatus is the status an MR's source issue moves to when the
+	// MR merges; beads.StatusClosed (the default) closes it.
+	MergedIssueStatus string `json:"merged_issue_status"`
+
+	// MergedIssueLabels are added to the source issue when its MR merges.
+	MergedIssueLabels []string `json:"merged_issue_labels"`
+
+	// PushRetries is how many times a push that failed with a
+	// git.NetworkError is retried, with exponential backoff. See pushTarget.
+	PushRetries int `json:"push_retries"`
 }
 
 // DefaultMergeQueueConfig returns sensible defaults for merge queue configuration.
@@ -68,9 +132,39 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		RetryFlakyTests:      1,
 		PollInterval:         30 * time.Second,
 		MaxConcurrent:        1,
+		ListIDWidth:          12,
+		ListBranchWidth:      24,
+		SignaturePolicy:      config.SignaturePolicyOff,
+		MRTimeout:            DefaultMRTimeout,
+		MergedIssueStatus:    beads.StatusClosed,
+		PushRetries:          DefaultPushRetries,
 	}
 }
 
+// DefaultPushRetries is the default merge_queue.push_retries.
+const DefaultPushRetries = 3
+
+// DefaultPushBackoff is the wait before the first push retry; each retry
+// after waits twice as long as the one before (2s, 4s, 8s, ...).
+const DefaultPushBackoff = 2 * time.Second
+
+// DefaultMRTimeout is the default merge_queue.mr_timeout.
+const DefaultMRTimeout = 30 * time.Minute
+
+// EffectiveLoopInterval is the wait between 'gt mq process --loop' cycles:
+// loop_interval if set, else poll_interval, never below
+// config.MinLoopInterval.
+func (c *MergeQueueConfig) EffectiveLoopInterval() time.Duration {
+	interval := c.LoopInterval
+	if interval == 0 {
+		interval = c.PollInterval
+	}
+	if interval < config.MinLoopInterval {
+		interval = config.MinLoopInterval
+	}
+	return interval
+}
+
 // Engineer is the merge queue processor that polls for ready merge-requests
 // and processes them according to the merge queue design.
 type Engineer struct {
@@ -84,6 +178,28 @@ type Engineer struct {
 	eventLogger *mrqueue.EventLogger
 	router      *mail.Router // Mail router for sending protocol messages
 
+	// holder identifies this refinery in merge queue claims
+	holder string
+
+	// ignoreWindow lets the engineer merge outside the merge window
+	ignoreWindow bool
+
+	// now is the clock checked against the merge window (time.Now;
+	// replaced in tests)
+	now func() time.Time
+
+	// reevaluated holds, by MR ID, the note from re-checking a pending MR
+	// after an overlapping merge earlier in the cycle (see reevaluate)
+	reevaluated map[string]string
+
+	// pushBackoff is the wait before the first push retry, doubled for
+	// each one after (DefaultPushBackoff; shortened in tests)
+	pushBackoff time.Duration
+
+	// mergeMR merges one claimed MR in ProcessOnce (ProcessMRFromQueue;
+	// replaced in tests)
+	mergeMR func(ctx context.Context, mr *mrqueue.MR) ProcessResult
+
 	// stopCh is used for graceful shutdown
 	stopCh chan struct{}
 }
@@ -94,18 +210,37 @@ func NewEngineer(r *rig.Rig) *Engineer {
 	// Override target branch with rig's configured default branch
 	cfg.TargetBranch = r.DefaultBranch()
 
-	return &Engineer{
+	g := git.NewGit(r.Path)
+	g.SetEnv(r.GitEnv())
+
+	e := &Engineer{
 		rig:         r,
 		beads:       beads.New(r.Path),
 		mrQueue:     mrqueue.New(r.Path),
-		git:         git.NewGit(r.Path),
+		git:         g,
 		config:      cfg,
 		workDir:     r.Path,
 		output:      os.Stdout,
 		eventLogger: mrqueue.NewEventLoggerFromRig(r.Path),
 		router:      mail.NewRouter(r.Path),
+		holder:      claimHolder(r.Name),
+		pushBackoff: DefaultPushBackoff,
+		now:         time.Now,
 		stopCh:      make(chan struct{}),
 	}
+	e.mergeMR = e.ProcessMRFromQueue
+	return e
+}
+
+// claimHolder is the name this refinery claims MRs under. Refineries
+// sharing a rig's queue must each set a distinct GT_REFINERY_WORKER (as
+// for 'gt refinery claim'): a worker can always re-claim an MR it holds,
+// so two refineries under one name could both take the same MR.
+func claimHolder(rigName string) string {
+	if id := os.Getenv("GT_REFINERY_WORKER"); id != "" {
+		return id
+	}
+	return rigName + "/refinery"
 }
 
 // SetOutput sets the output writer for user-facing messages.
@@ -114,10 +249,17 @@ func (e *Engineer) SetOutput(w io.Writer) {
 	e.output = w
 }
 
-// LoadConfig loads merge queue configuration from the rig's config.json.
+// SetIgnoreMergeWindow lets the engineer merge outside the configured merge
+// window, for an operator forcing a merge.
+func (e *Engineer) SetIgnoreMergeWindow(ignore bool) {
+	e.ignoreWindow = ignore
+}
+
+// LoadConfig loads merge queue configuration from the rig's config.json,
+// with the active environment's overlay merged in (config.ReadWithOverlay).
 func (e *Engineer) LoadConfig() error {
 	configPath := filepath.Join(e.rig.Path, "config.json")
-	data, err := os.ReadFile(configPath)
+	data, _, err := config.ReadWithOverlay(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Use defaults if no config file
@@ -142,21 +284,38 @@ func (e *Engineer) LoadConfig() error {
 	// Parse merge_queue section into our config struct
 	// We need special handling for poll_interval (string -> Duration)
 	var mqRaw struct {
-		Enabled              *bool   `json:"enabled"`
-		TargetBranch         *string `json:"target_branch"`
-		IntegrationBranches  *bool   `json:"integration_branches"`
-		OnConflict           *string `json:"on_conflict"`
-		RunTests             *bool   `json:"run_tests"`
-		TestCommand          *string `json:"test_command"`
-		DeleteMergedBranches *bool   `json:"delete_merged_branches"`
-		RetryFlakyTests      *int    `json:"retry_flaky_tests"`
-		PollInterval         *string `json:"poll_interval"`
-		MaxConcurrent        *int    `json:"max_concurrent"`
+		Enabled              *bool    `json:"enabled"`
+		TargetBranch         *string  `json:"target_branch"`
+		IntegrationBranches  *bool    `json:"integration_branches"`
+		OnConflict           *string  `json:"on_conflict"`
+		RunTests             *bool    `json:"run_tests"`
+		TestCommand          *string  `json:"test_command"`
+		DeleteMergedBranches *bool    `json:"delete_merged_branches"`
+		RetryFlakyTests      *int     `json:"retry_flaky_tests"`
+		PollInterval         *string  `json:"poll_interval"`
+		LoopInterval         *string  `json:"loop_interval"`
+		MaxConcurrent        *int     `json:"max_concurrent"`
+		BatchSize            *int     `json:"batch_size"`
+		MergeWindow          []string `json:"merge_window"`
+		HotBranches          []string `json:"hot_branches"`
+		NotifyOnMerge        *bool    `json:"notify_on_merge"`
+		ListIDWidth          *int     `json:"list_id_width"`
+		ListBranchWidth      *int     `json:"list_branch_width"`
+		MRIDPrefix           *string  `json:"mr_id_prefix"`
+		MergeMessageTemplate *string  `json:"merge_message_template"`
+		SignaturePolicy      *string  `json:"signature_policy"`
+		TrustedSigningKeys   []string `json:"trusted_signing_keys"`
+		IsolateMerges        *bool    `json:"isolate_merges"`
+		MRTimeout            *string  `json:"mr_timeout"`
+		MergedIssueStatus    *string  `json:"merged_issue_status"`
+		MergedIssueLabels    []string `json:"merged_issue_labels"`
+		PushRetries          *int     `json:"push_retries"`
 	}
 
 	if err := json.Unmarshal(rawConfig.MergeQueue, &mqRaw); err != nil {
 		return fmt.Errorf("parsing merge_queue config: %w", err)
 	}
+	config.WarnUnknownKeys(configPath, "merge_queue", rawConfig.MergeQueue, mqRaw)
 
 	// Apply non-nil values to config (preserving defaults for missing fields)
 	if mqRaw.Enabled != nil {
@@ -186,6 +345,33 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.MaxConcurrent != nil {
 		e.config.MaxConcurrent = *mqRaw.MaxConcurrent
 	}
+	if mqRaw.NotifyOnMerge != nil {
+		e.config.NotifyOnMerge = *mqRaw.NotifyOnMerge
+	}
+	if mqRaw.ListIDWidth != nil {
+		if *mqRaw.ListIDWidth < 0 {
+			return fmt.Errorf("invalid list_id_width %d: must be non-negative", *mqRaw.ListIDWidth)
+		}
+		e.config.ListIDWidth = *mqRaw.ListIDWidth
+	}
+	if mqRaw.ListBranchWidth != nil {
+		if *mqRaw.ListBranchWidth < 0 {
+			return fmt.Errorf("invalid list_branch_width %d: must be non-negative", *mqRaw.ListBranchWidth)
+		}
+		e.config.ListBranchWidth = *mqRaw.ListBranchWidth
+	}
+	if mqRaw.MRIDPrefix != nil {
+		if err := config.ValidateMRIDPrefix(*mqRaw.MRIDPrefix); err != nil {
+			return err
+		}
+		e.config.MRIDPrefix = *mqRaw.MRIDPrefix
+	}
+	if mqRaw.BatchSize != nil {
+		if *mqRaw.BatchSize < 0 {
+			return fmt.Errorf("invalid batch_size %d: must be non-negative", *mqRaw.BatchSize)
+		}
+		e.config.BatchSize = *mqRaw.BatchSize
+	}
 	if mqRaw.PollInterval != nil {
 		dur, err := time.ParseDuration(*mqRaw.PollInterval)
 		if err != nil {
@@ -193,6 +379,70 @@ func (e *Engineer) LoadConfig() error {
 		}
 		e.config.PollInterval = dur
 	}
+	if mqRaw.LoopInterval != nil {
+		dur, err := config.ParseLoopInterval(*mqRaw.LoopInterval)
+		if err != nil {
+			return err
+		}
+		e.config.LoopInterval = dur
+	}
+	if mqRaw.MergeWindow != nil {
+		window, err := config.ParseMergeWindow(mqRaw.MergeWindow)
+		if err != nil {
+			return err
+		}
+		e.config.MergeWindow = window
+	}
+	if mqRaw.HotBranches != nil {
+		hot, err := config.ParseHotBranches(mqRaw.HotBranches)
+		if err != nil {
+			return err
+		}
+		e.config.HotBranches = hot
+	}
+	if mqRaw.MergeMessageTemplate != nil {
+		if err := ValidateMergeMessageTemplate(*mqRaw.MergeMessageTemplate); err != nil {
+			return fmt.Errorf("invalid merge_message_template: %w", err)
+		}
+		e.config.MergeMessageTemplate = *mqRaw.MergeMessageTemplate
+	}
+	if mqRaw.SignaturePolicy != nil && *mqRaw.SignaturePolicy != "" {
+		if err := config.ValidateSignaturePolicy(*mqRaw.SignaturePolicy); err != nil {
+			return err
+		}
+		e.config.SignaturePolicy = *mqRaw.SignaturePolicy
+	}
+	if mqRaw.TrustedSigningKeys != nil {
+		e.config.TrustedSigningKeys = mqRaw.TrustedSigningKeys
+	}
+	if mqRaw.IsolateMerges != nil {
+		e.config.IsolateMerges = *mqRaw.IsolateMerges
+	}
+	if mqRaw.MRTimeout != nil {
+		dur, err := config.ParseMRTimeout(*mqRaw.MRTimeout)
+		if err != nil {
+			return err
+		}
+		e.config.MRTimeout = dur
+	}
+	if mqRaw.MergedIssueStatus != nil && *mqRaw.MergedIssueStatus != "" {
+		if err := config.ValidateMergedIssueStatus(*mqRaw.MergedIssueStatus); err != nil {
+			return err
+		}
+		e.config.MergedIssueStatus = *mqRaw.MergedIssueStatus
+	}
+	if mqRaw.MergedIssueLabels != nil {
+		if err := config.ValidateMergedIssueLabels(mqRaw.MergedIssueLabels); err != nil {
+			return err
+		}
+		e.config.MergedIssueLabels = mqRaw.MergedIssueLabels
+	}
+	if mqRaw.PushRetries != nil {
+		if err := config.ValidatePushRetries(*mqRaw.PushRetries); err != nil {
+			return err
+		}
+		e.config.PushRetries = *mqRaw.PushRetries
+	}
 
 	return nil
 }
@@ -204,13 +454,40 @@ func (e *Engineer) Config() *MergeQueueConfig {
 
 // ProcessResult contains the result of processing a merge request.
 type ProcessResult struct {
-	Success     bool
-	MergeCommit string
-	Error       string
-	Conflict    bool
-	TestsFailed bool
+	Success       bool
+	MergeCommit   string
+	Error         string
+	Conflict      bool
+	TestsFailed   bool
+	AlreadyMerged bool   // Target already contained the branch; no merge was made
+	Blocked       bool   // MR is blocked (open bead, unsigned under signature_policy block, or merge window closed); no merge was attempted
+	Log           string // Full transcript of a failed attempt (git and test output)
+
+	// Batch lists the MRs merged together in one push, when the MR was
+	// merged as part of a batch (see merge_queue.batch_size).
+	Batch []string
 }
 
+// Err returns the failure as an error, or nil on success.
+// Conflicts wrap ErrConflict and blocked MRs wrap ErrMRBlocked, so callers
+// can branch on them with errors.Is.
+func (r ProcessResult) Err() error {
+	switch {
+	case r.Success:
+		return nil
+	case r.Conflict:
+		return fmt.Errorf("%w: %s", ErrConflict, r.Error)
+	case r.Blocked:
+		return fmt.Errorf("%w: %s", ErrMRBlocked, r.Error)
+	default:
+		return errors.New(r.Error)
+	}
+}
+
+// ReasonAlreadyMerged is the merge_skipped event reason for MRs whose branch
+// was already merged into the target out of band.
+const ReasonAlreadyMerged = "already merged"
+
 // ProcessMR processes a single merge request from a beads issue.
 func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult {
 	// Parse MR fields from description
@@ -228,15 +505,55 @@ func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult
 	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mrFields.Target)
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mrFields.Worker)
 
-	return e.doMerge(ctx, mrFields.Branch, mrFields.Target, mrFields.SourceIssue)
+	return e.doMerge(ctx, MergeMessageData{
+		MRID:        mr.ID,
+		SourceIssue: mrFields.SourceIssue,
+		Worker:      mrFields.Worker,
+		Branch:      mrFields.Branch,
+		Target:      mrFields.Target,
+	})
 }
 
 // doMerge performs the actual git merge operation.
 // This is the core merge logic shared by ProcessMR and ProcessMRFromQueue.
-func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue string) ProcessResult {
+// A failed or already merged attempt's full transcript (engineer output, git
+// commands with their output, test output) is returned in the result's Log.
+func (e *Engineer) doMerge(ctx context.Context, data MergeMessageData) ProcessResult {
+	e.recordAttempt(data.MRID)
+
+	var attemptLog bytes.Buffer
+	output := e.output
+	e.output = io.MultiWriter(output, &attemptLog)
+	defer func() { e.output = output }()
+
+	if note := e.takeReevaluation(data.MRID); note != "" {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] %s\n", note)
+	}
+
+	result := e.mergeAttempt(ctx, data, &attemptLog)
+	if !result.Success || result.AlreadyMerged {
+		result.Log = attemptLog.String()
+	}
+	return result
+}
+
+// mergeAttempt runs the merge steps for doMerge, writing git and test output
+// to attemptLog.
+func (e *Engineer) mergeAttempt(ctx context.Context, data MergeMessageData, attemptLog io.Writer) ProcessResult {
+	branch, target := data.Branch, data.Target
+	if err := e.rig.CheckBranchUnprotected(target); err != nil {
+		return ProcessResult{Success: false, Error: err.Error()}
+	}
+	if err := e.CheckMergeWindow(); err != nil {
+		return ProcessResult{Blocked: true, Error: err.Error()}
+	}
+
+	// Git subprocesses are cancelled along with ctx (e.g., on Ctrl-C)
+	g := e.branchRepo().WithContext(ctx).WithTranscript(attemptLog)
+
 	// Step 1: Verify source branch exists locally (shared .repo.git with polecats)
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking local branch %s...\n", branch)
-	exists, err := e.git.BranchExists(branch)
+	exists, err := g.BranchExists(branch)
 	if err != nil {
 		return ProcessResult{
 			Success: false,
@@ -250,24 +567,55 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
-	// Step 2: Checkout the target branch
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking out target branch %s...\n", target)
-	if err := e.git.Checkout(target); err != nil {
+	// Everything below (signature, conflicts, tests, merge) works on the
+	// branch's head as of now: the branch lives in a repo shared with the
+	// worker, so a commit pushed to it mid-merge must not slip in unchecked.
+	head, err := g.Rev(branch)
+	if err != nil {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get branch SHA: %v", err),
+		}
+	}
+
+	// Step 1.5: Verify the head commit's signature, if the rig requires it
+	if check := e.verifySignature(g, head); check != nil && !check.Trusted {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Signature check failed for %s: %s\n", branch, check.Detail)
+		return ProcessResult{
+			Success: false,
+			Blocked: e.config.SignaturePolicy == config.SignaturePolicyBlock,
+			Error:   fmt.Sprintf("%v: %s", ErrUntrustedSignature, check.Detail),
+		}
+	}
+
+	// Step 2: Check out the target branch, in place or in a merge worktree
+	ws, err := e.openMergeWorkspace(ctx, target, data.MRID, attemptLog)
+	if err != nil {
 		return ProcessResult{
 			Success: false,
-			Error:   fmt.Sprintf("failed to checkout target %s: %v", target, err),
+			Error:   err.Error(),
 		}
 	}
+	defer ws.close()
+	g = ws.git
 
-	// Make sure target is up to date with origin
-	if err := e.git.Pull("origin", target); err != nil {
-		// Pull might fail if nothing to pull, that's ok
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+	// Step 2.5: Skip the merge if the target already contains the branch
+	// (e.g., it was merged manually). Redoing it would error or create an empty merge.
+	merged, err := g.IsAncestor(head, ws.base)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: ancestry check failed: %v (continuing)\n", err)
+	} else if merged {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] %s is already merged into %s, skipping merge\n", branch, target)
+		return ProcessResult{
+			Success:       true,
+			AlreadyMerged: true,
+			MergeCommit:   head,
+		}
 	}
 
 	// Step 3: Check for merge conflicts (using local branch)
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
-	conflicts, err := e.git.CheckConflicts(branch, target)
+	conflicts, err := g.CheckConflicts(head, ws.base)
 	if err != nil {
 		return ProcessResult{
 			Success:  false,
@@ -286,7 +634,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	// Step 4: Run tests if configured
 	if e.config.RunTests && e.config.TestCommand != "" {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
-		result := e.runTests(ctx)
+		result := e.runTests(ctx, ws.dir, attemptLog)
 		if !result.Success {
 			return ProcessResult{
 				Success:     false,
@@ -298,14 +646,11 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 
 	// Step 5: Perform the actual merge
-	mergeMsg := fmt.Sprintf("Merge %s into %s", branch, target)
-	if sourceIssue != "" {
-		mergeMsg = fmt.Sprintf("Merge %s into %s (%s)", branch, target, sourceIssue)
-	}
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging with message: %s\n", mergeMsg)
-	if err := e.git.MergeNoFF(branch, mergeMsg); err != nil {
+	mergeMsg := RenderMergeMessage(e.config.MergeMessageTemplate, data)
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging %s at %s with message: %s\n", branch, shortSHA(head), mergeMsg)
+	if err := g.MergeNoFF(head, mergeMsg); err != nil {
 		if errors.Is(err, git.ErrMergeConflict) {
-			_ = e.git.AbortMerge()
+			_ = ws.plain.AbortMerge() // not ctx-bound: must run even after cancellation
 			return ProcessResult{
 				Success:  false,
 				Conflict: true,
@@ -319,7 +664,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 
 	// Step 6: Get the merge commit SHA
-	mergeCommit, err := e.git.Rev("HEAD")
+	mergeCommit, err := g.Rev("HEAD")
 	if err != nil {
 		return ProcessResult{
 			Success: false,
@@ -329,7 +674,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 
 	// Step 7: Push to origin
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing to origin/%s...\n", target)
-	if err := e.git.Push("origin", target, false); err != nil {
+	if err := e.pushTarget(ctx, g, ws.push); err != nil {
 		return ProcessResult{
 			Success: false,
 			Error:   fmt.Sprintf("failed to push to origin: %v", err),
@@ -343,8 +688,35 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 }
 
-// runTests runs the configured test command and returns the result.
-func (e *Engineer) runTests(ctx context.Context) ProcessResult {
+// pushTarget pushes branch to origin, retrying up to PushRetries times with
+// exponential backoff while the push fails on a transient network error
+// (git.ErrNetwork). A rejection, or any other failure, is returned at once.
+func (e *Engineer) pushTarget(ctx context.Context, g *git.Git, branch string) error {
+	delay := e.pushBackoff
+	for attempt := 0; ; attempt++ {
+		err := g.Push("origin", branch, false)
+		if err == nil || !errors.Is(err, git.ErrNetwork) || attempt >= e.config.PushRetries {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Push failed (%v); retrying in %s (%d/%d)...\n",
+			err, delay, attempt+1, e.config.PushRetries)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (retry cancelled: %v)", err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// testWaitDelay is how long a cancelled test run's output may stay open
+// (held by a child that outlived the kill) before runTests gives up on it.
+const testWaitDelay = 10 * time.Second
+
+// runTests runs the configured test command in dir and returns the result.
+// The command's output goes to testLog.
+func (e *Engineer) runTests(ctx context.Context, dir string, testLog io.Writer) ProcessResult {
 	if e.config.TestCommand == "" {
 		return ProcessResult{Success: true}
 	}
@@ -355,6 +727,16 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		maxRetries = 1
 	}
 
+	// Builds put artifacts under the rig's build root, exposed as GT_BUILD_ROOT
+	buildRoot, err := e.rig.BuildRoot(dir)
+	if err != nil {
+		return ProcessResult{
+			Success:     false,
+			TestsFailed: true,
+			Error:       err.Error(),
+		}
+	}
+
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if attempt > 1 {
@@ -364,16 +746,22 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 		// Note: TestCommand comes from rig's config.json (trusted infrastructure config),
 		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
 		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
-		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
-
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GT_BUILD_ROOT="+buildRoot)
+		cmd.Stdout = testLog
+		cmd.Stderr = testLog
+		// On cancel, kill the tests' children too, and don't wait forever
+		// for any that survive to close the output
+		killProcessGroupOnCancel(cmd)
+		cmd.WaitDelay = testWaitDelay
+
+		_, _ = fmt.Fprintf(testLog, "$ %s\n", e.config.TestCommand)
 		err := cmd.Run()
 		if err == nil {
 			return ProcessResult{Success: true}
 		}
 		lastErr = err
+		_, _ = fmt.Fprintf(testLog, "(%v)\n", err)
 
 		// Check if context was canceled
 		if ctx.Err() != nil {
@@ -391,14 +779,28 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 	}
 }
 
+// FinishMR records the outcome of ProcessMR on the MR bead. On success it
+// closes the MR and its source issue; on failure it reopens the MR for rework.
+func (e *Engineer) FinishMR(mr *beads.Issue, result ProcessResult) {
+	if result.Success {
+		e.handleSuccess(mr, result)
+	} else {
+		e.handleFailure(mr, result)
+	}
+}
+
 // handleSuccess handles a successful merge completion.
 // Steps:
 // 1. Update MR with merge_commit SHA
 // 2. Close MR with reason 'merged'
-// 3. Close source issue with reference to MR
+// 3. Move the source issue to merged_issue_status (closed by default)
 // 4. Delete source branch if configured
 // 5. Log success
 func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
+	if result.AlreadyMerged {
+		e.saveMergeLog(mr.ID, result)
+	}
+
 	// Parse MR fields from description
 	mrFields := beads.ParseMRFields(mr)
 	if mrFields == nil {
@@ -408,6 +810,7 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 	// 1. Update MR with merge_commit SHA
 	mrFields.MergeCommit = result.MergeCommit
 	mrFields.CloseReason = "merged"
+	mrFields.MergedAt = time.Now().UTC().Format(time.RFC3339)
 	newDesc := beads.SetMRFields(mr, mrFields)
 	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
@@ -418,14 +821,10 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
 	}
 
-	// 3. Close source issue with reference to MR
+	// 3. Record the merge on the source issue and apply the post-merge transition
 	if mrFields.SourceIssue != "" {
-		closeReason := fmt.Sprintf("Merged in %s", mr.ID)
-		if err := e.beads.CloseWithReason(closeReason, mrFields.SourceIssue); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close source issue %s: %v\n", mrFields.SourceIssue, err)
-		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed source issue: %s\n", mrFields.SourceIssue)
-		}
+		e.postMergeResult(mrFields.SourceIssue, mr.ID, mrFields.Worker, result.MergeCommit)
+		e.transitionSourceIssue(mrFields.SourceIssue, mr.ID)
 	}
 
 	// 3.5. Clear agent bead's active_mr reference (traceability cleanup)
@@ -444,23 +843,158 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 		}
 	}
 
-	// 5. Log success
+	// 5. Tell the worker their work landed, if the rig wants that
+	e.notifyMerged(mr.ID, mrFields.Worker, mrFields.Branch, mrFields.Target, mrFields.SourceIssue, result.MergeCommit)
+
+	// 6. Log success
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
 }
 
+// notifyMerged mails the worker that their MR merged, when notify_on_merge
+// is set. Best-effort: the merge stands regardless.
+func (e *Engineer) notifyMerged(mrID, worker, branch, target, sourceIssue, mergeCommit string) {
+	if !e.config.NotifyOnMerge || worker == "" {
+		return
+	}
+	msg := newMergedNotice(e.rig.Name, mrID, worker, branch, target, sourceIssue, mergeCommit)
+	if err := e.router.Send(msg); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to notify %s of merge: %v\n", worker, err)
+	} else {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Notified %s of merge\n", worker)
+	}
+}
+
+// newMergedNotice builds the mail telling a worker their MR merged.
+func newMergedNotice(rigName, mrID, worker, branch, target, sourceIssue, mergeCommit string) *mail.Message {
+	body := fmt.Sprintf(`Your merge request has been merged.
+
+MR: %s
+Branch: %s
+Target: %s
+Commit: %s`, mrID, branch, target, mergeCommit)
+	if sourceIssue != "" {
+		body += "\nIssue: " + sourceIssue
+	}
+	return mail.NewMessage(
+		fmt.Sprintf("%s/refinery", rigName),
+		fmt.Sprintf("%s/%s", rigName, worker),
+		"Merge request merged: "+mrID,
+		body,
+	)
+}
+
+// transitionSourceIssue applies the rig's post-merge transition to an MR's
+// source issue: merged_issue_labels are added, then it is closed with a
+// reference to the MR, or with merged_issue_status set to something else
+// (e.g. "deferred" until deployed) moved to that status instead.
+func (e *Engineer) transitionSourceIssue(sourceIssue, mrID string) {
+	status := e.config.MergedIssueStatus
+	if status == "" {
+		status = beads.StatusClosed
+	}
+
+	if status != beads.StatusClosed {
+		opts := beads.UpdateOptions{Status: &status, AddLabels: e.config.MergedIssueLabels}
+		if err := e.beads.Update(sourceIssue, opts); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to move source issue %s to %s: %v\n", sourceIssue, status, err)
+		} else {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Moved source issue %s to %s\n", sourceIssue, status)
+		}
+		return
+	}
+
+	if len(e.config.MergedIssueLabels) > 0 {
+		if err := e.beads.Update(sourceIssue, beads.UpdateOptions{AddLabels: e.config.MergedIssueLabels}); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to label source issue %s: %v\n", sourceIssue, err)
+		}
+	}
+	if err := e.beads.CloseWithReason(fmt.Sprintf("Merged in %s", mrID), sourceIssue); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close source issue %s: %v\n", sourceIssue, err)
+	} else {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Closed source issue: %s\n", sourceIssue)
+	}
+}
+
+// postMergeResult comments on the source issue that its MR landed, so the
+// issue carries a record of who merged it and the resulting commit.
+func (e *Engineer) postMergeResult(sourceIssue, mrID, worker, mergeCommit string) {
+	if err := e.beads.Comment(sourceIssue, formatMergeComment(e.rig.Name, mrID, worker, mergeCommit)); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to comment on source issue %s: %v\n", sourceIssue, err)
+	}
+}
+
+// formatMergeComment builds the merge-result comment posted on a source issue.
+func formatMergeComment(rigName, mrID, worker, mergeCommit string) string {
+	msg := fmt.Sprintf("Merged via %s by %s/refinery", mrID, rigName)
+	if worker != "" {
+		msg += fmt.Sprintf(" (worker: %s)", worker)
+	}
+	if mergeCommit != "" {
+		msg += fmt.Sprintf("\nCommit: %s", mergeCommit)
+	}
+	return msg
+}
+
 // handleFailure handles a failed merge request.
 // Reopens the MR for rework and logs the failure.
 func (e *Engineer) handleFailure(mr *beads.Issue, result ProcessResult) {
+	e.saveMergeLog(mr.ID, result)
+
 	// Reopen the MR (back to open status for rework)
 	open := "open"
 	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Status: &open}); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reopen MR %s: %v\n", mr.ID, err)
 	}
+	e.stampFailure(mr.ID)
 
 	// Log the failure
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
 }
 
+// stampFailure records the time of a failed attempt on the MR bead, for
+// per-worker reporting (gt mq stats --by-worker).
+func (e *Engineer) stampFailure(mrID string) {
+	if mrID == "" {
+		return
+	}
+	issue, err := e.beads.Show(mrID)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mrID, err)
+		return
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	fields.FailedAt = time.Now().UTC().Format(time.RFC3339)
+	desc := beads.SetMRFields(issue, fields)
+	if err := e.beads.Update(mrID, beads.UpdateOptions{Description: &desc}); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record failure on MR %s: %v\n", mrID, err)
+	}
+}
+
+// recordAttempt counts a merge attempt on the MR bead, for triage of
+// chronically failing MRs (gt mq list --wide).
+func (e *Engineer) recordAttempt(mrID string) {
+	if mrID == "" {
+		return
+	}
+	issue, err := e.beads.Show(mrID)
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mrID, err)
+		return
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	fields.Attempts++
+	desc := beads.SetMRFields(issue, fields)
+	if err := e.beads.Update(mrID, beads.UpdateOptions{Description: &desc}); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record attempt on MR %s: %v\n", mrID, err)
+	}
+}
+
 // ProcessMRFromQueue processes a merge request from wisp queue.
 func (e *Engineer) ProcessMRFromQueue(ctx context.Context, mr *mrqueue.MR) ProcessResult {
 	// MR fields are directly on the struct (no parsing needed)
@@ -470,19 +1004,38 @@ func (e *Engineer) ProcessMRFromQueue(ctx context.Context, mr *mrqueue.MR) Proce
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mr.Worker)
 	_, _ = fmt.Fprintf(e.output, "  Source: %s\n", mr.SourceIssue)
 
+	if err := e.CheckReady(mr); err != nil {
+		return ProcessResult{
+			Success: false,
+			Blocked: true,
+			Error:   fmt.Sprintf("blocked by %s", mr.BlockedBy),
+		}
+	}
+
 	// Emit merge_started event
 	if err := e.eventLogger.LogMergeStarted(mr); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_started event: %v\n", err)
 	}
 
 	// Use the shared merge logic
-	return e.doMerge(ctx, mr.Branch, mr.Target, mr.SourceIssue)
+	return e.doMerge(ctx, MergeMessageData{
+		MRID:        mr.ID,
+		SourceIssue: mr.SourceIssue,
+		Worker:      mr.Worker,
+		Branch:      mr.Branch,
+		Target:      mr.Target,
+	})
 }
 
 // handleSuccessFromQueue handles a successful merge from wisp queue.
 func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult) {
-	// Emit merged event
-	if err := e.eventLogger.LogMerged(mr, result.MergeCommit); err != nil {
+	// Emit merged event (or merge_skipped if the branch had already landed)
+	if result.AlreadyMerged {
+		e.saveMergeLog(mr.ID, result)
+		if err := e.eventLogger.LogMergeSkipped(mr, ReasonAlreadyMerged); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_skipped event: %v\n", err)
+		}
+	} else if err := e.eventLogger.LogMergedInBatch(mr, result.MergeCommit, result.Batch); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merged event: %v\n", err)
 	}
 
@@ -514,6 +1067,7 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 			}
 			mrFields.MergeCommit = result.MergeCommit
 			mrFields.CloseReason = "merged"
+			mrFields.MergedAt = time.Now().UTC().Format(time.RFC3339)
 			newDesc := beads.SetMRFields(mrBead, mrFields)
 			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
 				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
@@ -528,14 +1082,10 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 		}
 	}
 
-	// 1. Close source issue with reference to MR
+	// 1. Record the merge on the source issue and apply the post-merge transition
 	if mr.SourceIssue != "" {
-		closeReason := fmt.Sprintf("Merged in %s", mr.ID)
-		if err := e.beads.CloseWithReason(closeReason, mr.SourceIssue); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close source issue %s: %v\n", mr.SourceIssue, err)
-		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed source issue: %s\n", mr.SourceIssue)
-		}
+		e.postMergeResult(mr.SourceIssue, mr.ID, mr.Worker, result.MergeCommit)
+		e.transitionSourceIssue(mr.SourceIssue, mr.ID)
 	}
 
 	// 1.5. Clear agent bead's active_mr reference (traceability cleanup)
@@ -559,6 +1109,11 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to remove MR from queue: %v\n", err)
 	}
 
+	// 3.5. Tell the worker their work landed (not if it had landed already)
+	if !result.AlreadyMerged {
+		e.notifyMerged(mr.ID, mr.Worker, mr.Branch, mr.Target, mr.SourceIssue, result.MergeCommit)
+	}
+
 	// 4. Log success
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
 }
@@ -567,6 +1122,9 @@ func (e *Engineer) handleSuccessFromQueue(mr *mrqueue.MR, result ProcessResult)
 // For conflicts, creates a resolution task and blocks the MR until resolved.
 // This enables non-blocking delegation: the queue continues to the next MR.
 func (e *Engineer) handleFailureFromQueue(mr *mrqueue.MR, result ProcessResult) {
+	e.saveMergeLog(mr.ID, result)
+	e.stampFailure(mr.ID)
+
 	// Emit merge_failed event
 	if err := e.eventLogger.LogMergeFailed(mr, result.Error); err != nil {
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_failed event: %v\n", err)
@@ -743,12 +1301,87 @@ func (e *Engineer) IsBeadOpen(beadID string) (bool, error) {
 	return issue.Status != "closed", nil
 }
 
+// CheckReady returns an error wrapping ErrMRBlocked if the MR is blocked
+// by a bead that is still open.
+func (e *Engineer) CheckReady(mr *mrqueue.MR) error {
+	if mr.BlockedBy == "" {
+		return nil
+	}
+	open, err := e.IsBeadOpen(mr.BlockedBy)
+	if err != nil {
+		return err
+	}
+	if open {
+		return fmt.Errorf("%w: waiting on %s", ErrMRBlocked, mr.BlockedBy)
+	}
+	return nil
+}
+
 // ListReadyMRs returns MRs that are ready for processing:
 // - Not claimed by another worker (or claim is stale)
 // - Not blocked by an open task
-// Sorted by priority score (highest first).
+// - Not marked draft on the MR bead
+// - Not held (gt mq hold)
+// Sorted by priority score (highest first), with MRs on hot branches
+// scored as HotPriority.
 func (e *Engineer) ListReadyMRs() ([]*mrqueue.MR, error) {
-	return e.mrQueue.ListReady(e.IsBeadOpen)
+	ready, err := e.mrQueue.ListReady(e.IsBeadOpen)
+	if err != nil {
+		return nil, err
+	}
+	if len(ready) > 0 {
+		drafts := e.draftMRs()
+		ready = withoutDrafts(ready, func(mrID string) bool { return drafts[mrID] })
+	}
+	ready = withoutHeld(ready, NewManager(e.rig).holds())
+	if len(e.config.HotBranches) > 0 {
+		sortByHotScore(ready, e.config.HotBranches, time.Now())
+	}
+	return ready, nil
+}
+
+// draftMRs returns the IDs of the open MRs marked draft, from a single bd
+// list of the rig's MR beads. If the beads can't be listed, no MR is
+// treated as a draft.
+func (e *Engineer) draftMRs() map[string]bool {
+	issues, err := e.beads.List(beads.ListOptions{Status: "open", Type: "merge-request", Priority: -1})
+	if err != nil {
+		return nil
+	}
+	drafts := make(map[string]bool)
+	for _, issue := range issues {
+		if fields := beads.ParseMRFields(issue); fields != nil && fields.Draft {
+			drafts[issue.ID] = true
+		}
+	}
+	return drafts
+}
+
+// withoutDrafts returns mrs minus those isDraft reports as drafts.
+func withoutDrafts(mrs []*mrqueue.MR, isDraft func(mrID string) bool) []*mrqueue.MR {
+	kept := mrs[:0]
+	for _, mr := range mrs {
+		if !isDraft(mr.ID) {
+			kept = append(kept, mr)
+		}
+	}
+	return kept
+}
+
+// sortByHotScore re-sorts MRs by score after bumping those on hot branches
+// to HotPriority. The stored priority is left alone.
+func sortByHotScore(mrs []*mrqueue.MR, hot config.HotBranches, now time.Time) {
+	score := func(mr *mrqueue.MR) float64 {
+		if hot.Match(mr.Target, mr.Branch) {
+			bumped := *mr
+			bumped.Priority = HotPriority
+			return bumped.ScoreAt(now)
+		}
+		return mr.ScoreAt(now)
+	}
+	sort.SliceStable(mrs, func(i, j int) bool {
+		return score(mrs[i]) > score(mrs[j])
+	})
 }
 
 // ListBlockedMRs returns MRs that are blocked by open tasks.
@@ -1,12 +1,24 @@
 package refinery
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -186,6 +198,104 @@ func TestEngineer_LoadConfig_InvalidPollInterval(t *testing.T) {
 	}
 }
 
+func TestEngineer_LoadConfig_InvalidMergeMessageTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"merge_message_template": "Merge {{branch}} for {{ticket}}",
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err == nil {
+		t.Error("expected error for unknown merge_message_template placeholder")
+	}
+}
+
+func TestEngineer_LoadConfig_LoopInterval(t *testing.T) {
+	load := func(t *testing.T, mq map[string]interface{}) (*Engineer, error) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		data, _ := json.MarshalIndent(map[string]interface{}{"merge_queue": mq}, "", "  ")
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+		return e, e.LoadConfig()
+	}
+
+	e, err := load(t, map[string]interface{}{"loop_interval": "2m", "poll_interval": "10s"})
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := e.Config().EffectiveLoopInterval(); got != 2*time.Minute {
+		t.Errorf("EffectiveLoopInterval() = %v, want 2m", got)
+	}
+
+	// Without loop_interval, poll_interval applies, floored at the minimum
+	e, err = load(t, map[string]interface{}{"poll_interval": "1s"})
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := e.Config().EffectiveLoopInterval(); got != 5*time.Second {
+		t.Errorf("EffectiveLoopInterval() = %v, want the 5s minimum", got)
+	}
+
+	if _, err := load(t, map[string]interface{}{"loop_interval": "100ms"}); err == nil {
+		t.Error("expected error for loop_interval below the minimum")
+	}
+}
+
+func TestRenderMergeMessage(t *testing.T) {
+	data := MergeMessageData{
+		MRID:        "gt-mr-1",
+		SourceIssue: "gt-xyz",
+		Worker:      "Nux",
+		Branch:      "polecat/Nux/gt-xyz",
+		Target:      "main",
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		data MergeMessageData
+		want string
+	}{
+		{"default", "", data, "Merge polecat/Nux/gt-xyz into main (gt-xyz)"},
+		{"default without issue", "", MergeMessageData{Branch: "b", Target: "main"}, "Merge b into main"},
+		{"custom", "{{source_issue}}: merge {{mr_id}} by {{ worker }}", data, "gt-xyz: merge gt-mr-1 by Nux"},
+		{"renders empty", "{{source_issue}}", MergeMessageData{Branch: "b", Target: "main"}, "Merge b into main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMergeMessage(tt.tmpl, tt.data); got != tt.want {
+				t.Errorf("RenderMergeMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMergeMessageTemplate(t *testing.T) {
+	valid := []string{"", "Merge {{branch}} into {{target}}", "{{mr_id}} {{source_issue}} {{worker}}"}
+	for _, tmpl := range valid {
+		if err := ValidateMergeMessageTemplate(tmpl); err != nil {
+			t.Errorf("ValidateMergeMessageTemplate(%q) = %v, want nil", tmpl, err)
+		}
+	}
+	invalid := []string{"{{issue}}", "Merge {{branch}"}
+	for _, tmpl := range invalid {
+		if err := ValidateMergeMessageTemplate(tmpl); err == nil {
+			t.Errorf("ValidateMergeMessageTemplate(%q) = nil, want error", tmpl)
+		}
+	}
+}
+
 func TestNewEngineer(t *testing.T) {
 	r := &rig.Rig{
 		Name: "test-rig",
@@ -215,3 +325,1048 @@ func TestEngineer_DeleteMergedBranchesConfig(t *testing.T) {
 		t.Error("expected DeleteMergedBranches to be true by default")
 	}
 }
+
+func TestFormatMergeComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		worker      string
+		mergeCommit string
+		want        string
+	}{
+		{
+			name:        "worker and commit",
+			worker:      "Nux",
+			mergeCommit: "abc1234",
+			want:        "Merged via gt-mr1 by gastown/refinery (worker: Nux)\nCommit: abc1234",
+		},
+		{
+			name: "no worker or commit",
+			want: "Merged via gt-mr1 by gastown/refinery",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatMergeComment("gastown", "gt-mr1", tt.worker, tt.mergeCommit)
+			if got != tt.want {
+				t.Errorf("formatMergeComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessResult_Err(t *testing.T) {
+	if err := (ProcessResult{Success: true}).Err(); err != nil {
+		t.Errorf("Err() on success = %v, want nil", err)
+	}
+	if err := (ProcessResult{Conflict: true, Error: "conflicts in a.go"}).Err(); !errors.Is(err, ErrConflict) {
+		t.Errorf("Err() on conflict = %v, want ErrConflict", err)
+	}
+	if err := (ProcessResult{Blocked: true, Error: "blocked by gt-1"}).Err(); !errors.Is(err, ErrMRBlocked) {
+		t.Errorf("Err() on blocked = %v, want ErrMRBlocked", err)
+	}
+	err := (ProcessResult{TestsFailed: true, Error: "tests failed"}).Err()
+	if err == nil || errors.Is(err, ErrConflict) || errors.Is(err, ErrMRBlocked) {
+		t.Errorf("Err() on test failure = %v, want plain error", err)
+	}
+}
+
+func TestEngineer_DoMerge_AlreadyMerged(t *testing.T) {
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("checkout", "-b", "polecat/Nux/gt-xyz")
+	if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "work")
+	// Merge out of band
+	run("checkout", "main")
+	run("merge", "--ff-only", "polecat/Nux/gt-xyz")
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: repo})
+	e.SetOutput(io.Discard)
+	e.config.RunTests = false
+
+	result := e.doMerge(context.Background(), MergeMessageData{
+		Branch:      "polecat/Nux/gt-xyz",
+		Target:      "main",
+		SourceIssue: "gt-xyz",
+	})
+	if !result.Success || !result.AlreadyMerged {
+		t.Fatalf("doMerge() = %+v, want successful AlreadyMerged result", result)
+	}
+	if result.MergeCommit == "" {
+		t.Error("expected MergeCommit to be the already-merged branch head")
+	}
+
+	// The outcome is kept for gt mq log, marked as already merged
+	e.saveMergeLog("gt-mr-1", result)
+	log, err := ReadMergeLog(repo, "gt-mr-1")
+	if err != nil {
+		t.Fatalf("ReadMergeLog: %v", err)
+	}
+	alreadyMerged, body := MergeLogAlreadyMerged(log)
+	if !alreadyMerged || !strings.Contains(body, "is already merged into main") {
+		t.Errorf("merge log = %q, want an already merged record with the attempt's output", log)
+	}
+	if alreadyMerged, _ := MergeLogAlreadyMerged("[Engineer] Running tests\n"); alreadyMerged {
+		t.Error("a failure log was read as already merged")
+	}
+}
+
+func TestEngineer_DoMerge_FailureLog(t *testing.T) {
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("checkout", "-b", "polecat/Nux/gt-xyz")
+	if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "work")
+	run("checkout", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: repo})
+	e.SetOutput(io.Discard)
+	e.config.RunTests = true
+	e.config.TestCommand = "echo test-output-marker; exit 1"
+
+	result := e.doMerge(context.Background(), MergeMessageData{
+		Branch: "polecat/Nux/gt-xyz",
+		Target: "main",
+	})
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("doMerge() = %+v, want failed tests", result)
+	}
+	for _, want := range []string{"[Engineer] Running tests", "$ git checkout main", "test-output-marker"} {
+		if !strings.Contains(result.Log, want) {
+			t.Errorf("result.Log missing %q:\n%s", want, result.Log)
+		}
+	}
+
+	if err := WriteMergeLog(repo, "gt-mr-1", result.Log); err != nil {
+		t.Fatalf("WriteMergeLog: %v", err)
+	}
+	got, err := ReadMergeLog(repo, "gt-mr-1")
+	if err != nil || got != result.Log {
+		t.Errorf("ReadMergeLog() = %q, %v; want the written log", got, err)
+	}
+}
+
+func TestEngineer_DoMerge_IsolatedFailureLeavesNoResidue(t *testing.T) {
+	repo := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("checkout", "-b", "polecat/Nux/gt-xyz")
+	if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "work")
+	run("checkout", "main")
+	mainHead := run("rev-parse", "HEAD")
+
+	// Merge worktrees are created in the temp dir
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: repo})
+	e.SetOutput(io.Discard)
+	e.config.IsolateMerges = true
+	e.config.RunTests = true
+	e.config.TestCommand = "pwd; exit 1"
+
+	result := e.doMerge(context.Background(), MergeMessageData{
+		Branch: "polecat/Nux/gt-xyz",
+		Target: "main",
+	})
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("doMerge() = %+v, want failed tests", result)
+	}
+	if !strings.Contains(result.Log, filepath.Join(tmp, "gt-merge-")) {
+		t.Errorf("tests didn't run in a merge worktree:\n%s", result.Log)
+	}
+
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temp dir has %d entries after the merge, want none", len(entries))
+	}
+	if got := run("worktree", "list", "--porcelain"); strings.Count(got, "worktree ") != 1 {
+		t.Errorf("worktrees after the merge:\n%s\nwant only the rig's", got)
+	}
+	if got := run("rev-parse", "HEAD"); got != mainHead {
+		t.Errorf("rig HEAD = %s, want %s (untouched)", got, mainHead)
+	}
+	if got := run("status", "--porcelain"); got != "" {
+		t.Errorf("rig checkout not clean:\n%s", got)
+	}
+}
+
+func TestEngineer_CheckMerge(t *testing.T) {
+	repo := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run("add", file)
+		run("commit", "-m", "update "+file)
+	}
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	commit("README.md", "# Test\n")
+	run("branch", "polecat/Nux/merged")
+	run("checkout", "-b", "polecat/Nux/clean")
+	commit("work.txt", "work\n")
+	run("checkout", "-b", "polecat/Nux/conflict", "main")
+	commit("README.md", "# Nux\n")
+	run("checkout", "main")
+	commit("README.md", "# Main\n")
+	mainHead := run("rev-parse", "HEAD")
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: repo})
+	e.SetOutput(io.Discard)
+	ctx := context.Background()
+
+	check, err := e.CheckMerge(ctx, "polecat/Nux/clean", "main", io.Discard)
+	if err != nil || !check.Mergeable || check.AlreadyMerged || check.TargetHead != mainHead {
+		t.Errorf("CheckMerge(clean) = %+v, %v; want mergeable at %s", check, err, mainHead)
+	}
+	check, err = e.CheckMerge(ctx, "polecat/Nux/merged", "main", io.Discard)
+	if err != nil || !check.AlreadyMerged {
+		t.Errorf("CheckMerge(merged) = %+v, %v; want already merged", check, err)
+	}
+
+	check, err = e.CheckMerge(ctx, "polecat/Nux/conflict", "main", io.Discard)
+	var conflict *git.MergeConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, git.ErrMergeConflict) {
+		t.Fatalf("CheckMerge(conflict) error = %v, want a *git.MergeConflictError", err)
+	}
+	if check == nil || check.Mergeable || !reflect.DeepEqual(conflict.Files, []string{"README.md"}) {
+		t.Errorf("CheckMerge(conflict) = %+v, files %v; want README.md", check, conflict.Files)
+	}
+
+	if _, err := e.CheckMerge(ctx, "polecat/Nux/missing", "main", io.Discard); !errors.Is(err, git.ErrBranchNotFound) {
+		t.Errorf("CheckMerge(missing) error = %v, want ErrBranchNotFound", err)
+	}
+
+	// Nothing changed: same checkout, no worktrees left
+	if got := run("rev-parse", "HEAD"); got != mainHead {
+		t.Errorf("HEAD = %s, want %s", got, mainHead)
+	}
+	if got := run("status", "--porcelain"); got != "" {
+		t.Errorf("checkout not clean:\n%s", got)
+	}
+	if got := run("worktree", "list", "--porcelain"); strings.Count(got, "worktree ") != 1 {
+		t.Errorf("worktrees left behind:\n%s", got)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temp dir has %d entries, want none", len(entries))
+	}
+}
+
+func TestWriteMergeLog_Truncates(t *testing.T) {
+	rigPath := t.TempDir()
+	log := strings.Repeat("x", MaxMergeLogSize) + "tail-marker"
+
+	if err := WriteMergeLog(rigPath, "gt-mr-1", log); err != nil {
+		t.Fatalf("WriteMergeLog: %v", err)
+	}
+	got, err := ReadMergeLog(rigPath, "gt-mr-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "[... 11 bytes truncated ...]") || !strings.HasSuffix(got, "tail-marker") {
+		t.Errorf("truncated log = %q...%q", got[:40], got[len(got)-20:])
+	}
+}
+
+func TestEngineer_ProcessOnce_Locked(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	e.SetOutput(io.Discard)
+
+	if err := os.MkdirAll(filepath.Dir(e.processLockPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	held := flock.New(e.processLockPath())
+	if ok, err := held.TryLock(); err != nil || !ok {
+		t.Fatalf("TryLock() = %v, %v", ok, err)
+	}
+	defer func() { _ = held.Unlock() }()
+
+	if _, err := e.ProcessOnce(context.Background()); !errors.Is(err, ErrProcessingLocked) {
+		t.Errorf("ProcessOnce() = %v, want ErrProcessingLocked", err)
+	}
+}
+
+func TestEngineer_ProcessOnce_IsolatesBadMR(t *testing.T) {
+	rigPath := t.TempDir()
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: rigPath})
+	e.SetOutput(io.Discard)
+	e.config.DeleteMergedBranches = false
+	e.config.MRTimeout = 100 * time.Millisecond
+
+	for _, id := range []string{"gt-mr-a", "gt-mr-panic", "gt-mr-hang", "gt-mr-b"} {
+		mr := &mrqueue.MR{ID: id, Branch: "polecat/Nux/" + id, Target: "main"}
+		if err := e.mrQueue.Submit(mr); err != nil {
+			t.Fatalf("Submit(%s): %v", id, err)
+		}
+	}
+
+	// Fake merge: one MR panics, one hangs until cancelled, the rest merge
+	var merged []string
+	e.mergeMR = func(ctx context.Context, mr *mrqueue.MR) ProcessResult {
+		switch mr.ID {
+		case "gt-mr-panic":
+			panic("adapter exploded")
+		case "gt-mr-hang":
+			<-ctx.Done()
+			return ProcessResult{Error: ctx.Err().Error()}
+		}
+		merged = append(merged, mr.ID)
+		return ProcessResult{Success: true, MergeCommit: "abc123"}
+	}
+
+	processed, err := e.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce() error = %v", err)
+	}
+	if len(merged) != 2 {
+		t.Errorf("merged %v, want gt-mr-a and gt-mr-b", merged)
+	}
+
+	outcomes := make(map[string]ProcessedMR)
+	for _, p := range processed {
+		outcomes[p.ID] = p
+	}
+	if got := outcomes["gt-mr-panic"]; got.Outcome != OutcomeFailed || !strings.Contains(got.Error, "adapter exploded") {
+		t.Errorf("panicking MR = %+v, want failed with the panic", got)
+	}
+	if got := outcomes["gt-mr-hang"]; got.Outcome != OutcomeFailed || !strings.Contains(got.Error, "timed out") {
+		t.Errorf("hanging MR = %+v, want failed with a timeout", got)
+	}
+	for _, id := range []string{"gt-mr-a", "gt-mr-b"} {
+		if got := outcomes[id]; got.Outcome != OutcomeMerged {
+			t.Errorf("%s = %+v, want merged", id, got)
+		}
+	}
+
+	// The failed MRs stay queued, unclaimed, for a retry
+	for _, id := range []string{"gt-mr-panic", "gt-mr-hang"} {
+		mr, err := e.mrQueue.Get(id)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if mr.IsClaimed() {
+			t.Errorf("%s still claimed after failing", id)
+		}
+	}
+}
+
+func TestEngineer_ProcessOnce_GivesUpOnHungMerge(t *testing.T) {
+	defer func(grace, wait time.Duration) { mrStuckGrace, mrStuckWait = grace, wait }(mrStuckGrace, mrStuckWait)
+	mrStuckGrace, mrStuckWait = 20*time.Millisecond, 50*time.Millisecond
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	e.SetOutput(io.Discard)
+	e.config.MRTimeout = 20 * time.Millisecond
+	if err := e.mrQueue.Submit(&mrqueue.MR{ID: "gt-mr-hung", Branch: "polecat/Nux/gt-1", Target: "main"}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// Fake merge: never exits until the test is over
+	hung := make(chan struct{})
+	defer close(hung)
+	e.mergeMR = func(ctx context.Context, mr *mrqueue.MR) ProcessResult {
+		<-hung
+		return ProcessResult{}
+	}
+
+	processed, err := e.ProcessOnce(context.Background())
+	if !errors.Is(err, ErrMergeStuck) {
+		t.Fatalf("ProcessOnce() error = %v, want ErrMergeStuck", err)
+	}
+	if len(processed) != 1 || processed[0].Outcome != OutcomeFailed || !strings.Contains(processed[0].Error, "did not exit") {
+		t.Errorf("processed = %+v, want gt-mr-hung failed as not exiting", processed)
+	}
+}
+
+func TestEngineer_RunTests_KillsChildrenOnCancel(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	e.SetOutput(io.Discard)
+	// The suite forks a child that ignores SIGTERM and holds the output open
+	e.config.TestCommand = `sh -c 'trap "" TERM; sleep 30' & sleep 30`
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result := e.runTests(ctx, t.TempDir(), io.Discard)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("runTests returned %v after cancel, want the child killed with it", elapsed)
+	}
+	if result.Success || result.Error != "test run canceled" {
+		t.Errorf("runTests() = %+v, want canceled", result)
+	}
+}
+
+func TestEngineer_ProcessOnce_StopsOnStuckMerge(t *testing.T) {
+	defer func(grace time.Duration) { mrStuckGrace = grace }(mrStuckGrace)
+	mrStuckGrace = 50 * time.Millisecond
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	e.SetOutput(io.Discard)
+	e.config.MRTimeout = 50 * time.Millisecond
+
+	for _, id := range []string{"gt-mr-stuck", "gt-mr-next"} {
+		mr := &mrqueue.MR{ID: id, Branch: "polecat/Nux/" + id, Target: "main", Priority: 1}
+		if id == "gt-mr-next" {
+			mr.Priority = 2 // scored after the stuck MR
+		}
+		if err := e.mrQueue.Submit(mr); err != nil {
+			t.Fatalf("Submit(%s): %v", id, err)
+		}
+	}
+
+	// Fake merge: gt-mr-stuck ignores cancellation for a while
+	var exited atomic.Bool
+	var merged []string
+	e.mergeMR = func(ctx context.Context, mr *mrqueue.MR) ProcessResult {
+		if mr.ID == "gt-mr-stuck" {
+			time.Sleep(300 * time.Millisecond)
+			exited.Store(true)
+			return ProcessResult{Error: "killed"}
+		}
+		merged = append(merged, mr.ID)
+		return ProcessResult{Success: true, MergeCommit: "abc123"}
+	}
+
+	processed, err := e.ProcessOnce(context.Background())
+	if !errors.Is(err, ErrMergeStuck) {
+		t.Fatalf("ProcessOnce() error = %v, want ErrMergeStuck", err)
+	}
+	if !exited.Load() {
+		t.Error("ProcessOnce returned (releasing the processing lock) while the stuck merge was still running")
+	}
+	if len(merged) != 0 {
+		t.Errorf("merged %v after a stuck merge, want the cycle stopped", merged)
+	}
+	if len(processed) != 1 || processed[0].ID != "gt-mr-stuck" || processed[0].Outcome != OutcomeFailed {
+		t.Errorf("processed = %+v, want only gt-mr-stuck, failed", processed)
+	}
+	for _, id := range []string{"gt-mr-stuck", "gt-mr-next"} {
+		mr, err := e.mrQueue.Get(id)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if mr.IsClaimed() {
+			t.Errorf("%s still claimed after the cycle stopped", id)
+		}
+	}
+}
+
+func TestEngineer_ProcessOnce_ReevaluatesOverlappingTargets(t *testing.T) {
+	rigPath := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = rigPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(rigPath, file), []byte(file+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run("add", file)
+		run("commit", "-m", "add "+file)
+	}
+	// integration/b is cut from integration/a; integration/c only shares main
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	commit("README.md")
+	run("checkout", "-b", "integration/a")
+	commit("a.txt")
+	run("checkout", "-b", "integration/b")
+	commit("b.txt")
+	run("checkout", "-b", "integration/c", "main")
+	commit("c.txt")
+	run("checkout", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: rigPath})
+	e.SetOutput(io.Discard)
+	e.config.DeleteMergedBranches = false
+
+	for i, mr := range []*mrqueue.MR{
+		{ID: "gt-mr-a", Target: "integration/a"},
+		{ID: "gt-mr-b", Target: "integration/b"},
+		{ID: "gt-mr-gone", Target: "integration/b"},
+		{ID: "gt-mr-c", Target: "integration/c"},
+	} {
+		mr.Branch = "polecat/Nux/" + mr.ID
+		mr.Priority = i
+		if err := e.mrQueue.Submit(mr); err != nil {
+			t.Fatalf("Submit(%s): %v", mr.ID, err)
+		}
+	}
+
+	// Merging gt-mr-a withdraws gt-mr-gone, which the cycle had as ready
+	notes := make(map[string]string)
+	e.mergeMR = func(ctx context.Context, mr *mrqueue.MR) ProcessResult {
+		notes[mr.ID] = e.takeReevaluation(mr.ID)
+		if mr.ID == "gt-mr-a" {
+			if err := e.mrQueue.Remove("gt-mr-gone"); err != nil {
+				t.Errorf("Remove: %v", err)
+			}
+		}
+		return ProcessResult{Success: true, MergeCommit: "abc123"}
+	}
+
+	processed, err := e.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce() error = %v", err)
+	}
+	var ids []string
+	for _, p := range processed {
+		ids = append(ids, p.ID)
+	}
+	if want := []string{"gt-mr-a", "gt-mr-b", "gt-mr-c"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("processed %v, want %v", ids, want)
+	}
+
+	if !strings.Contains(notes["gt-mr-b"], "re-evaluated after gt-mr-a") {
+		t.Errorf("gt-mr-b note = %q, want re-evaluated after gt-mr-a", notes["gt-mr-b"])
+	}
+	if notes["gt-mr-c"] != "" {
+		t.Errorf("gt-mr-c note = %q, want none (integration/c doesn't overlap)", notes["gt-mr-c"])
+	}
+
+	events, _, err := e.eventLogger.ReadEvents(0)
+	if err != nil {
+		t.Fatalf("ReadEvents: %v", err)
+	}
+	var skipped bool
+	for _, ev := range events {
+		if ev.MRID == "gt-mr-gone" && ev.Type == mrqueue.EventMergeSkipped && strings.Contains(ev.Reason, "re-evaluated after gt-mr-a") {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Errorf("no re-evaluation merge_skipped event for gt-mr-gone in %+v", events)
+	}
+}
+
+func TestNextBatch(t *testing.T) {
+	ready := []*mrqueue.MR{
+		{ID: "a", Target: "main"},
+		{ID: "b", Target: "integration/gt-epic"},
+		{ID: "c", Target: "main"},
+		{ID: "d", Target: "main"},
+	}
+	ids := func(mrs []*mrqueue.MR) string {
+		var s []string
+		for _, mr := range mrs {
+			s = append(s, mr.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	tests := []struct {
+		size int
+		want string
+	}{
+		{0, "a"},
+		{1, "a"},
+		{2, "a,c"},
+		{5, "a,c,d"},
+	}
+	for _, tt := range tests {
+		if got := ids(nextBatch(ready, tt.size)); got != tt.want {
+			t.Errorf("nextBatch(size=%d) = %s, want %s", tt.size, got, tt.want)
+		}
+	}
+	if got := ids(without(ready, nextBatch(ready, 5))); got != "b" {
+		t.Errorf("without(batch) = %s, want b", got)
+	}
+}
+
+func TestEngineer_CheckMergeWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := `{"merge_queue": {"merge_window": ["Mon-Fri 09:00-17:00"]}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	// 2026-01-10 is a Saturday
+	weekend := time.Date(2026, 1, 10, 12, 0, 0, 0, time.Local)
+	e.now = func() time.Time { return weekend }
+	err := e.CheckMergeWindow()
+	if !errors.Is(err, ErrMergeWindowClosed) {
+		t.Fatalf("CheckMergeWindow(Saturday) = %v, want ErrMergeWindowClosed", err)
+	}
+	if !strings.Contains(err.Error(), "Mon 09:00") {
+		t.Errorf("error = %q, want the next opening", err)
+	}
+
+	// The merge step itself is gated, not just ProcessOnce
+	result := e.mergeAttempt(context.Background(), MergeMessageData{Branch: "polecat/nux", Target: "main"}, io.Discard)
+	if !result.Blocked || !strings.Contains(result.Error, "merge window closed") {
+		t.Errorf("mergeAttempt(Saturday) = %+v, want blocked by the merge window", result)
+	}
+	batch := []*mrqueue.MR{{ID: "gt-mr-a", Branch: "polecat/nux", Target: "main"}, {ID: "gt-mr-b", Branch: "polecat/toast", Target: "main"}}
+	if _, ok := e.mergeBatch(context.Background(), batch); ok {
+		t.Error("mergeBatch(Saturday) merged, want it left to the individual merges")
+	}
+
+	e.now = func() time.Time { return weekend.AddDate(0, 0, 2) }
+	if err := e.CheckMergeWindow(); err != nil {
+		t.Errorf("CheckMergeWindow(Monday noon) = %v, want open", err)
+	}
+
+	e.now = func() time.Time { return weekend }
+	e.SetIgnoreMergeWindow(true)
+	if err := e.CheckMergeWindow(); err != nil {
+		t.Errorf("CheckMergeWindow with the window ignored = %v, want nil", err)
+	}
+}
+
+func TestEngineer_LoadConfig_InvalidMergeWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := `{"merge_queue": {"merge_window": ["weekdays 9-5"]}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if err := e.LoadConfig(); err == nil {
+		t.Error("LoadConfig() with an invalid merge_window: want error")
+	}
+}
+
+func TestSortByHotScore(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Hour)
+	mrs := []*mrqueue.MR{
+		{ID: "p1-feature", Branch: "polecat/Toast", Target: "main", Priority: 1, CreatedAt: created},
+		{ID: "p3-release", Branch: "polecat/Nux", Target: "release/1.2", Priority: 3, CreatedAt: created},
+		{ID: "p2-feature", Branch: "polecat/Slit", Target: "main", Priority: 2, CreatedAt: created},
+	}
+
+	hot, err := config.ParseHotBranches([]string{"release/*"})
+	if err != nil {
+		t.Fatalf("ParseHotBranches: %v", err)
+	}
+	sortByHotScore(mrs, hot, now)
+
+	var got []string
+	for _, mr := range mrs {
+		got = append(got, mr.ID)
+	}
+	want := []string{"p3-release", "p1-feature", "p2-feature"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if mrs[0].Priority != 3 {
+		t.Errorf("stored priority changed to %d, want 3", mrs[0].Priority)
+	}
+}
+
+func TestWithoutDrafts(t *testing.T) {
+	mrs := []*mrqueue.MR{{ID: "gt-mr-a"}, {ID: "gt-mr-draft"}, {ID: "gt-mr-b"}}
+	kept := withoutDrafts(mrs, func(id string) bool { return id == "gt-mr-draft" })
+
+	var got []string
+	for _, mr := range kept {
+		got = append(got, mr.ID)
+	}
+	if want := "gt-mr-a,gt-mr-b"; strings.Join(got, ",") != want {
+		t.Errorf("withoutDrafts() = %v, want %s", got, want)
+	}
+}
+
+func TestEngineer_ListReadyMRs_SkipsDrafts(t *testing.T) {
+	// Fake bd: lists one draft and one ready MR bead; every call is logged
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "calls.log")
+	script := `#!/bin/sh
+echo "$@" >> ` + logPath + `
+printf '%s' '[{"id":"gt-mr-draft","status":"open","issue_type":"merge-request","description":"branch: polecat/Nux/a\ntarget: main\ndraft: true"},{"id":"gt-mr-ready","status":"open","issue_type":"merge-request","description":"branch: polecat/Toast/b\ntarget: main"}]'
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	for _, id := range []string{"gt-mr-draft", "gt-mr-ready"} {
+		if err := e.mrQueue.Submit(&mrqueue.MR{ID: id, Branch: "polecat/Nux/" + id, Target: "main"}); err != nil {
+			t.Fatalf("Submit(%s): %v", id, err)
+		}
+	}
+
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		t.Fatalf("ListReadyMRs: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != "gt-mr-ready" {
+		t.Errorf("ListReadyMRs() = %v, want only gt-mr-ready", ready)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read bd calls: %v", err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(calls) != 1 || !strings.Contains(calls[0], "list --json") {
+		t.Errorf("drafts should come from a single bd list; calls:\n%s", data)
+	}
+}
+
+func TestEngineer_LoadConfig_InvalidHotBranches(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := `{"merge_queue": {"hot_branches": ["release/["]}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if err := e.LoadConfig(); !errors.Is(err, config.ErrInvalidHotBranch) {
+		t.Errorf("LoadConfig() = %v, want %v", err, config.ErrInvalidHotBranch)
+	}
+}
+
+func TestNewMergedNotice(t *testing.T) {
+	msg := newMergedNotice("gastown", "gt-mr-1", "Toast", "polecat/Toast", "main", "gt-abc", "abc1234")
+	if msg.From != "gastown/refinery" || msg.To != "gastown/Toast" {
+		t.Errorf("From/To = %s/%s, want gastown/refinery to gastown/Toast", msg.From, msg.To)
+	}
+	for _, want := range []string{"MR: gt-mr-1", "Target: main", "Commit: abc1234", "Issue: gt-abc"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, msg.Body)
+		}
+	}
+}
+
+func TestEngineer_LoadConfig_NotifyOnMerge(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if e.Config().NotifyOnMerge {
+		t.Error("NotifyOnMerge should default to off")
+	}
+
+	cfg := `{"merge_queue": {"notify_on_merge": true}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if !e.Config().NotifyOnMerge {
+		t.Error("NotifyOnMerge = false, want true from config")
+	}
+}
+
+func TestEngineer_LoadConfig_MRIDPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if e.Config().MRIDPrefix != "" {
+		t.Errorf("MRIDPrefix should default to empty, got %q", e.Config().MRIDPrefix)
+	}
+
+	cfg := `{"merge_queue": {"mr_id_prefix": "acme"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.Config().MRIDPrefix != "acme" {
+		t.Errorf("MRIDPrefix = %q, want acme", e.Config().MRIDPrefix)
+	}
+
+	cfg = `{"merge_queue": {"mr_id_prefix": "Acme_MR"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("LoadConfig() should reject an invalid mr_id_prefix")
+	}
+}
+
+func TestEngineer_LoadConfig_SignaturePolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if e.Config().SignaturePolicy != config.SignaturePolicyOff {
+		t.Errorf("SignaturePolicy should default to off, got %q", e.Config().SignaturePolicy)
+	}
+
+	cfg := `{"merge_queue": {"signature_policy": "block", "trusted_signing_keys": ["SHA256:abc"]}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.Config().SignaturePolicy != "block" || len(e.Config().TrustedSigningKeys) != 1 {
+		t.Errorf("config = %q %v, want block with one trusted key", e.Config().SignaturePolicy, e.Config().TrustedSigningKeys)
+	}
+
+	cfg = `{"merge_queue": {"signature_policy": "strict"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("LoadConfig() should reject an invalid signature_policy")
+	}
+}
+
+func TestEngineer_TransitionSourceIssue(t *testing.T) {
+	// Fake bd: every call is logged
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "calls.log")
+	script := `#!/bin/sh
+echo "$@" >> ` + logPath + `
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	calls := func() string {
+		t.Helper()
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			t.Fatalf("read bd calls: %v", err)
+		}
+		_ = os.Remove(logPath)
+		return string(data)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+	e.SetOutput(io.Discard)
+
+	e.transitionSourceIssue("gt-task", "gt-mr-1")
+	if log := calls(); !strings.Contains(log, "close gt-task --reason=Merged in gt-mr-1") || strings.Contains(log, "update") {
+		t.Errorf("default transition should only close the issue; calls:\n%s", log)
+	}
+
+	e.config.MergedIssueStatus = "deferred"
+	e.config.MergedIssueLabels = []string{"pending-deploy"}
+	e.transitionSourceIssue("gt-task", "gt-mr-1")
+	log := calls()
+	for _, want := range []string{"update gt-task", "--status=deferred", "--add-label=pending-deploy"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("transition to deferred should run %q; calls:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "close") {
+		t.Errorf("transition to deferred should not close the issue; calls:\n%s", log)
+	}
+}
+
+func TestEngineer_LoadConfig_MergedIssueStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := `{"merge_queue": {"merged_issue_status": "deferred", "merged_issue_labels": ["pending-deploy"]}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+	if e.Config().MergedIssueStatus != "closed" {
+		t.Errorf("MergedIssueStatus should default to closed, got %q", e.Config().MergedIssueStatus)
+	}
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.Config().MergedIssueStatus != "deferred" || len(e.Config().MergedIssueLabels) != 1 {
+		t.Errorf("config = %q %v, want deferred with one label", e.Config().MergedIssueStatus, e.Config().MergedIssueLabels)
+	}
+
+	cfg = `{"merge_queue": {"merged_issue_status": "deployed"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("LoadConfig() should reject a status beads doesn't accept")
+	}
+}
+
+func TestEngineer_LoadConfig_PushRetries(t *testing.T) {
+	tmpDir := t.TempDir()
+	for cfg, want := range map[string]int{
+		`{"merge_queue": {}}`:                  DefaultPushRetries,
+		`{"merge_queue": {"push_retries": 0}}`: 0,
+		`{"merge_queue": {"push_retries": 5}}`: 5,
+	} {
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		e := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir})
+		if err := e.LoadConfig(); err != nil {
+			t.Fatalf("LoadConfig(%s): %v", cfg, err)
+		}
+		if e.Config().PushRetries != want {
+			t.Errorf("LoadConfig(%s) PushRetries = %d, want %d", cfg, e.Config().PushRetries, want)
+		}
+	}
+
+	cfg := `{"merge_queue": {"push_retries": 11}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "testrig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("LoadConfig() should reject push_retries above the maximum")
+	}
+}
+
+func TestEngineer_PushTarget(t *testing.T) {
+	origin := t.TempDir()
+	repo := t.TempDir()
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run(origin, "init", "--bare", "-b", "main")
+	run(repo, "init", "-b", "main")
+	run(repo, "config", "user.email", "test@test.com")
+	run(repo, "config", "user.name", "Test User")
+	run(repo, "commit", "--allow-empty", "-m", "initial")
+
+	var out bytes.Buffer
+	e := NewEngineer(&rig.Rig{Name: "testrig", Path: repo})
+	e.SetOutput(&out)
+	e.pushBackoff = time.Millisecond
+	e.config.PushRetries = 2
+	g := git.NewGit(repo)
+
+	// Nothing listens on port 1: a network failure, retried, then reported
+	run(repo, "remote", "add", "origin", "http://127.0.0.1:1/none.git")
+	err := e.pushTarget(context.Background(), g, "main")
+	if !errors.Is(err, git.ErrNetwork) {
+		t.Fatalf("pushTarget to unreachable remote = %v, want ErrNetwork", err)
+	}
+	if got := strings.Count(out.String(), "retrying in"); got != 2 {
+		t.Errorf("retried %d times, want 2; output:\n%s", got, out.String())
+	}
+
+	// A rejected push is never retried
+	run(repo, "remote", "set-url", "origin", origin)
+	run(repo, "push", "origin", "main")
+	run(repo, "commit", "--allow-empty", "--amend", "-m", "rewritten")
+	out.Reset()
+	err = e.pushTarget(context.Background(), g, "main")
+	if err == nil || errors.Is(err, git.ErrNetwork) {
+		t.Fatalf("pushTarget non-fast-forward = %v, want a rejection", err)
+	}
+	if strings.Contains(out.String(), "retrying") {
+		t.Errorf("rejected push was retried:\n%s", out.String())
+	}
+
+	// A cancelled context stops the retries
+	run(repo, "remote", "set-url", "origin", "http://127.0.0.1:1/none.git")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.pushBackoff = time.Hour
+	if err := e.pushTarget(ctx, g, "main"); !errors.Is(err, git.ErrNetwork) || !strings.Contains(err.Error(), "retry cancelled") {
+		t.Errorf("pushTarget with cancelled context = %v, want cancelled network error", err)
+	}
+}
+
+func TestSignatureTrusted(t *testing.T) {
+	gpg := &git.CommitSignature{Code: "G", Signer: "Alice", Key: "0123456789ABCDEF", Fingerprint: "AAAABBBBCCCCDDDD0123456789ABCDEF"}
+	unknown := &git.CommitSignature{Code: "U", Key: "0123456789ABCDEF", Fingerprint: "AAAABBBBCCCCDDDD0123456789ABCDEF"}
+	ssh := &git.CommitSignature{Code: "G", Key: "SHA256:AbCdEf"}
+	tests := []struct {
+		name    string
+		sig     *git.CommitSignature
+		trusted []string
+		want    bool
+	}{
+		{"unsigned", &git.CommitSignature{Code: "N"}, nil, false},
+		{"bad signature", &git.CommitSignature{Code: "B", Key: "0123456789ABCDEF"}, []string{"0123456789ABCDEF"}, false},
+		{"revoked key", &git.CommitSignature{Code: "R", Key: "0123456789ABCDEF"}, []string{"0123456789ABCDEF"}, false},
+		{"git-trusted, no list", gpg, nil, true},
+		{"unknown validity, no list", unknown, nil, false},
+		{"unknown validity, listed", unknown, []string{"0123456789abcdef"}, true},
+		{"listed by fingerprint with spaces", gpg, []string{"AAAA BBBB CCCC DDDD 0123 4567 89AB CDEF"}, true},
+		{"not listed", gpg, []string{"FEDCBA9876543210"}, false},
+		{"short key ID", gpg, []string{"89ABCDEF"}, false},
+		{"one character", gpg, []string{"F"}, false},
+		{"long key ID of fingerprint", gpg, []string{"0123456789ABCDEF"}, true},
+		{"not hex", gpg, []string{"XXXXXXXX0123456789ABCDEF"}, false},
+		{"ssh listed", ssh, []string{"SHA256:AbCdEf"}, true},
+		{"ssh case differs", ssh, []string{"SHA256:abcdef"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, detail := SignatureTrusted(tt.sig, tt.trusted)
+			if got != tt.want {
+				t.Errorf("SignatureTrusted() = %v (%s), want %v", got, detail, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package refinery
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// ErrInvalidHold is returned for a hold pattern that can't be matched.
+var ErrInvalidHold = errors.New("invalid hold pattern")
+
+// Holds are the rig's held MR patterns: each an MR ID or a glob matched
+// against MR IDs and source branches (e.g. "gt-mr-abc", "polecat/Nux/*").
+// The refinery skips held MRs however ready they are otherwise.
+type Holds []string
+
+// ValidateHold checks that pattern is usable as a hold.
+func ValidateHold(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("%w: empty pattern", ErrInvalidHold)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidHold, pattern, err)
+	}
+	return nil
+}
+
+// Match returns the first hold matching an MR by ID or source branch, or ""
+// if it isn't held.
+func (h Holds) Match(mrID, branch string) string {
+	for _, p := range h {
+		if holdMatches(p, mrID, branch) {
+			return p
+		}
+	}
+	return ""
+}
+
+// holdMatches reports whether a hold pattern matches an MR's ID or source
+// branch. Empty values never match.
+func holdMatches(pattern, mrID, branch string) bool {
+	for _, s := range []string{mrID, branch} {
+		if s == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HeldPattern is a hold and the open MRs it matches right now.
+type HeldPattern struct {
+	Pattern string   `json:"pattern"`
+	Matches []string `json:"matches"`
+}
+
+// Holds returns the rig's held MR patterns.
+func (m *Manager) Holds() (Holds, error) {
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	return Holds(ref.Holds), nil
+}
+
+// holds is Holds for callers that skip held MRs as a best effort: if the
+// refinery state can't be read, nothing is held.
+func (m *Manager) holds() Holds {
+	holds, _ := m.Holds()
+	return holds
+}
+
+// Hold adds a pattern to the rig's holds. Holds live in the refinery state,
+// so they persist across restarts until removed with Unhold. Returns false
+// if the pattern was already held.
+func (m *Manager) Hold(pattern string) (bool, error) {
+	if err := ValidateHold(pattern); err != nil {
+		return false, err
+	}
+	ref, err := m.loadState()
+	if err != nil {
+		return false, err
+	}
+	for _, p := range ref.Holds {
+		if p == pattern {
+			return false, nil
+		}
+	}
+	ref.Holds = append(ref.Holds, pattern)
+	return true, m.saveState(ref)
+}
+
+// Unhold removes a pattern from the rig's holds. Returns false if it wasn't
+// held.
+func (m *Manager) Unhold(pattern string) (bool, error) {
+	ref, err := m.loadState()
+	if err != nil {
+		return false, err
+	}
+	kept := ref.Holds[:0]
+	for _, p := range ref.Holds {
+		if p != pattern {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(ref.Holds) {
+		return false, nil
+	}
+	ref.Holds = kept
+	if len(ref.Holds) == 0 {
+		ref.Holds = nil
+	}
+	return true, m.saveState(ref)
+}
+
+// HeldPatterns returns each of the given patterns with the open MRs it
+// matches, in order.
+func (m *Manager) HeldPatterns(patterns Holds) ([]HeldPattern, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issues, err := b.List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "open",
+		Priority: -1, // No priority filter
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying merge queue from beads: %w", err)
+	}
+	return matchHolds(patterns, issues), nil
+}
+
+// matchHolds pairs each pattern with the MR issues it matches.
+func matchHolds(patterns Holds, issues []*beads.Issue) []HeldPattern {
+	held := make([]HeldPattern, 0, len(patterns))
+	for _, p := range patterns {
+		h := HeldPattern{Pattern: p, Matches: []string{}}
+		for _, issue := range issues {
+			branch := ""
+			if fields := beads.ParseMRFields(issue); fields != nil {
+				branch = fields.Branch
+			}
+			if holdMatches(p, issue.ID, branch) {
+				h.Matches = append(h.Matches, issue.ID)
+			}
+		}
+		held = append(held, h)
+	}
+	return held
+}
+
+// withoutHeld returns mrs minus those matched by holds.
+func withoutHeld(mrs []*mrqueue.MR, holds Holds) []*mrqueue.MR {
+	if len(holds) == 0 {
+		return mrs
+	}
+	kept := mrs[:0]
+	for _, mr := range mrs {
+		if holds.Match(mr.ID, mr.Branch) == "" {
+			kept = append(kept, mr)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,464 @@
+//go:build integration
+
+// Integration tests for the refinery against a real git rig on disk.
+//
+// Run with: go test -tags=integration ./internal/refinery -v
+package refinery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// testRig is a throwaway rig backed by real git repositories:
+//
+//	<tmp>/origin.git     bare repo standing in for the remote
+//	<tmp>/<name>         the rig: a clone of origin, where the refinery merges
+//	<tmp>/<name>/.repo.git  the shared repo polecat worktrees are created from
+//
+// .repo.git points at the rig clone's .git, so worker branches are local to
+// the refinery exactly as they are with a real shared bare repo.
+type testRig struct {
+	t      *testing.T
+	Rig    *rig.Rig
+	Origin string
+	Git    *git.Git
+}
+
+// newTestRig creates a rig on disk with an initial commit on main. Everything
+// lives under t.TempDir() and is removed when the test ends.
+func newTestRig(t *testing.T, name string) *testRig {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmp := t.TempDir()
+
+	// Seed repo with an initial commit on main
+	seed := filepath.Join(tmp, "seed")
+	runGit(t, "", "init", "--initial-branch=main", seed)
+	configGitUser(t, seed)
+	writeFile(t, filepath.Join(seed, "README.md"), "# "+name+"\n")
+	runGit(t, seed, "add", ".")
+	runGit(t, seed, "commit", "-m", "Initial commit")
+
+	origin := filepath.Join(tmp, "origin.git")
+	runGit(t, "", "clone", "--bare", seed, origin)
+
+	rigPath := filepath.Join(tmp, name)
+	runGit(t, "", "clone", origin, rigPath)
+	configGitUser(t, rigPath)
+	if err := os.Symlink(filepath.Join(rigPath, ".git"), filepath.Join(rigPath, ".repo.git")); err != nil {
+		t.Fatalf("linking .repo.git: %v", err)
+	}
+	// Keep rig bookkeeping out of the refinery's working tree
+	writeFile(t, filepath.Join(rigPath, ".git", "info", "exclude"),
+		".repo.git\npolecats/\n.runtime/\n.beads/\n")
+
+	r := &rig.Rig{Name: name, Path: rigPath}
+	return &testRig{t: t, Rig: r, Origin: origin, Git: git.NewGit(rigPath)}
+}
+
+// AddWorker creates a polecat worktree on a fresh branch, the way
+// 'gt sling' does, and returns it.
+func (tr *testRig) AddWorker(name string) *polecat.Polecat {
+	tr.t.Helper()
+	p, err := polecat.NewManager(tr.Rig, tr.Git).Add(name)
+	if err != nil {
+		tr.t.Fatalf("adding worker %s: %v", name, err)
+	}
+	return p
+}
+
+// Commit writes a file in a worker's worktree and commits it.
+func (tr *testRig) Commit(p *polecat.Polecat, file, content string) {
+	tr.t.Helper()
+	writeFile(tr.t, filepath.Join(p.ClonePath, file), content)
+	runGit(tr.t, p.ClonePath, "add", file)
+	runGit(tr.t, p.ClonePath, "commit", "-m", "Update "+file)
+}
+
+// Submit queues a worker's branch for merge into target.
+func (tr *testRig) Submit(p *polecat.Polecat, target string) *mrqueue.MR {
+	tr.t.Helper()
+	mr := &mrqueue.MR{
+		Branch: p.Branch,
+		Target: target,
+		Worker: p.Name,
+		Rig:    tr.Rig.Name,
+		Title:  "Merge " + p.Branch,
+	}
+	if err := mrqueue.New(tr.Rig.Path).Submit(mr); err != nil {
+		tr.t.Fatalf("submitting %s: %v", p.Branch, err)
+	}
+	return mr
+}
+
+// Engineer returns a refinery engineer for the rig with output sent to the
+// test log.
+func (tr *testRig) Engineer() *Engineer {
+	e := NewEngineer(tr.Rig)
+	e.SetOutput(testLogWriter{tr.t})
+	return e
+}
+
+// OriginFile returns a file's content on a branch of the origin repo.
+func (tr *testRig) OriginFile(branch, file string) string {
+	tr.t.Helper()
+	return runGit(tr.t, "", "--git-dir="+tr.Origin, "show", branch+":"+file)
+}
+
+func TestIntegration_SubmitAndMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	worker := tr.AddWorker("Toast")
+	tr.Commit(worker, "feature.txt", "hello\n")
+	mr := tr.Submit(worker, "main")
+
+	processed, err := tr.Engineer().ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].ID != mr.ID || processed[0].Outcome != OutcomeMerged {
+		t.Fatalf("processed = %+v, want %s merged", processed, mr.ID)
+	}
+
+	if got := tr.OriginFile("main", "feature.txt"); got != "hello" {
+		t.Errorf("origin main:feature.txt = %q, want %q", got, "hello")
+	}
+	if pending, _ := mrqueue.New(tr.Rig.Path).List(); len(pending) != 0 {
+		t.Errorf("queue after merge = %d MRs, want empty", len(pending))
+	}
+}
+
+func TestIntegration_ConflictFails(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	first := tr.AddWorker("Toast")
+	second := tr.AddWorker("Nux")
+	tr.Commit(first, "README.md", "first\n")
+	tr.Commit(second, "README.md", "second\n")
+	tr.Submit(first, "main")
+
+	eng := tr.Engineer()
+	if _, err := eng.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("first ProcessOnce: %v", err)
+	}
+	mr := tr.Submit(second, "main")
+
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("second ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].ID != mr.ID || processed[0].Outcome != OutcomeConflict {
+		t.Fatalf("processed = %+v, want %s conflict", processed, mr.ID)
+	}
+	if got := tr.OriginFile("main", "README.md"); got != "first" {
+		t.Errorf("origin main:README.md = %q, want the first merge only", got)
+	}
+}
+
+func TestIntegration_IsolatedMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+	t.Setenv("TMPDIR", t.TempDir())
+	rigHead := runGit(t, tr.Rig.Path, "rev-parse", "HEAD")
+
+	first := tr.AddWorker("Toast")
+	second := tr.AddWorker("Nux")
+	tr.Commit(first, "README.md", "first\n")
+	tr.Commit(second, "README.md", "second\n")
+	tr.Submit(first, "main")
+
+	eng := tr.Engineer()
+	eng.config.IsolateMerges = true
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("first ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].Outcome != OutcomeMerged {
+		t.Fatalf("processed = %+v, want merged", processed)
+	}
+	if got := tr.OriginFile("main", "README.md"); got != "first" {
+		t.Errorf("origin main:README.md = %q, want %q", got, "first")
+	}
+
+	mr := tr.Submit(second, "main")
+	processed, err = eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("second ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].ID != mr.ID || processed[0].Outcome != OutcomeConflict {
+		t.Fatalf("processed = %+v, want %s conflict", processed, mr.ID)
+	}
+
+	// The rig's checkout was never used, and no merge worktree is left
+	if got := runGit(t, tr.Rig.Path, "rev-parse", "HEAD"); got != rigHead {
+		t.Errorf("rig HEAD = %s, want %s (untouched)", got, rigHead)
+	}
+	if got := runGit(t, tr.Rig.Path, "worktree", "list"); strings.Contains(got, "gt-merge-") {
+		t.Errorf("merge worktree left behind:\n%s", got)
+	}
+}
+
+func TestIntegration_BatchMerge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	first := tr.AddWorker("Toast")
+	second := tr.AddWorker("Nux")
+	tr.Commit(first, "a.txt", "a\n")
+	tr.Commit(second, "b.txt", "b\n")
+	tr.Submit(first, "main")
+	tr.Submit(second, "main")
+
+	eng := tr.Engineer()
+	eng.Config().BatchSize = 2
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	if len(processed) != 2 {
+		t.Fatalf("processed = %+v, want 2 MRs", processed)
+	}
+	for _, p := range processed {
+		if p.Outcome != OutcomeMerged || len(p.Batch) != 2 {
+			t.Errorf("%s: outcome %s batch %v, want merged in a batch of 2", p.ID, p.Outcome, p.Batch)
+		}
+	}
+	if tr.OriginFile("main", "a.txt") != "a" || tr.OriginFile("main", "b.txt") != "b" {
+		t.Error("origin main is missing a batched change")
+	}
+}
+
+func TestIntegration_BatchConflictFallsBack(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	first := tr.AddWorker("Toast")
+	second := tr.AddWorker("Nux")
+	tr.Commit(first, "README.md", "first\n")
+	tr.Commit(second, "README.md", "second\n")
+	tr.Submit(first, "main")
+	tr.Submit(second, "main")
+
+	eng := tr.Engineer()
+	eng.Config().BatchSize = 2
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+
+	outcomes := map[string]int{}
+	for _, p := range processed {
+		outcomes[p.Outcome]++
+		if len(p.Batch) != 0 {
+			t.Errorf("%s: batch %v, want none after falling back", p.ID, p.Batch)
+		}
+	}
+	if outcomes[OutcomeMerged] != 1 || outcomes[OutcomeConflict] != 1 {
+		t.Errorf("outcomes = %v, want one merged and one conflict", outcomes)
+	}
+}
+
+// TestIntegration_ClaimedByOtherRefinery simulates a second refinery
+// sharing the rig's queue: an MR it holds is left alone until released.
+func TestIntegration_ClaimedByOtherRefinery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	worker := tr.AddWorker("Toast")
+	tr.Commit(worker, "feature.txt", "hello\n")
+	mr := tr.Submit(worker, "main")
+
+	other := mrqueue.New(tr.Rig.Path)
+	if err := other.Claim(mr.ID, "refinery-2"); err != nil {
+		t.Fatalf("Claim by refinery-2: %v", err)
+	}
+
+	eng := tr.Engineer()
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("first ProcessOnce: %v", err)
+	}
+	if len(processed) != 0 {
+		t.Fatalf("processed = %+v, want nothing while refinery-2 holds %s", processed, mr.ID)
+	}
+
+	if err := other.Release(mr.ID); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	processed, err = eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("second ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].ID != mr.ID || processed[0].Outcome != OutcomeMerged {
+		t.Fatalf("processed = %+v, want %s merged", processed, mr.ID)
+	}
+}
+
+func TestIntegration_SignaturePolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	for _, tt := range []struct {
+		policy string
+		want   string
+	}{
+		{config.SignaturePolicyBlock, OutcomeBlocked},
+		{config.SignaturePolicyReject, OutcomeFailed},
+	} {
+		t.Run(tt.policy, func(t *testing.T) {
+			tr := newTestRig(t, "testrig")
+			worker := tr.AddWorker("Toast")
+			tr.Commit(worker, "feature.txt", "hello\n")
+			mr := tr.Submit(worker, "main")
+
+			eng := tr.Engineer()
+			eng.config.SignaturePolicy = tt.policy
+			processed, err := eng.ProcessOnce(context.Background())
+			if err != nil {
+				t.Fatalf("ProcessOnce: %v", err)
+			}
+			if len(processed) != 1 || processed[0].Outcome != tt.want || !strings.Contains(processed[0].Error, "unsigned") {
+				t.Fatalf("processed = %+v, want %s %s as unsigned", processed, mr.ID, tt.want)
+			}
+			if exec.Command("git", "--git-dir="+tr.Origin, "cat-file", "-e", "main:feature.txt").Run() == nil {
+				t.Error("unsigned MR was merged")
+			}
+		})
+	}
+}
+
+func TestIntegration_MergesCheckedCommit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	worker := tr.AddWorker("Toast")
+	tr.Commit(worker, "feature.txt", "hello\n")
+	tr.Submit(worker, "main")
+
+	// The worker pushes another commit to the branch while tests run
+	eng := tr.Engineer()
+	eng.Config().RunTests = true
+	eng.Config().TestCommand = "cd '" + worker.ClonePath + "' && echo late > late.txt && git add late.txt && git commit -qm late"
+	processed, err := eng.ProcessOnce(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessOnce: %v", err)
+	}
+	if len(processed) != 1 || processed[0].Outcome != OutcomeMerged {
+		t.Fatalf("processed = %+v, want merged", processed)
+	}
+	if got := tr.OriginFile("main", "feature.txt"); got != "hello" {
+		t.Errorf("main:feature.txt = %q, want the checked commit merged", got)
+	}
+	if exec.Command("git", "--git-dir="+tr.Origin, "cat-file", "-e", "main:late.txt").Run() == nil {
+		t.Error("commit added to the branch after the checks was merged")
+	}
+}
+
+func TestIntegration_Revalidate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	tr := newTestRig(t, "testrig")
+
+	worker := tr.AddWorker("Toast")
+	tr.Commit(worker, "feature.txt", "hello\n")
+
+	eng := tr.Engineer()
+	eng.Config().RunTests = true
+	eng.Config().TestCommand = "test -f feature.txt"
+	results, err := eng.Revalidate(context.Background(), worker.Branch, "main", io.Discard)
+	if err != nil {
+		t.Fatalf("Revalidate: %v", err)
+	}
+	if len(results) != 3 || results[2].Gate != GateTests || !results[2].Passed {
+		t.Errorf("results = %+v, want branch, conflicts and tests passed", results)
+	}
+
+	eng.Config().TestCommand = "false"
+	results, err = eng.Revalidate(context.Background(), worker.Branch, "main", io.Discard)
+	if !errors.Is(err, ErrGateFailed) {
+		t.Fatalf("Revalidate with failing tests = %v, want ErrGateFailed", err)
+	}
+	if last := results[len(results)-1]; last.Gate != GateTests || last.Passed {
+		t.Errorf("last gate = %+v, want failed tests", last)
+	}
+	if branch := runGit(t, tr.Rig.Path, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("refinery left on %q after revalidating, want main", branch)
+	}
+
+	_, err = eng.Revalidate(context.Background(), "polecat/nobody", "main", io.Discard)
+	if !errors.Is(err, ErrGateFailed) || !strings.Contains(err.Error(), GateBranch) {
+		t.Errorf("Revalidate of missing branch = %v, want branch gate failure", err)
+	}
+}
+
+// runGit runs git in dir (the test's cwd if empty) and returns its trimmed
+// output, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// configGitUser sets a commit identity so commits work on bare CI machines.
+func configGitUser(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// testLogWriter sends engineer output to the test log.
+type testLogWriter struct{ t *testing.T }
+
+func (w testLogWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+var _ io.Writer = testLogWriter{}
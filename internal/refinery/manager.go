@@ -16,12 +16,15 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/mq"
 	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/wisp"
 )
 
 // Common errors
@@ -29,6 +32,7 @@ var (
 	ErrNotRunning     = errors.New("refinery not running")
 	ErrAlreadyRunning = errors.New("refinery already running")
 	ErrNoQueue        = errors.New("no items in queue")
+	ErrNothingReady   = errors.New("nothing ready to merge")
 )
 
 // Manager handles refinery lifecycle and queue operations.
@@ -101,10 +105,57 @@ func (m *Manager) Status() (*Refinery, error) {
 	return m.loadState()
 }
 
+// RecordCycleResult persists the outcome of a refinery cycle. A non-nil err
+// is stored as the refinery's last error so CLI users can see that the
+// refinery itself is wedged; a nil err clears it.
+func (m *Manager) RecordCycleResult(cycleErr error) error {
+	ref, err := m.loadState()
+	if err != nil {
+		return err
+	}
+
+	if cycleErr == nil {
+		if ref.LastError == "" {
+			return nil
+		}
+		ref.LastError = ""
+		ref.LastErrorAt = nil
+	} else {
+		now := time.Now()
+		ref.LastError = cycleErr.Error()
+		ref.LastErrorAt = &now
+	}
+
+	return m.saveState(ref)
+}
+
+// SetCurrentMR publishes the MR the refinery is merging right now, and the
+// rest of its batch if any, so 'gt mq list' can show it. A nil mr clears it.
+func (m *Manager) SetCurrentMR(mr *MergeRequest, batch []string) error {
+	ref, err := m.loadState()
+	if err != nil {
+		return err
+	}
+
+	if mr == nil {
+		if ref.CurrentMR == nil {
+			return nil
+		}
+		ref.CurrentMR, ref.CurrentBatch, ref.CurrentPID = nil, nil, 0
+	} else {
+		ref.CurrentMR, ref.CurrentBatch, ref.CurrentPID = mr, batch, os.Getpid()
+	}
+	return m.saveState(ref)
+}
+
 // Start starts the refinery.
 // If foreground is true, runs in the current process (blocking) using the Go-based polling loop.
 // Otherwise, spawns a Claude agent in a tmux session to process the merge queue.
 func (m *Manager) Start(foreground bool) error {
+	if err := m.checkNotPaused(); err != nil {
+		return err
+	}
+
 	ref, err := m.loadState()
 	if err != nil {
 		return err
@@ -321,13 +372,14 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 
 	// Score and sort issues by priority score (highest first)
 	now := time.Now()
+	hot := m.hotBranches()
 	type scoredIssue struct {
 		issue *beads.Issue
 		score float64
 	}
 	scored := make([]scoredIssue, 0, len(issues))
 	for _, issue := range issues {
-		score := m.calculateIssueScore(issue, now)
+		score := IssueScore(issue, now, hot)
 		scored = append(scored, scoredIssue{issue: issue, score: score})
 	}
 
@@ -347,6 +399,7 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 				Position: pos,
 				MR:       mr,
 				Age:      formatAge(mr.CreatedAt),
+				Issue:    s.issue,
 			})
 			pos++
 		}
@@ -355,9 +408,92 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 	return items, nil
 }
 
-// calculateIssueScore computes the priority score for an MR issue.
-// Higher scores mean higher priority (process first).
-func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64 {
+// NextMR is the merge request the refinery will pick on its next cycle.
+type NextMR struct {
+	Issue *beads.Issue // The MR bead
+	Score float64      // Priority score (same scoring as Queue)
+	Ready int          // Number of ready MRs, including this one
+}
+
+// Next returns the open MR the refinery will merge on its next cycle.
+// It applies the same ordering as Queue (priority, convoy age, retry backoff,
+// MR age) after skipping blocked MRs and the MR currently being processed.
+// Returns an error wrapping ErrNothingReady, with the reason, if none qualifies.
+func (m *Manager) Next() (*NextMR, error) {
+	return m.next(false)
+}
+
+// NextFIFO is like Next, but picks the oldest ready MR instead of the
+// highest-scoring one. The score is still reported.
+func (m *Manager) NextFIFO() (*NextMR, error) {
+	return m.next(true)
+}
+
+// next implements Next and NextFIFO.
+func (m *Manager) next(fifo bool) (*NextMR, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issues, err := b.List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "open",
+		Priority: -1, // No priority filter
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying merge queue from beads: %w", err)
+	}
+
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	currentID := ""
+	if len(ref.Merging()) > 0 {
+		currentID = ref.CurrentMR.ID
+	}
+
+	return m.pickNext(issues, currentID, time.Now(), fifo)
+}
+
+// pickNext selects the highest-scoring ready MR from issues, or with fifo
+// the oldest one.
+func (m *Manager) pickNext(issues []*beads.Issue, currentID string, now time.Time, fifo bool) (*NextMR, error) {
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("%w: queue is empty", ErrNothingReady)
+	}
+
+	var next *NextMR
+	blocked, ready := 0, 0
+	for _, e := range m.planQueue(issues, currentID, now) {
+		switch e.Decision {
+		case PlanReady:
+			ready++
+			if next == nil || (fifo && parseTime(e.issue.CreatedAt).Before(parseTime(next.Issue.CreatedAt))) {
+				next = &NextMR{Issue: e.issue, Score: e.Score}
+			}
+		case PlanBlocked:
+			blocked++
+		}
+	}
+
+	if next == nil {
+		switch {
+		case blocked > 0 && currentID != "":
+			return nil, fmt.Errorf("%w: %s is being processed and %d MR(s) are blocked", ErrNothingReady, currentID, blocked)
+		case blocked > 0:
+			return nil, fmt.Errorf("%w: all %d open MR(s) are blocked", ErrNothingReady, blocked)
+		case currentID != "":
+			return nil, fmt.Errorf("%w: %s is being processed", ErrNothingReady, currentID)
+		default:
+			return nil, fmt.Errorf("%w: every open MR is a draft or held", ErrNothingReady)
+		}
+	}
+	next.Ready = ready
+	return next, nil
+}
+
+// IssueScore computes the priority score the refinery orders an MR issue
+// by. Higher scores mean higher priority (process first); MRs on a hot
+// branch are scored as HotPriority.
+func IssueScore(issue *beads.Issue, now time.Time, hot config.HotBranches) float64 {
 	fields := beads.ParseMRFields(issue)
 
 	// Parse MR creation time
@@ -383,11 +519,25 @@ func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64
 				input.ConvoyCreatedAt = &convoyTime
 			}
 		}
+
+		if hot.Match(fields.Target, fields.Branch) {
+			input.Priority = HotPriority
+		}
 	}
 
 	return mrqueue.ScoreMRWithDefaults(input)
 }
 
+// hotBranches returns the rig's merge_queue.hot_branches, or nil if the
+// config can't be loaded.
+func (m *Manager) hotBranches() config.HotBranches {
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return nil
+	}
+	return eng.Config().HotBranches
+}
+
 // issueToMR converts a beads issue to a MergeRequest.
 func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 	if issue == nil {
@@ -406,6 +556,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 			Status:       MROpen,
 			CreatedAt:    parseTime(issue.CreatedAt),
 			TargetBranch: defaultBranch,
+			Priority:     issue.Priority,
 		}
 	}
 
@@ -423,6 +574,7 @@ func (m *Manager) issueToMR(issue *beads.Issue) *MergeRequest {
 		TargetBranch: target,
 		Status:       MROpen,
 		CreatedAt:    parseTime(issue.CreatedAt),
+		Priority:     issue.Priority,
 	}
 }
 
@@ -612,28 +764,43 @@ Then the Refinery will retry the merge.`,
 	_ = router.Send(msg) // best-effort notification
 }
 
-// notifyWorkerMerged sends a success notification to a polecat.
-func (m *Manager) notifyWorkerMerged(mr *MergeRequest) {
-	router := mail.NewRouter(m.workDir)
-	msg := &mail.Message{
-		From:    fmt.Sprintf("%s/refinery", m.rig.Name),
-		To:      fmt.Sprintf("%s/%s", m.rig.Name, mr.Worker),
-		Subject: "Work merged successfully",
-		Body: fmt.Sprintf(`Your branch %s has been merged to %s.
-
-Issue: %s
-Thank you for your contribution!`,
-			mr.Branch, mr.TargetBranch, mr.IssueID),
-	}
-	_ = router.Send(msg) // best-effort notification
-}
-
-// Common errors for MR operations
+// Common errors for MR operations.
+// Returned errors may wrap these with detail; check them with errors.Is.
 var (
 	ErrMRNotFound  = errors.New("merge request not found")
 	ErrMRNotFailed = errors.New("merge request has not failed")
+	ErrMRBlocked   = errors.New("merge request is blocked")
+	ErrMRClosed    = errors.New("merge request is closed")
+	ErrRigPaused   = errors.New("rig is paused")
+	ErrConflict    = errors.New("merge conflict")
+
+	// ErrMRAlreadyClosed is returned when rejecting an MR that has already
+	// been merged or closed.
+	ErrMRAlreadyClosed = errors.New("merge request already closed")
+
+	// ErrTargetNotFound is returned when retargeting an MR at a branch that
+	// doesn't exist on the remote.
+	ErrTargetNotFound = errors.New("target branch not found on remote")
+
+	// ErrSupersederNotFound is returned when rejecting an MR as superseded
+	// by an MR that doesn't exist.
+	ErrSupersederNotFound = errors.New("superseding MR not found")
 )
 
+// checkNotPaused returns ErrRigPaused if the rig is parked or docked.
+// Only the local wisp status is consulted (no beads round-trip).
+func (m *Manager) checkNotPaused() error {
+	townRoot := findTownRoot(m.rig.Path)
+	if townRoot == "" {
+		return nil
+	}
+	status := strings.ToLower(wisp.NewConfig(townRoot, m.rig.Name).GetString("status"))
+	if status == "parked" || status == "docked" {
+		return fmt.Errorf("%w: %s is %s", ErrRigPaused, m.rig.Name, status)
+	}
+	return nil
+}
+
 // GetMR returns a merge request by ID from the state.
 func (m *Manager) GetMR(id string) (*MergeRequest, error) {
 	ref, err := m.loadState()
@@ -656,6 +823,38 @@ func (m *Manager) GetMR(id string) (*MergeRequest, error) {
 	return nil, ErrMRNotFound
 }
 
+// ShowMR returns the MR with the given ID whatever its status, from its
+// bead, along with the bead. An MR waiting for a retry carries its last
+// error from the refinery's state.
+func (m *Manager) ShowMR(id string) (*MergeRequest, *beads.Issue, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(id)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrMRNotFound, id)
+		}
+		return nil, nil, fmt.Errorf("fetching MR %s: %w", id, err)
+	}
+	if issue.Type != "merge-request" {
+		return nil, nil, fmt.Errorf("%w: %s is a %s, not a merge request", ErrMRNotFound, id, issue.Type)
+	}
+
+	mr := m.issueToMR(issue)
+	switch issue.Status {
+	case "closed":
+		mr.Status = MRClosed
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			mr.CloseReason = CloseReason(fields.CloseReason)
+		}
+	case "in_progress":
+		mr.Status = MRInProgress
+	}
+	if state, err := m.GetMR(id); err == nil {
+		mr.Error = state.Error
+	}
+	return mr, issue, nil
+}
+
 // FindMR finds a merge request by ID or branch name in the queue.
 func (m *Manager) FindMR(idOrBranch string) (*MergeRequest, error) {
 	queue, err := m.Queue()
@@ -684,13 +883,102 @@ func (m *Manager) FindMR(idOrBranch string) (*MergeRequest, error) {
 	return nil, ErrMRNotFound
 }
 
+// ResolveMRID expands arg to a full MR ID if it is a unique prefix of one
+// of the rig's MR IDs (any status), as 'gt mq list' shows truncated IDs.
+// See ResolveMRIDIn.
+func (m *Manager) ResolveMRID(arg string) (string, error) {
+	return ResolveMRIDIn(beads.New(m.rig.BeadsPath()), arg)
+}
+
+// ResolveMRIDIn expands arg to the MR ID in b it is a unique prefix of. An
+// arg that is a full ID, or matches no MR (e.g. a branch name), is returned
+// unchanged for the caller to look up as before. Returns an error wrapping
+// mq.ErrAmbiguousID, listing the candidates, if arg is a prefix of several.
+func ResolveMRIDIn(b *beads.Beads, arg string) (string, error) {
+	ids, err := MRIDs(b)
+	if err != nil {
+		return arg, nil // Best-effort: the caller's own lookup reports the failure
+	}
+	id, err := mq.ResolveID(ids, arg)
+	switch {
+	case errors.Is(err, mq.ErrAmbiguousID):
+		return "", err
+	case err != nil:
+		return arg, nil
+	}
+	return id, nil
+}
+
+// MRIDs returns the IDs of every merge request in b, of any status.
+func MRIDs(b *beads.Beads) ([]string, error) {
+	issues, err := b.List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids, nil
+}
+
+// LowestPriority is the lowest MR priority (P4).
+const LowestPriority = 4
+
+// HotPriority is the priority MRs on a hot branch (merge_queue.hot_branches)
+// are scored at, whatever their own priority.
+const HotPriority = 0
+
+// FailedMRs returns the MRs waiting for a retry (open with an error),
+// oldest first.
+func (m *Manager) FailedMRs() ([]*MergeRequest, error) {
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []*MergeRequest
+	for _, mr := range ref.PendingMRs {
+		if mr.Status == MROpen && mr.Error != "" {
+			failed = append(failed, mr)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		if !failed[i].CreatedAt.Equal(failed[j].CreatedAt) {
+			return failed[i].CreatedAt.Before(failed[j].CreatedAt)
+		}
+		return failed[i].ID < failed[j].ID
+	})
+	return failed, nil
+}
+
+// RetryOptions configures a retry.
+type RetryOptions struct {
+	// ProcessNow is deprecated - the Refinery agent handles processing.
+	ProcessNow bool
+
+	// Deprioritize drops the MR one priority level (down to P4), so a
+	// persistently failing MR stops getting ahead of healthy ones.
+	Deprioritize bool
+}
+
 // Retry resets a failed merge request so it can be processed again.
-// The processNow parameter is deprecated - the Refinery agent handles processing.
 // Clearing the error is sufficient; the agent will pick up the MR in its next patrol cycle.
-func (m *Manager) Retry(id string, processNow bool) error {
+//
+// The MR keeps its priority, so it returns to its original place in the
+// queue, behind only the score's retry penalty. With opts.Deprioritize it
+// drops one level instead, on the MR bead as well as in state.
+//
+// It returns when the MR is next eligible to merge: the zero time if on the
+// next refinery cycle, else when the rig's merge window next opens.
+func (m *Manager) Retry(id string, opts RetryOptions) (time.Time, error) {
 	ref, err := m.loadState()
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	// Find the MR
@@ -699,30 +987,62 @@ func (m *Manager) Retry(id string, processNow bool) error {
 		mr = ref.PendingMRs[id]
 	}
 	if mr == nil {
-		return ErrMRNotFound
+		return time.Time{}, ErrMRNotFound
 	}
-
-	// Verify it's in a failed state (open with an error)
-	if mr.Status != MROpen || mr.Error == "" {
-		return ErrMRNotFailed
+	if err := m.checkRetryable(mr); err != nil {
+		return time.Time{}, err
 	}
 
 	// Clear the error to mark as ready for retry
 	mr.Error = ""
+	if opts.Deprioritize && mr.Priority < LowestPriority {
+		mr.Priority++
+		b := beads.New(m.rig.BeadsPath())
+		if err := b.Update(mr.ID, beads.UpdateOptions{Priority: &mr.Priority}); err != nil {
+			_, _ = fmt.Fprintf(m.output, "Warning: failed to update priority of MR bead %s: %v\n", mr.ID, err)
+		}
+	}
 
 	// Save the state
 	if err := m.saveState(ref); err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	// Note: processNow is deprecated (ZFC #5).
 	// The Refinery agent handles merge processing.
 	// It will pick up this MR in its next patrol cycle.
-	if processNow {
+	if opts.ProcessNow {
 		_, _ = fmt.Fprintln(m.output, "Note: --now is deprecated. The Refinery agent will process this MR in its next patrol cycle.")
 	}
 
-	return nil
+	return m.nextEligible(time.Now()), nil
+}
+
+// checkRetryable returns why Retry would refuse an MR, or nil if it can be
+// retried: it must be failed (open with an error) and the rig not paused.
+func (m *Manager) checkRetryable(mr *MergeRequest) error {
+	if mr.IsClosed() {
+		return fmt.Errorf("%w: closed with reason %s", ErrMRClosed, mr.CloseReason)
+	}
+	if mr.Status != MROpen || mr.Error == "" {
+		return ErrMRNotFailed
+	}
+	return m.checkNotPaused()
+}
+
+// nextEligible returns when a ready MR can next merge: the zero time if the
+// rig's merge window is open at now (or can't be loaded), else when it
+// next opens.
+func (m *Manager) nextEligible(now time.Time) time.Time {
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return time.Time{}
+	}
+	window := eng.Config().MergeWindow
+	if window.Open(now) {
+		return time.Time{}
+	}
+	return window.NextOpen(now)
 }
 
 // RegisterMR adds a merge request to the pending queue.
@@ -740,52 +1060,436 @@ func (m *Manager) RegisterMR(mr *MergeRequest) error {
 	return m.saveState(ref)
 }
 
+// RenameMRBranch points open MRs for oldBranch at newBranch, after a worker
+// rename moved the branch: their beads, their merge queue entries and the
+// refinery's pending state. If newWorker is non-empty, the MR's worker field
+// is updated too. Returns the number of MR beads updated.
+func (m *Manager) RenameMRBranch(oldBranch, newBranch, newWorker string) (int, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issues, err := b.List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "open",
+		Priority: -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("querying merge queue from beads: %w", err)
+	}
+
+	updated := 0
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil || fields.Branch != oldBranch {
+			continue
+		}
+		fields.Branch = newBranch
+		if newWorker != "" {
+			fields.Worker = newWorker
+		}
+		desc := beads.SetMRFields(issue, fields)
+		if err := b.Update(issue.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+			return updated, fmt.Errorf("updating MR %s: %w", issue.ID, err)
+		}
+		updated++
+	}
+
+	// And the queue entries the engineer merges from
+	q := mrqueue.New(m.rig.Path)
+	queued, err := q.List()
+	if err != nil {
+		return updated, fmt.Errorf("listing merge queue: %w", err)
+	}
+	for _, mr := range queued {
+		if mr.Branch != oldBranch {
+			continue
+		}
+		if err := q.SetBranch(mr.ID, newBranch, newWorker); err != nil && !errors.Is(err, mrqueue.ErrNotFound) {
+			return updated, fmt.Errorf("updating queued MR %s: %w", mr.ID, err)
+		}
+	}
+
+	// Keep local processing state in step
+	ref, err := m.loadState()
+	if err != nil {
+		return updated, err
+	}
+	changed := false
+	for _, mr := range ref.PendingMRs {
+		if mr.Branch == oldBranch {
+			mr.Branch = newBranch
+			if newWorker != "" {
+				mr.Worker = newWorker
+			}
+			changed = true
+		}
+	}
+	if changed {
+		if err := m.saveState(ref); err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
+
+// SetTarget points an open MR at a different target branch, e.g. another
+// epic's integration branch. The target must exist on origin and accept
+// direct merges, so the MR isn't left aimed at a branch it can never merge
+// into. The MR bead, the refinery state and the processing queue are all
+// updated. Returns the previous target.
+func (m *Manager) SetTarget(mrID, target string) (string, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(mrID)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrMRNotFound, mrID)
+		}
+		return "", fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+	if err := alreadyClosedError(issue); err != nil {
+		return "", err
+	}
+
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	old := fields.Target
+	if old == target {
+		return old, nil
+	}
+
+	if err := m.rig.CheckBranchUnprotected(target); err != nil {
+		return old, err
+	}
+	exists, err := git.NewGit(m.rig.Path).RemoteBranchExists("origin", target)
+	if err != nil {
+		return old, fmt.Errorf("checking origin/%s: %w", target, err)
+	}
+	if !exists {
+		return old, fmt.Errorf("%w: origin/%s", ErrTargetNotFound, target)
+	}
+
+	fields.Target = target
+	desc := beads.SetMRFields(issue, fields)
+	if err := b.Update(mrID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return old, fmt.Errorf("updating MR %s: %w", mrID, err)
+	}
+
+	// Keep local processing state and the queue in step
+	ref, err := m.loadState()
+	if err != nil {
+		return old, err
+	}
+	if mr := ref.PendingMRs[mrID]; mr != nil {
+		mr.TargetBranch = target
+		if err := m.saveState(ref); err != nil {
+			return old, err
+		}
+	}
+	if err := mrqueue.New(m.rig.Path).SetTarget(mrID, target); err != nil && !errors.Is(err, mrqueue.ErrNotFound) {
+		return old, fmt.Errorf("updating queued MR %s: %w", mrID, err)
+	}
+
+	return old, nil
+}
+
+// RejectOptions configures a manual rejection.
+type RejectOptions struct {
+	Reason   string // Free-text reason (required)
+	Category string // One of RejectCategories (optional)
+	Notify   bool   // Mail the worker about the rejection
+
+	// SupersededBy is the MR replacing the rejected one (optional). It must
+	// exist; the category defaults to "superseded".
+	SupersededBy string
+
+	// Force rejects the MR even if it has already merged or closed,
+	// overwriting its close record. Actor (who is rejecting) is recorded on
+	// the MR and in the audit log when it does.
+	Force bool
+	Actor string
+}
+
+// RejectResult describes a completed rejection.
+type RejectResult struct {
+	MR *MergeRequest
+
+	// ThreadID is the mail thread of the worker notification, if one was
+	// sent. The worker replies on this thread to discuss the rejection.
+	ThreadID string
+
+	// Overridden is the state check Force bypassed (e.g. the MR had
+	// merged), or empty if none was needed.
+	Overridden string
+}
+
 // RejectMR manually rejects a merge request.
-// It closes the MR with rejected status and optionally notifies the worker.
-// Returns the rejected MR for display purposes.
-func (m *Manager) RejectMR(idOrBranch string, reason string, notify bool) (*MergeRequest, error) {
-	mr, err := m.FindMR(idOrBranch)
+// It closes the MR with rejected status, records the reason and category on
+// the MR bead, and optionally notifies the worker. The notification's mail
+// thread is recorded on the MR too, linking any discussion back to it.
+func (m *Manager) RejectMR(idOrBranch string, opts RejectOptions) (*RejectResult, error) {
+	category, err := ValidateRejectCategory(opts.Category)
 	if err != nil {
 		return nil, err
 	}
+	reason := opts.Reason
+	if opts.SupersededBy != "" && category == "" {
+		category = "superseded"
+	}
 
-	// Verify MR is open or in_progress (can't reject already closed)
-	if mr.IsClosed() {
-		return nil, fmt.Errorf("%w: MR is already closed with reason: %s", ErrClosedImmutable, mr.CloseReason)
+	b := beads.New(m.rig.BeadsPath())
+	var overridden error // the state check opts.Force bypassed, if any
+	mr, err := m.FindMR(idOrBranch)
+	if err != nil {
+		// Closed MRs drop out of the queue: say so rather than "not found"
+		if errors.Is(err, ErrMRNotFound) {
+			if issue, showErr := b.Show(idOrBranch); showErr == nil {
+				if closedErr := alreadyClosedError(issue); closedErr != nil {
+					if !opts.Force {
+						return nil, closedErr
+					}
+					mr, overridden = m.issueToMR(issue), closedErr
+				}
+			}
+		}
+		if mr == nil {
+			return nil, err
+		}
+	}
+
+	if overridden == nil {
+		// Verify MR is open or in_progress (can't reject already closed)
+		var closedErr error
+		if mr.IsClosed() {
+			closedErr = fmt.Errorf("%w: %s (%s): %w", ErrMRAlreadyClosed, mr.ID, mr.CloseReason, ErrClosedImmutable)
+		} else if issue, err := b.Show(mr.ID); err == nil {
+			// The bead is authoritative: the MR may have merged since state was saved
+			closedErr = alreadyClosedError(issue)
+		}
+		if closedErr != nil {
+			if !opts.Force {
+				return nil, closedErr
+			}
+			overridden = closedErr
+		}
+	}
+
+	if opts.SupersededBy != "" {
+		if err := checkSuperseder(b, mr.ID, opts.SupersededBy); err != nil {
+			return nil, err
+		}
 	}
 
-	// Close with rejected reason
-	if err := mr.Close(CloseReasonRejected); err != nil {
+	// Close with rejected reason (a forced rejection overwrites the close)
+	forcedBy := ""
+	if overridden != nil {
+		forcedBy = opts.Actor
+		if forcedBy == "" {
+			forcedBy = "unknown"
+		}
+		mr.Status, mr.CloseReason = MRClosed, CloseReasonRejected
+	} else if err := mr.Close(CloseReasonRejected); err != nil {
 		return nil, fmt.Errorf("failed to close MR: %w", err)
 	}
 	mr.Error = reason
+	result := &RejectResult{MR: mr}
+	if overridden != nil {
+		result.Overridden = overridden.Error()
+	}
+
+	// Optionally notify worker (best-effort: the rejection stands regardless)
+	if opts.Notify {
+		threadID, err := m.notifyWorkerRejected(mr, reason)
+		if err != nil {
+			_, _ = fmt.Fprintf(m.output, "Warning: failed to notify %s: %v\n", mr.Worker, err)
+		} else {
+			result.ThreadID = threadID
+		}
+	}
+
+	if err := m.recordRejection(mr.ID, reason, category, result.ThreadID, opts.SupersededBy, forcedBy); err != nil {
+		return nil, err
+	}
+	if forcedBy != "" {
+		payload := events.MergePayload(mr.ID, mr.Worker, mr.Branch, reason)
+		payload["rig"] = m.rig.Name
+		payload["overridden"] = result.Overridden
+		// Audit in the rig's own town, which needn't be the cwd's
+		// (--rig-root, rig path args)
+		_ = events.LogAuditAt(findTownRoot(m.rig.Path), events.TypeRejectForced, forcedBy, payload)
+	}
+
+	if err := mrqueue.NewEventLoggerFromRig(m.rig.Path).LogEvent(mrqueue.Event{
+		Type:         mrqueue.EventRejected,
+		MRID:         mr.ID,
+		Branch:       mr.Branch,
+		Target:       mr.TargetBranch,
+		Worker:       mr.Worker,
+		SourceIssue:  mr.IssueID,
+		Rig:          m.rig.Name,
+		Reason:       reason,
+		SupersededBy: opts.SupersededBy,
+	}); err != nil {
+		_, _ = fmt.Fprintf(m.output, "Warning: failed to log rejection event: %v\n", err)
+	}
+
+	return result, nil
+}
 
-	// Optionally notify worker
-	if notify {
-		m.notifyWorkerRejected(mr, reason)
+// checkSuperseder checks that the MR superseding mrID is another MR bead
+// that exists.
+func checkSuperseder(b *beads.Beads, mrID, supersededBy string) error {
+	if supersededBy == mrID {
+		return fmt.Errorf("%s cannot supersede itself", mrID)
+	}
+	issue, err := b.Show(supersededBy)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrSupersederNotFound, supersededBy)
+		}
+		return fmt.Errorf("fetching MR %s: %w", supersededBy, err)
+	}
+	if issue.Type != "merge-request" {
+		return fmt.Errorf("%w: %s is a %s, not a merge request", ErrSupersederNotFound, supersededBy, issue.Type)
 	}
+	return nil
+}
 
-	return mr, nil
+// alreadyClosedError returns ErrMRAlreadyClosed, with the close reason, if
+// the MR bead is closed, so a rejection is never written over a completed MR.
+func alreadyClosedError(issue *beads.Issue) error {
+	if issue.Status != "closed" {
+		return nil
+	}
+	reason := "unknown"
+	if fields := beads.ParseMRFields(issue); fields != nil && fields.CloseReason != "" {
+		reason = fields.CloseReason
+	}
+	return fmt.Errorf("%w: %s (%s)", ErrMRAlreadyClosed, issue.ID, reason)
 }
 
-// notifyWorkerRejected sends a rejection notification to a polecat.
-func (m *Manager) notifyWorkerRejected(mr *MergeRequest, reason string) {
+// recordRejection stores the rejection on the MR bead and closes it, if it
+// isn't closed already. forcedBy is set for a forced rejection.
+func (m *Manager) recordRejection(mrID, reason, category, threadID, supersededBy, forcedBy string) error {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(mrID)
+	if err != nil {
+		return fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	fields.CloseReason = string(CloseReasonRejected)
+	fields.RejectReason = reason
+	fields.RejectCategory = category
+	fields.RejectThread = threadID
+	fields.SupersededBy = supersededBy
+	fields.RejectForcedBy = forcedBy
+	fields.RejectedAt = time.Now().UTC().Format(time.RFC3339)
+	desc := beads.SetMRFields(issue, fields)
+	if err := b.Update(mrID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("updating MR %s: %w", mrID, err)
+	}
+
+	if issue.Status == "closed" {
+		return nil
+	}
+	if err := b.CloseWithReason("rejected: "+reason, mrID); err != nil {
+		return fmt.Errorf("closing MR %s: %w", mrID, err)
+	}
+	return nil
+}
+
+// AddNote appends a note to an MR, leaving the MR itself untouched. Notes
+// are comments on the MR bead, so each keeps its author and timestamp.
+func (m *Manager) AddNote(mrID, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("note text is empty")
+	}
+
+	b := beads.New(m.rig.BeadsPath())
+	if _, err := b.Show(mrID); err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrMRNotFound, mrID)
+		}
+		return fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+	if err := b.Comment(mrID, text); err != nil {
+		return fmt.Errorf("adding note to %s: %w", mrID, err)
+	}
+	return nil
+}
+
+// SetDraft marks an open MR as a draft, or as ready again. The refinery
+// skips drafts however ready they are otherwise. Returns false if the MR was
+// already in the requested state.
+func (m *Manager) SetDraft(mrID string, draft bool) (bool, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(mrID)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return false, fmt.Errorf("%w: %s", ErrMRNotFound, mrID)
+		}
+		return false, fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+	if err := alreadyClosedError(issue); err != nil {
+		return false, err
+	}
+
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+	if fields.Draft == draft {
+		return false, nil
+	}
+	fields.Draft = draft
+	desc := beads.SetMRFields(issue, fields)
+	if err := b.Update(mrID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return false, fmt.Errorf("updating MR %s: %w", mrID, err)
+	}
+	return true, nil
+}
+
+// Notes returns an MR's notes, oldest first.
+func (m *Manager) Notes(mrID string) ([]beads.IssueComment, error) {
+	issue, err := beads.New(m.rig.BeadsPath()).Show(mrID)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrMRNotFound, mrID)
+		}
+		return nil, fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+	return issue.Comments, nil
+}
+
+// notifyWorkerRejected sends a rejection notification to a polecat and
+// returns its mail thread ID, so replies stay linked to the MR.
+func (m *Manager) notifyWorkerRejected(mr *MergeRequest, reason string) (string, error) {
 	router := mail.NewRouter(m.workDir)
-	msg := &mail.Message{
-		From:    fmt.Sprintf("%s/refinery", m.rig.Name),
-		To:      fmt.Sprintf("%s/%s", m.rig.Name, mr.Worker),
-		Subject: "Merge request rejected",
-		Body: fmt.Sprintf(`Your merge request has been rejected.
+	msg := mail.NewMessage(
+		fmt.Sprintf("%s/refinery", m.rig.Name),
+		fmt.Sprintf("%s/%s", m.rig.Name, mr.Worker),
+		"Merge request rejected: "+mr.ID,
+		fmt.Sprintf(`Your merge request has been rejected.
 
+MR: %s
 Branch: %s
 Issue: %s
 Reason: %s
 
-Please review the feedback and address the issues before resubmitting.`,
-			mr.Branch, mr.IssueID, reason),
-		Priority: mail.PriorityNormal,
+Please review the feedback and address the issues before resubmitting.
+To discuss, reply to this message (gt mail reply); the conversation is
+linked to the MR.`,
+			mr.ID, mr.Branch, mr.IssueID, reason),
+	)
+	if err := router.Send(msg); err != nil {
+		return "", err
 	}
-	_ = router.Send(msg) // best-effort notification
+	return msg.ThreadID, nil
 }
 
 // findTownRoot walks up directories to find the town root.
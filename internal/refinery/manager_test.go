@@ -2,12 +2,21 @@ package refinery
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mrqueue"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/wisp"
 )
 
 func setupTestManager(t *testing.T) (*Manager, string) {
@@ -84,10 +93,13 @@ func TestManager_Retry(t *testing.T) {
 		}
 
 		// Retry without processing
-		err := mgr.Retry("gt-mr-failed", false)
+		eligible, err := mgr.Retry("gt-mr-failed", RetryOptions{})
 		if err != nil {
 			t.Errorf("Retry() unexpected error: %v", err)
 		}
+		if !eligible.IsZero() {
+			t.Errorf("Retry() eligible = %v, want zero (next cycle)", eligible)
+		}
 
 		// Verify error was cleared
 		found, _ := mgr.GetMR("gt-mr-failed")
@@ -96,6 +108,52 @@ func TestManager_Retry(t *testing.T) {
 		}
 	})
 
+	t.Run("retry keeps priority", func(t *testing.T) {
+		mgr, _ := setupTestManager(t)
+
+		mr := &MergeRequest{
+			ID:       "gt-mr-p1",
+			Branch:   "polecat/Toast/gt-p1",
+			Status:   MROpen,
+			Priority: 1,
+			Error:    "tests failed",
+		}
+		if err := mgr.RegisterMR(mr); err != nil {
+			t.Fatalf("RegisterMR: %v", err)
+		}
+
+		if _, err := mgr.Retry("gt-mr-p1", RetryOptions{}); err != nil {
+			t.Fatalf("Retry() unexpected error: %v", err)
+		}
+
+		found, _ := mgr.GetMR("gt-mr-p1")
+		if found.Priority != 1 {
+			t.Errorf("Retry() priority = %d, want 1 (unchanged)", found.Priority)
+		}
+	})
+
+	t.Run("retry with deprioritize drops one level", func(t *testing.T) {
+		mgr, _ := setupTestManager(t)
+		mgr.SetOutput(io.Discard) // no bd here: the bead update only warns
+
+		for id, priority := range map[string]int{"gt-mr-p2": 2, "gt-mr-p4": LowestPriority} {
+			mr := &MergeRequest{ID: id, Status: MROpen, Priority: priority, Error: "tests failed"}
+			if err := mgr.RegisterMR(mr); err != nil {
+				t.Fatalf("RegisterMR: %v", err)
+			}
+			if _, err := mgr.Retry(id, RetryOptions{Deprioritize: true}); err != nil {
+				t.Fatalf("Retry(%s) unexpected error: %v", id, err)
+			}
+		}
+
+		if found, _ := mgr.GetMR("gt-mr-p2"); found.Priority != 3 {
+			t.Errorf("deprioritized P2 = P%d, want P3", found.Priority)
+		}
+		if found, _ := mgr.GetMR("gt-mr-p4"); found.Priority != LowestPriority {
+			t.Errorf("deprioritized P4 = P%d, want P%d", found.Priority, LowestPriority)
+		}
+	})
+
 	t.Run("retry non-failed MR fails", func(t *testing.T) {
 		mgr, _ := setupTestManager(t)
 
@@ -112,7 +170,7 @@ func TestManager_Retry(t *testing.T) {
 			t.Fatalf("RegisterMR: %v", err)
 		}
 
-		err := mgr.Retry("gt-mr-success", false)
+		_, err := mgr.Retry("gt-mr-success", RetryOptions{})
 		if err != ErrMRNotFailed {
 			t.Errorf("Retry() error = %v, want %v", err, ErrMRNotFailed)
 		}
@@ -121,11 +179,75 @@ func TestManager_Retry(t *testing.T) {
 	t.Run("retry nonexistent MR fails", func(t *testing.T) {
 		mgr, _ := setupTestManager(t)
 
-		err := mgr.Retry("nonexistent", false)
+		_, err := mgr.Retry("nonexistent", RetryOptions{})
 		if err != ErrMRNotFound {
 			t.Errorf("Retry() error = %v, want %v", err, ErrMRNotFound)
 		}
 	})
+
+	t.Run("retry closed MR fails", func(t *testing.T) {
+		mgr, _ := setupTestManager(t)
+
+		mr := &MergeRequest{
+			ID:          "gt-mr-closed",
+			Branch:      "polecat/Toast/gt-def",
+			Status:      MRClosed,
+			CloseReason: CloseReasonRejected,
+			Error:       "rejected",
+		}
+		if err := mgr.RegisterMR(mr); err != nil {
+			t.Fatalf("RegisterMR: %v", err)
+		}
+
+		_, err := mgr.Retry("gt-mr-closed", RetryOptions{})
+		if !errors.Is(err, ErrMRClosed) {
+			t.Errorf("Retry() error = %v, want %v", err, ErrMRClosed)
+		}
+	})
+
+	t.Run("retry with merge window closed returns when it opens", func(t *testing.T) {
+		mgr, rigPath := setupTestManager(t)
+		opens := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
+		window := opens.Format("15:04") + "-" + opens.Add(time.Hour).Format("15:04")
+		cfg := `{"type": "rig", "merge_queue": {"merge_window": ["` + window + `"]}}`
+		if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatalf("write config.json: %v", err)
+		}
+
+		mr := &MergeRequest{ID: "gt-mr-failed", Status: MROpen, Error: "merge conflict"}
+		if err := mgr.RegisterMR(mr); err != nil {
+			t.Fatalf("RegisterMR: %v", err)
+		}
+
+		eligible, err := mgr.Retry("gt-mr-failed", RetryOptions{})
+		if err != nil {
+			t.Fatalf("Retry() unexpected error: %v", err)
+		}
+		if !eligible.Equal(opens) {
+			t.Errorf("Retry() eligible = %v, want %v (window %s)", eligible, opens, window)
+		}
+	})
+
+	t.Run("retry on paused rig fails", func(t *testing.T) {
+		mgr, rigPath := setupTestManager(t)
+		townRoot := filepath.Dir(rigPath)
+		if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+			t.Fatalf("mkdir mayor: %v", err)
+		}
+		if err := wisp.NewConfig(townRoot, "testrig").Set("status", "parked"); err != nil {
+			t.Fatalf("park rig: %v", err)
+		}
+
+		mr := &MergeRequest{ID: "gt-mr-failed", Status: MROpen, Error: "merge conflict"}
+		if err := mgr.RegisterMR(mr); err != nil {
+			t.Fatalf("RegisterMR: %v", err)
+		}
+
+		_, err := mgr.Retry("gt-mr-failed", RetryOptions{})
+		if !errors.Is(err, ErrRigPaused) {
+			t.Errorf("Retry() error = %v, want %v", err, ErrRigPaused)
+		}
+	})
 }
 
 func TestManager_RegisterMR(t *testing.T) {
@@ -170,3 +292,505 @@ func TestManager_RegisterMR(t *testing.T) {
 		t.Errorf("saved MR worker = %s, want Cheedo", saved.Worker)
 	}
 }
+
+func TestManager_PickNext(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+	now := time.Now()
+	created := now.Add(-time.Hour).Format(time.RFC3339)
+
+	p0 := &beads.Issue{ID: "gt-mr-p0", Priority: 0, CreatedAt: created}
+	p2 := &beads.Issue{ID: "gt-mr-p2", Priority: 2, CreatedAt: created}
+	blocked := &beads.Issue{ID: "gt-mr-blocked", Priority: 0, CreatedAt: created, BlockedBy: []string{"gt-x"}}
+
+	t.Run("picks highest priority ready MR", func(t *testing.T) {
+		next, err := mgr.pickNext([]*beads.Issue{p2, blocked, p0}, "", now, false)
+		if err != nil {
+			t.Fatalf("pickNext() unexpected error: %v", err)
+		}
+		if next.Issue.ID != "gt-mr-p0" {
+			t.Errorf("pickNext() = %s, want gt-mr-p0", next.Issue.ID)
+		}
+		if next.Ready != 2 {
+			t.Errorf("pickNext() Ready = %d, want 2", next.Ready)
+		}
+	})
+
+	t.Run("skips MR being processed", func(t *testing.T) {
+		next, err := mgr.pickNext([]*beads.Issue{p2, p0}, "gt-mr-p0", now, false)
+		if err != nil {
+			t.Fatalf("pickNext() unexpected error: %v", err)
+		}
+		if next.Issue.ID != "gt-mr-p2" {
+			t.Errorf("pickNext() = %s, want gt-mr-p2", next.Issue.ID)
+		}
+	})
+
+	t.Run("fifo picks oldest ready MR", func(t *testing.T) {
+		old := &beads.Issue{ID: "gt-mr-old", Priority: 3, CreatedAt: now.Add(-2 * time.Hour).Format(time.RFC3339)}
+		next, err := mgr.pickNext([]*beads.Issue{p0, old, p2}, "", now, true)
+		if err != nil {
+			t.Fatalf("pickNext() unexpected error: %v", err)
+		}
+		if next.Issue.ID != "gt-mr-old" || next.Ready != 3 {
+			t.Errorf("pickNext(fifo) = %s (ready %d), want gt-mr-old (ready 3)", next.Issue.ID, next.Ready)
+		}
+		if want := IssueScore(old, now, nil); next.Score != want {
+			t.Errorf("pickNext(fifo) score = %v, want %v", next.Score, want)
+		}
+	})
+
+	t.Run("nothing ready", func(t *testing.T) {
+		for _, issues := range [][]*beads.Issue{nil, {blocked}} {
+			_, err := mgr.pickNext(issues, "", now, false)
+			if !errors.Is(err, ErrNothingReady) {
+				t.Errorf("pickNext(%d issues) error = %v, want ErrNothingReady", len(issues), err)
+			}
+		}
+	})
+}
+
+func TestManager_FailedMRs(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+	now := time.Now()
+
+	for _, mr := range []*MergeRequest{
+		{ID: "gt-mr-new", Status: MROpen, Error: "conflict", CreatedAt: now},
+		{ID: "gt-mr-old", Status: MROpen, Error: "tests failed", CreatedAt: now.Add(-time.Hour)},
+		{ID: "gt-mr-ok", Status: MROpen, CreatedAt: now},
+	} {
+		if err := mgr.RegisterMR(mr); err != nil {
+			t.Fatalf("RegisterMR: %v", err)
+		}
+	}
+
+	failed, err := mgr.FailedMRs()
+	if err != nil {
+		t.Fatalf("FailedMRs: %v", err)
+	}
+	if len(failed) != 2 || failed[0].ID != "gt-mr-old" || failed[1].ID != "gt-mr-new" {
+		t.Errorf("FailedMRs() = %v, want gt-mr-old then gt-mr-new", failed)
+	}
+}
+
+func TestManager_PlanQueue(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+	now := time.Now()
+	created := now.Add(-time.Hour).Format(time.RFC3339)
+
+	issues := []*beads.Issue{
+		{ID: "gt-mr-p2", Priority: 2, CreatedAt: created},
+		{ID: "gt-mr-blocked", Priority: 0, CreatedAt: created, BlockedBy: []string{"gt-x"}},
+		{ID: "gt-mr-current", Priority: 0, CreatedAt: created},
+		{ID: "gt-mr-p0", Priority: 0, CreatedAt: created},
+		{ID: "gt-mr-draft", Priority: 0, CreatedAt: created, Description: "branch: polecat/Nux/gt-d\ndraft: true"},
+	}
+
+	plan := mgr.planQueue(issues, "gt-mr-current", now)
+
+	want := []struct {
+		id       string
+		order    int
+		decision string
+	}{
+		{"gt-mr-p0", 1, PlanReady},
+		{"gt-mr-p2", 2, PlanReady},
+		{"gt-mr-blocked", 0, PlanBlocked},
+		{"gt-mr-current", 0, PlanSkipped},
+		{"gt-mr-draft", 0, PlanSkipped},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("planQueue() returned %d entries, want %d", len(plan), len(want))
+	}
+	for i, w := range want {
+		e := plan[i]
+		if e.ID != w.id || e.Order != w.order || e.Decision != w.decision {
+			t.Errorf("entry %d = {%s %d %s}, want {%s %d %s}", i, e.ID, e.Order, e.Decision, w.id, w.order, w.decision)
+		}
+	}
+	if plan[2].Reason != "blocked by gt-x" {
+		t.Errorf("blocked reason = %q, want %q", plan[2].Reason, "blocked by gt-x")
+	}
+	if plan[4].Reason != "draft" {
+		t.Errorf("draft reason = %q, want %q", plan[4].Reason, "draft")
+	}
+}
+
+func TestManager_Holds(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+
+	for _, bad := range []string{"", "  ", "polecat/[Nux"} {
+		if _, err := mgr.Hold(bad); !errors.Is(err, ErrInvalidHold) {
+			t.Errorf("Hold(%q) error = %v, want ErrInvalidHold", bad, err)
+		}
+	}
+	if added, err := mgr.Hold("polecat/Nux/*"); err != nil || !added {
+		t.Fatalf("Hold() = %v, %v; want added", added, err)
+	}
+	if added, err := mgr.Hold("polecat/Nux/*"); err != nil || added {
+		t.Errorf("Hold() again = %v, %v; want already held", added, err)
+	}
+	if _, err := mgr.Hold("gt-mr-one"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Holds persist in the refinery state
+	holds, err := NewManager(mgr.rig).Holds()
+	if err != nil || !reflect.DeepEqual(holds, Holds{"polecat/Nux/*", "gt-mr-one"}) {
+		t.Fatalf("Holds() = %v, %v", holds, err)
+	}
+
+	issues := []*beads.Issue{
+		{ID: "gt-mr-nux", Status: "open", Description: "branch: polecat/Nux/gt-a"},
+		{ID: "gt-mr-one", Status: "open", Description: "branch: polecat/Toast/gt-b"},
+		{ID: "gt-mr-free", Status: "open", Description: "branch: polecat/Toast/gt-c"},
+	}
+	held := matchHolds(holds, issues)
+	if !reflect.DeepEqual(held[0].Matches, []string{"gt-mr-nux"}) || !reflect.DeepEqual(held[1].Matches, []string{"gt-mr-one"}) {
+		t.Errorf("matchHolds() = %+v", held)
+	}
+
+	plan := mgr.planQueue(issues, "", time.Now())
+	for _, e := range plan {
+		wantHeld := e.ID != "gt-mr-free"
+		if gotHeld := e.Decision == PlanSkipped && strings.HasPrefix(e.Reason, "held by "); gotHeld != wantHeld {
+			t.Errorf("plan entry %s = %s (%s), held = %v", e.ID, e.Decision, e.Reason, wantHeld)
+		}
+	}
+
+	ref, _ := mgr.loadState()
+	r := mgr.readiness(issues[0], ref, nil)
+	if r.Ready || len(r.Unmet()) != 1 || r.Unmet()[0].Name != "not held" {
+		t.Errorf("readiness of held MR unmet = %+v, want only not held", r.Unmet())
+	}
+
+	ready := withoutHeld([]*mrqueue.MR{
+		{ID: "gt-mr-nux", Branch: "polecat/Nux/gt-a"},
+		{ID: "gt-mr-free", Branch: "polecat/Toast/gt-c"},
+	}, holds)
+	if len(ready) != 1 || ready[0].ID != "gt-mr-free" {
+		t.Errorf("withoutHeld() = %v, want only gt-mr-free", ready)
+	}
+
+	if removed, err := mgr.Unhold("polecat/Nux/*"); err != nil || !removed {
+		t.Errorf("Unhold() = %v, %v; want removed", removed, err)
+	}
+	if removed, err := mgr.Unhold("polecat/Nux/*"); err != nil || removed {
+		t.Errorf("Unhold() again = %v, %v; want not held", removed, err)
+	}
+	if holds, _ := mgr.Holds(); !reflect.DeepEqual(holds, Holds{"gt-mr-one"}) {
+		t.Errorf("Holds() after unhold = %v", holds)
+	}
+}
+
+func TestManager_RetryPlan(t *testing.T) {
+	mgr, rigPath := setupTestManager(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = rigPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(rigPath, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run("add", file)
+		run("commit", "-m", "update "+file)
+	}
+	run("init", "-b", "main")
+	run("config", "user.email", "test@test.com")
+	run("config", "user.name", "Test User")
+	commit("README.md", "# Test\n")
+	run("checkout", "-b", "polecat/Nux/stale")
+	commit("README.md", "# Nux\n")
+	run("checkout", "main")
+	commit("README.md", "# Main\n")
+	commit("main.txt", "main\n")
+
+	// Fake bd: the MR has been retried twice
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+printf '%s\n' '[{"id":"gt-mr-stale","issue_type":"merge-request","status":"open","description":"branch: polecat/Nux/stale\ntarget: main\nretry_count: 2"}]'
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	mr := &MergeRequest{ID: "gt-mr-stale", Branch: "polecat/Nux/stale", TargetBranch: "main",
+		Priority: 2, Status: MROpen, Error: "merge conflict"}
+	if err := mgr.RegisterMR(mr); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := mgr.RetryPlan("gt-mr-stale", RetryOptions{Deprioritize: true})
+	if err != nil {
+		t.Fatalf("RetryPlan: %v", err)
+	}
+	if plan.Refused != "" || plan.Priority != 3 {
+		t.Errorf("plan refused %q at P%d, want accepted at P3", plan.Refused, plan.Priority)
+	}
+	if !plan.NeedsRebase || plan.Behind != 2 || !reflect.DeepEqual(plan.Conflicts, []string{"README.md"}) {
+		t.Errorf("plan = behind %d, needs rebase %v, conflicts %v; want 2, true, [README.md]", plan.Behind, plan.NeedsRebase, plan.Conflicts)
+	}
+	if len(plan.Blockers) != 0 {
+		t.Errorf("Blockers = %+v, want none (the failure is what the retry clears)", plan.Blockers)
+	}
+	if plan.RetryCount != 2 || plan.RetryPenalty != 100 {
+		t.Errorf("backoff = %d retries, penalty %v; want 2, 100", plan.RetryCount, plan.RetryPenalty)
+	}
+
+	// Nothing changed
+	got, err := mgr.GetMR("gt-mr-stale")
+	if err != nil || got.Error == "" || got.Priority != 2 {
+		t.Errorf("MR after dry run = %+v, %v; want unchanged", got, err)
+	}
+
+	// An MR that hasn't failed would be refused
+	mr.Error = ""
+	if err := mgr.RegisterMR(mr); err != nil {
+		t.Fatal(err)
+	}
+	if plan, err := mgr.RetryPlan("gt-mr-stale", RetryOptions{}); err != nil || plan.Refused == "" {
+		t.Errorf("RetryPlan(not failed) = %+v, %v; want refused", plan, err)
+	}
+}
+
+func TestManager_RecordCycleResult(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+
+	if err := mgr.RecordCycleResult(errors.New("fetch failed")); err != nil {
+		t.Fatalf("RecordCycleResult(err) = %v", err)
+	}
+	ref, err := mgr.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.LastError != "fetch failed" || ref.LastErrorAt == nil {
+		t.Errorf("after error: LastError=%q LastErrorAt=%v", ref.LastError, ref.LastErrorAt)
+	}
+
+	if err := mgr.RecordCycleResult(nil); err != nil {
+		t.Fatalf("RecordCycleResult(nil) = %v", err)
+	}
+	ref, err = mgr.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.LastError != "" || ref.LastErrorAt != nil {
+		t.Errorf("after success: LastError=%q LastErrorAt=%v, want cleared", ref.LastError, ref.LastErrorAt)
+	}
+}
+
+func TestManager_Readiness(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+
+	ready := mgr.readiness(&beads.Issue{ID: "gt-mr-ok", Status: "open"}, &Refinery{}, nil)
+	if !ready.Ready || len(ready.Unmet()) != 0 {
+		t.Errorf("readiness of unblocked MR = %+v, want ready", ready)
+	}
+
+	issue := &beads.Issue{
+		ID:     "gt-mr-stuck",
+		Status: "open",
+		Dependencies: []beads.IssueDep{
+			{ID: "gt-a", Status: "open", DependencyType: "blocks"},
+			{ID: "gt-b", Status: "closed", DependencyType: "blocks"},
+			{ID: "gt-c", Status: "in_progress", DependencyType: "blocks"},
+		},
+	}
+	ref := &Refinery{PendingMRs: map[string]*MergeRequest{
+		"gt-mr-stuck": {ID: "gt-mr-stuck", Error: "tests failed"},
+	}}
+	got := mgr.readiness(issue, ref, errors.New("rig is parked"))
+	if got.Ready {
+		t.Fatalf("readiness = ready, want not ready")
+	}
+
+	unmet := make(map[string]string)
+	for _, c := range got.Unmet() {
+		unmet[c.Name] = c.Detail
+	}
+	if len(unmet) != 3 {
+		t.Errorf("unmet checks = %v, want no failure pending, unblocked and queue running", unmet)
+	}
+	if d := unmet["unblocked"]; !strings.Contains(d, "gt-a") || !strings.Contains(d, "gt-c") || strings.Contains(d, "gt-b") {
+		t.Errorf("unblocked detail = %q, want both open blockers and not the closed one", d)
+	}
+	if d := unmet["no failure pending"]; !strings.Contains(d, "tests failed") {
+		t.Errorf("no failure pending detail = %q, want the failure", d)
+	}
+	if _, ok := unmet["queue running"]; !ok {
+		t.Errorf("queue running should be unmet while the rig is parked")
+	}
+
+	draft := mgr.readiness(&beads.Issue{ID: "gt-mr-draft", Status: "open", Description: "draft: true"}, &Refinery{}, nil)
+	if draft.Ready || len(draft.Unmet()) != 1 || draft.Unmet()[0].Name != "not draft" {
+		t.Errorf("readiness of draft MR unmet = %+v, want only not draft", draft.Unmet())
+	}
+}
+
+func TestAlreadyClosedError(t *testing.T) {
+	open := &beads.Issue{ID: "gt-mr-1", Status: "open"}
+	if err := alreadyClosedError(open); err != nil {
+		t.Errorf("alreadyClosedError(open) = %v, want nil", err)
+	}
+
+	merged := &beads.Issue{ID: "gt-mr-2", Status: "closed", Description: "branch: polecat/Toast\nclose_reason: merged"}
+	err := alreadyClosedError(merged)
+	if !errors.Is(err, ErrMRAlreadyClosed) {
+		t.Fatalf("alreadyClosedError(merged) = %v, want %v", err, ErrMRAlreadyClosed)
+	}
+	if !strings.Contains(err.Error(), "gt-mr-2 (merged)") {
+		t.Errorf("error %q should name the MR and its close reason", err)
+	}
+}
+
+func TestManager_SetCurrentMR(t *testing.T) {
+	mgr, _ := setupTestManager(t)
+
+	mr := &MergeRequest{ID: "gt-mr-1", Branch: "polecat/Toast", Status: MRInProgress}
+	if err := mgr.SetCurrentMR(mr, []string{"gt-mr-1", "gt-mr-2"}); err != nil {
+		t.Fatalf("SetCurrentMR: %v", err)
+	}
+	ref, err := mgr.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if got := ref.Merging(); strings.Join(got, ",") != "gt-mr-1,gt-mr-2" {
+		t.Errorf("Merging() = %v, want the whole batch", got)
+	}
+
+	if err := mgr.SetCurrentMR(nil, nil); err != nil {
+		t.Fatalf("SetCurrentMR(nil): %v", err)
+	}
+	ref, _ = mgr.Status()
+	if ref.CurrentMR != nil || len(ref.Merging()) != 0 {
+		t.Errorf("after clearing: CurrentMR = %+v, Merging() = %v; want none", ref.CurrentMR, ref.Merging())
+	}
+}
+
+func TestRefinery_Merging_DeadProcess(t *testing.T) {
+	ref := &Refinery{
+		CurrentMR:  &MergeRequest{ID: "gt-mr-1"},
+		CurrentPID: 1 << 30, // No such process
+	}
+	if got := ref.Merging(); len(got) != 0 {
+		t.Errorf("Merging() = %v, want none when the merging process is gone", got)
+	}
+}
+
+func TestCheckSuperseder(t *testing.T) {
+	// Fake bd: "bd --no-daemon show <id> --json" knows one MR and one task
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+case "$3" in
+gt-mr-new) echo '[{"id":"gt-mr-new","issue_type":"merge-request"}]' ;;
+gt-task) echo '[{"id":"gt-task","issue_type":"task"}]' ;;
+*) echo '[]' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	b := beads.New(t.TempDir())
+
+	if err := checkSuperseder(b, "gt-mr-old", "gt-mr-new"); err != nil {
+		t.Errorf("existing MR: unexpected error %v", err)
+	}
+	if err := checkSuperseder(b, "gt-mr-old", "gt-mr-old"); err == nil {
+		t.Error("self-supersede: expected error")
+	}
+	if err := checkSuperseder(b, "gt-mr-old", "gt-mr-gone"); !errors.Is(err, ErrSupersederNotFound) {
+		t.Errorf("missing MR: got %v, want ErrSupersederNotFound", err)
+	}
+	if err := checkSuperseder(b, "gt-mr-old", "gt-task"); !errors.Is(err, ErrSupersederNotFound) {
+		t.Errorf("non-MR bead: got %v, want ErrSupersederNotFound", err)
+	}
+}
+
+func TestRejectMR_Force(t *testing.T) {
+	mgr, rigPath := setupTestManager(t)
+
+	// Fake bd: the queue is empty and gt-mr-merged has merged; calls are logged
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "calls.log")
+	script := `#!/bin/sh
+echo "$@" >> ` + logPath + `
+case "$2" in
+list) echo '[]' ;;
+show) printf '%s\n' '[{"id":"gt-mr-merged","issue_type":"merge-request","status":"closed","description":"branch: polecat/nux\nworker: nux\nclose_reason: merged"}]' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	// The forced reject is audited to the rig's town's events file, even
+	// when run from another town (--rig-root)
+	town := filepath.Dir(rigPath)
+	otherTown := t.TempDir()
+	for _, dir := range []string{town, otherTown} {
+		if err := os.MkdirAll(filepath.Join(dir, "mayor"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "mayor", "town.json"), []byte(`{"type":"town","name":"test"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(otherTown)
+
+	opts := RejectOptions{Reason: "reverted", Actor: "greenplace/crew/max"}
+	if _, err := mgr.RejectMR("gt-mr-merged", opts); !errors.Is(err, ErrMRAlreadyClosed) {
+		t.Fatalf("RejectMR without --force = %v, want ErrMRAlreadyClosed", err)
+	}
+
+	opts.Force = true
+	result, err := mgr.RejectMR("gt-mr-merged", opts)
+	if err != nil {
+		t.Fatalf("RejectMR with --force: %v", err)
+	}
+	if !strings.Contains(result.Overridden, "merged") {
+		t.Errorf("Overridden = %q, want the merged state check", result.Overridden)
+	}
+	if result.MR.Status != MRClosed || result.MR.CloseReason != CloseReasonRejected {
+		t.Errorf("MR = %s/%s, want closed/rejected", result.MR.Status, result.MR.CloseReason)
+	}
+
+	calls, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read bd calls: %v", err)
+	}
+	log := string(calls)
+	for _, want := range []string{"close_reason: rejected", "reject_forced_by: greenplace/crew/max"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("bd update should record %q; calls:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, " close ") {
+		t.Errorf("already-closed MR should not be closed again; calls:\n%s", log)
+	}
+
+	data, err := os.ReadFile(filepath.Join(town, events.EventsFile))
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(otherTown, events.EventsFile)); err == nil {
+		t.Error("audit should not be written to the cwd's town")
+	}
+	var audit events.Event
+	if err := json.Unmarshal(data, &audit); err != nil {
+		t.Fatalf("parse audit record: %v\n%s", err, data)
+	}
+	if audit.Type != events.TypeRejectForced || audit.Actor != "greenplace/crew/max" || audit.Visibility != events.VisibilityAudit {
+		t.Errorf("audit record = %+v, want a reject_forced audit by greenplace/crew/max", audit)
+	}
+	if audit.Payload["mr"] != "gt-mr-merged" || audit.Payload["reason"] != "reverted" {
+		t.Errorf("audit payload = %v, want the MR and reason", audit.Payload)
+	}
+}
@@ -0,0 +1,76 @@
+package refinery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxMergeLogSize caps a stored merge log. Longer logs keep their tail,
+// where the failure usually is.
+const MaxMergeLogSize = 256 * 1024
+
+// alreadyMergedHeader opens the stored log of an attempt that found the MR
+// already merged into its target, to tell it apart from a failure.
+const alreadyMergedHeader = "[Engineer] Outcome: already merged (target already contained the branch; no merge was made)\n"
+
+// MergeLogPath returns where the full log of an MR's last failed (or
+// already merged) merge attempt is stored.
+func MergeLogPath(rigPath, mrID string) string {
+	return filepath.Join(rigPath, ".runtime", "merge-logs", mrID+".log")
+}
+
+// WriteMergeLog stores the log of a failed merge attempt, replacing any
+// earlier one for the MR.
+func WriteMergeLog(rigPath, mrID, log string) error {
+	if len(log) > MaxMergeLogSize {
+		dropped := len(log) - MaxMergeLogSize
+		log = fmt.Sprintf("[... %d bytes truncated ...]\n", dropped) + log[dropped:]
+	}
+
+	path := MergeLogPath(rigPath, mrID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating merge log dir: %w", err)
+	}
+	return os.WriteFile(path, []byte(log), 0644)
+}
+
+// ReadMergeLog returns the stored log of an MR's last failed (or already
+// merged) merge attempt. Returns an error satisfying os.IsNotExist if none
+// was stored.
+func ReadMergeLog(rigPath, mrID string) (string, error) {
+	data, err := os.ReadFile(MergeLogPath(rigPath, mrID))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// MergeLogAlreadyMerged reports whether a stored merge log records an
+// attempt that found the MR already merged, and returns the log without its
+// outcome header.
+func MergeLogAlreadyMerged(log string) (bool, string) {
+	if rest, ok := strings.CutPrefix(log, alreadyMergedHeader); ok {
+		return true, rest
+	}
+	return false, log
+}
+
+// saveMergeLog stores a failed or already merged result's log, warning on
+// error. Logs of real merges aren't kept.
+func (e *Engineer) saveMergeLog(mrID string, result ProcessResult) {
+	log := result.Log
+	if result.AlreadyMerged {
+		if log == "" {
+			log = fmt.Sprintf("[Engineer] target already contained %s\n", result.MergeCommit)
+		}
+		log = alreadyMergedHeader + log
+	}
+	if mrID == "" || log == "" {
+		return
+	}
+	if err := WriteMergeLog(e.rig.Path, mrID, log); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to save merge log for %s: %v\n", mrID, err)
+	}
+}
@@ -0,0 +1,67 @@
+package refinery
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MergeMessageData holds the values available to a merge commit message
+// template.
+type MergeMessageData struct {
+	MRID        string
+	SourceIssue string
+	Worker      string
+	Branch      string
+	Target      string
+}
+
+// mergeMessageVarRegex matches {{variable}} placeholders in a merge message template.
+var mergeMessageVarRegex = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// vars maps template placeholder names to their values.
+func (d MergeMessageData) vars() map[string]string {
+	return map[string]string{
+		"mr_id":        d.MRID,
+		"source_issue": d.SourceIssue,
+		"worker":       d.Worker,
+		"branch":       d.Branch,
+		"target":       d.Target,
+	}
+}
+
+// ValidateMergeMessageTemplate checks that a template only uses known
+// placeholders. An empty template is valid and selects the built-in format.
+func ValidateMergeMessageTemplate(tmpl string) error {
+	known := MergeMessageData{}.vars()
+	for _, m := range mergeMessageVarRegex.FindAllStringSubmatch(tmpl, -1) {
+		if _, ok := known[m[1]]; !ok {
+			return fmt.Errorf("unknown placeholder {{%s}} (valid: mr_id, source_issue, worker, branch, target)", m[1])
+		}
+	}
+	if strings.Count(tmpl, "{{") != strings.Count(tmpl, "}}") {
+		return fmt.Errorf("unbalanced braces in %q", tmpl)
+	}
+	return nil
+}
+
+// RenderMergeMessage renders the merge commit message for an MR. An empty
+// template (or one that renders to nothing) falls back to the built-in
+// "Merge <branch> into <target> (<source issue>)" format.
+func RenderMergeMessage(tmpl string, data MergeMessageData) string {
+	if strings.TrimSpace(tmpl) != "" {
+		vars := data.vars()
+		msg := mergeMessageVarRegex.ReplaceAllStringFunc(tmpl, func(match string) string {
+			name := mergeMessageVarRegex.FindStringSubmatch(match)[1]
+			return vars[name]
+		})
+		if msg = strings.TrimSpace(msg); msg != "" {
+			return msg
+		}
+	}
+
+	if data.SourceIssue != "" {
+		return fmt.Sprintf("Merge %s into %s (%s)", data.Branch, data.Target, data.SourceIssue)
+	}
+	return fmt.Sprintf("Merge %s into %s", data.Branch, data.Target)
+}
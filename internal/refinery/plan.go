@@ -0,0 +1,142 @@
+package refinery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Decisions recorded for each MR in a refinery plan.
+const (
+	PlanReady   = "ready"
+	PlanBlocked = "blocked"
+	PlanSkipped = "skipped"
+)
+
+// PlanEntry is what the refinery would do with one open MR this cycle.
+type PlanEntry struct {
+	// Order is the MR's place in the merge order (1 = first); 0 if it
+	// won't be merged this cycle.
+	Order    int     `json:"order,omitempty"`
+	ID       string  `json:"id"`
+	Branch   string  `json:"branch,omitempty"`
+	Worker   string  `json:"worker,omitempty"`
+	Target   string  `json:"target,omitempty"`
+	Priority int     `json:"priority"`
+	Hot      bool    `json:"hot,omitempty"` // Scored as HotPriority (merge_queue.hot_branches)
+	Score    float64 `json:"score"`
+	Decision string  `json:"decision"`
+	Reason   string  `json:"reason"`
+
+	issue *beads.Issue
+}
+
+// Plan is the refinery's preview of one cycle: every open MR, ready ones
+// first in merge order, then blocked and skipped ones.
+type Plan struct {
+	Entries []PlanEntry `json:"entries"`
+
+	// Paused is set when the rig is parked or docked; nothing merges until
+	// it is resumed, though the order stays as planned.
+	Paused string `json:"paused,omitempty"`
+}
+
+// Ready returns the number of MRs that would be merged this cycle.
+func (p *Plan) Ready() int {
+	n := 0
+	for _, e := range p.Entries {
+		if e.Decision == PlanReady {
+			n++
+		}
+	}
+	return n
+}
+
+// Plan computes what the next refinery cycle would do, without merging or
+// claiming anything. It uses the same scheduling as Next.
+func (m *Manager) Plan() (*Plan, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issues, err := b.List(beads.ListOptions{
+		Type:     "merge-request",
+		Status:   "open",
+		Priority: -1, // No priority filter
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying merge queue from beads: %w", err)
+	}
+
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	currentID := ""
+	if len(ref.Merging()) > 0 {
+		currentID = ref.CurrentMR.ID
+	}
+
+	plan := &Plan{Entries: m.planQueue(issues, currentID, time.Now())}
+	if err := m.checkNotPaused(); err != nil {
+		plan.Paused = err.Error()
+	}
+	return plan, nil
+}
+
+// planQueue decides, for each open MR, whether the refinery would merge it
+// and in what order. Ready MRs come first, highest score first; then
+// blocked MRs, then skipped ones.
+func (m *Manager) planQueue(issues []*beads.Issue, currentID string, now time.Time) []PlanEntry {
+	hot := m.hotBranches()
+	holds := m.holds()
+	entries := make([]PlanEntry, 0, len(issues))
+	for _, issue := range issues {
+		e := PlanEntry{
+			ID:       issue.ID,
+			Priority: issue.Priority,
+			Score:    IssueScore(issue, now, hot),
+			issue:    issue,
+		}
+		fields := beads.ParseMRFields(issue)
+		if fields != nil {
+			e.Branch, e.Worker, e.Target = fields.Branch, fields.Worker, fields.Target
+			e.Hot = hot.Match(fields.Target, fields.Branch)
+		}
+
+		hold := holds.Match(issue.ID, e.Branch)
+
+		switch {
+		case issue.ID == currentID:
+			e.Decision, e.Reason = PlanSkipped, "being processed"
+		case fields != nil && fields.Draft:
+			e.Decision, e.Reason = PlanSkipped, "draft"
+		case hold != "":
+			e.Decision, e.Reason = PlanSkipped, "held by "+hold
+		case len(issue.BlockedBy) > 0:
+			e.Decision, e.Reason = PlanBlocked, "blocked by "+strings.Join(issue.BlockedBy, ", ")
+		case issue.BlockedByCount > 0:
+			e.Decision, e.Reason = PlanBlocked, fmt.Sprintf("blocked by %d open issue(s)", issue.BlockedByCount)
+		default:
+			e.Decision, e.Reason = PlanReady, "ready"
+			if fields != nil && fields.RetryCount > 0 {
+				e.Reason = fmt.Sprintf("ready (retry %d)", fields.RetryCount)
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	rank := map[string]int{PlanReady: 0, PlanBlocked: 1, PlanSkipped: 2}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if rank[entries[i].Decision] != rank[entries[j].Decision] {
+			return rank[entries[i].Decision] < rank[entries[j].Decision]
+		}
+		return entries[i].Score > entries[j].Score
+	})
+	for i := range entries {
+		if entries[i].Decision == PlanReady {
+			entries[i].Order = i + 1
+		}
+	}
+	return entries
+}
@@ -0,0 +1,366 @@
+package refinery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// ErrProcessingLocked is returned when another process is already running a
+// refinery cycle for the rig.
+var ErrProcessingLocked = errors.New("refinery cycle already running")
+
+// ErrMergeStuck is returned when a merge keeps running past mr_timeout
+// (or cancellation) and its grace period. The cycle stops there: it waits
+// for the merge to exit, holding the processing lock, so no other merge can
+// start in the same checkout meanwhile.
+var ErrMergeStuck = errors.New("merge did not stop when cancelled")
+
+// ErrMergeWindowClosed is returned when a cycle runs outside the rig's
+// merge window (merge_queue.merge_window). Ready MRs stay queued.
+var ErrMergeWindowClosed = errors.New("merge window closed")
+
+// Outcomes recorded for each MR handled by ProcessOnce.
+const (
+	OutcomeMerged   = "merged"
+	OutcomeSkipped  = "skipped"
+	OutcomeFailed   = "failed"
+	OutcomeConflict = "conflict"
+	OutcomeBlocked  = "blocked"
+)
+
+// ProcessedMR records what a refinery cycle did with one MR.
+type ProcessedMR struct {
+	ID          string `json:"id"`
+	Branch      string `json:"branch"`
+	Outcome     string `json:"outcome"`
+	MergeCommit string `json:"merge_commit,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	// Batch lists the MRs merged together with this one in a single push.
+	Batch []string `json:"batch,omitempty"`
+}
+
+// processLockPath returns the lock file serializing refinery cycles.
+func (e *Engineer) processLockPath() string {
+	return filepath.Join(e.rig.Path, ".runtime", "refinery-process.lock")
+}
+
+// LockProcessing takes the rig's processing lock, held by everything that
+// merges in or otherwise uses the refinery's checkout: refinery cycles,
+// revalidation and foreground retries. It doesn't wait: if another holder
+// has the lock it returns ErrProcessingLocked. Call unlock when done.
+func (e *Engineer) LockProcessing() (unlock func(), err error) {
+	lockPath := e.processLockPath()
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("creating runtime dir: %w", err)
+	}
+	fileLock := flock.New(lockPath)
+	locked, err := fileLock.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("acquiring processing lock: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("%w for rig '%s'", ErrProcessingLocked, e.rig.Name)
+	}
+	return func() { _ = fileLock.Unlock() }, nil
+}
+
+// ProcessOnce runs one refinery cycle synchronously: every MR that is ready
+// when the cycle starts is claimed, merged and finished, in score order.
+// It holds the rig's processing lock for the whole cycle so concurrent
+// triggers (CI hooks, gt mq process) can't merge the same queue twice;
+// a second caller gets ErrProcessingLocked rather than waiting.
+func (e *Engineer) ProcessOnce(ctx context.Context) ([]ProcessedMR, error) {
+	if err := e.CheckMergeWindow(); err != nil {
+		return nil, err
+	}
+
+	unlock, err := e.LockProcessing()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		err = fmt.Errorf("listing ready MRs: %w", err)
+		e.recordCycleResult(err)
+		return nil, err
+	}
+	e.recordCycleResult(nil)
+	defer e.publishCurrent(nil)
+
+	var processed []ProcessedMR
+	pending := ready
+	for len(pending) > 0 {
+		if ctx.Err() != nil {
+			break
+		}
+		batch := nextBatch(pending, e.config.BatchSize)
+		pending = without(pending, batch)
+
+		var claimed []*mrqueue.MR
+		for _, mr := range batch {
+			if err := e.mrQueue.Claim(mr.ID, e.holder); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Skipping %s: %v\n", mr.ID, err)
+				continue
+			}
+			claimed = append(claimed, mr)
+		}
+		stopRenewing := e.keepClaims(claimed)
+		results, stuckErr := e.mergeClaimed(ctx, claimed)
+		stopRenewing()
+		processed = append(processed, results...)
+		if stuckErr != nil {
+			return processed, stuckErr
+		}
+		pending = e.reevaluateAfter(pending, claimed, results)
+	}
+
+	return processed, ctx.Err()
+}
+
+// mergeClaimed merges a batch of MRs this refinery has claimed: together
+// if there is more than one and the batch merges cleanly, else one at a
+// time. If a merge gets stuck it stops there, releasing the MRs it didn't
+// get to, and returns ErrMergeStuck.
+func (e *Engineer) mergeClaimed(ctx context.Context, claimed []*mrqueue.MR) ([]ProcessedMR, error) {
+	var processed []ProcessedMR
+	if len(claimed) > 1 {
+		for _, mr := range claimed {
+			if err := e.eventLogger.LogMergeStarted(mr); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_started event: %v\n", err)
+			}
+		}
+		e.publishCurrent(claimed)
+		batchCtx, cancel := e.mrContext(ctx)
+		results, ok := e.mergeBatch(batchCtx, claimed)
+		cancel()
+		if ok {
+			for i, mr := range claimed {
+				e.recordAttempt(mr.ID)
+				processed = append(processed, e.finishClaimed(mr, results[i]))
+			}
+			return processed, nil
+		}
+	}
+	// Unbatched, or the batch failed: merge one at a time
+	var stuckErr error
+	for _, mr := range claimed {
+		if ctx.Err() != nil || stuckErr != nil {
+			_ = e.mrQueue.Release(mr.ID)
+			continue
+		}
+		e.publishCurrent([]*mrqueue.MR{mr})
+		result, stuck := e.mergeIsolated(ctx, mr)
+		processed = append(processed, e.finishClaimed(mr, result))
+		if stuck {
+			stuckErr = fmt.Errorf("%w: %s", ErrMergeStuck, mr.ID)
+		}
+	}
+	return processed, stuckErr
+}
+
+// mrStuckGrace is how long a cancelled or timed-out merge gets to unwind
+// (its git and test subprocesses are killed with its context) before it
+// counts as stuck, and mrStuckWait how much longer the cycle then waits for
+// it to exit before failing the MR and giving up on it. Variables so tests
+// can shorten them.
+var (
+	mrStuckGrace = 30 * time.Second
+	mrStuckWait  = 5 * time.Minute
+)
+
+// mergeIsolated merges one claimed MR with e.mergeMR, so that a panic in
+// the merge path, or a merge running past merge_queue.mr_timeout, fails
+// just that MR and the cycle carries on with the rest.
+//
+// A merge still running mrStuckGrace after it was cancelled is stuck: it
+// may yet change the checkout (or e's state), so mergeIsolated waits for it
+// to exit and reports stuck, and the caller must not start another merge.
+// If it still hasn't exited after mrStuckWait, the MR is failed anyway so
+// the refinery isn't wedged for good.
+func (e *Engineer) mergeIsolated(ctx context.Context, mr *mrqueue.MR) (result ProcessResult, stuck bool) {
+	mrCtx, cancel := e.mrContext(ctx)
+	defer cancel()
+
+	out := e.output // doMerge swaps e.output; don't read it concurrently
+	done := make(chan ProcessResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				_, _ = fmt.Fprintf(out, "[Engineer] Panic while merging %s: %v\n%s", mr.ID, r, debug.Stack())
+				// Don't leave a half-done merge for the next MR. An isolated
+				// merge's worktree was already removed as the panic unwound.
+				if !e.config.IsolateMerges {
+					_ = e.git.AbortMerge()
+				}
+				done <- ProcessResult{Error: fmt.Sprintf("refinery panicked while merging: %v", r)}
+			}
+		}()
+		done <- e.mergeMR(mrCtx, mr)
+	}()
+
+	select {
+	case result = <-done:
+	case <-mrCtx.Done():
+		select {
+		case result = <-done:
+		case <-time.After(mrStuckGrace):
+			_, _ = fmt.Fprintf(out, "[Engineer] Merge of %s still running %s after %v; stopping the cycle once it exits\n", mr.ID, mrStuckGrace, mrCtx.Err())
+			stuck = true
+			select {
+			case result = <-done:
+			case <-time.After(mrStuckWait):
+				_, _ = fmt.Fprintf(out, "[Engineer] Merge of %s did not exit; giving up on it\n", mr.ID)
+				return ProcessResult{Error: fmt.Sprintf("merge did not exit %s after %v; its processes may still be running", mrStuckGrace+mrStuckWait, mrCtx.Err())}, true
+			}
+		}
+	}
+	if !result.Success && ctx.Err() == nil && errors.Is(mrCtx.Err(), context.DeadlineExceeded) {
+		result.Error = fmt.Sprintf("merge timed out after %s (merge_queue.mr_timeout): %s", e.config.MRTimeout, result.Error)
+	}
+	return result, stuck
+}
+
+// mrContext bounds one merge by merge_queue.mr_timeout, if set.
+func (e *Engineer) mrContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.config.MRTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, e.config.MRTimeout)
+}
+
+// keepClaims renews this refinery's claims on mrs every
+// mrqueue.ClaimRenewInterval until the returned function is called, so a
+// long merge (a slow test run) can't let a claim go stale and another
+// refinery take the MR over mid-merge.
+func (e *Engineer) keepClaims(mrs []*mrqueue.MR) (stop func()) {
+	out := e.output // doMerge swaps e.output; don't read it concurrently
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(mrqueue.ClaimRenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for _, mr := range mrs {
+					if err := e.mrQueue.Renew(mr.ID, e.holder); err != nil && !errors.Is(err, mrqueue.ErrNotFound) {
+						_, _ = fmt.Fprintf(out, "[Engineer] Warning: failed to renew claim on %s: %v\n", mr.ID, err)
+					}
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// CheckMergeWindow returns ErrMergeWindowClosed if the rig's merge window is
+// closed, unless the window is being ignored (SetIgnoreMergeWindow). Every
+// merge the engineer makes is gated on it.
+func (e *Engineer) CheckMergeWindow() error {
+	now := e.now()
+	if e.ignoreWindow || e.config.MergeWindow.Open(now) {
+		return nil
+	}
+	if next := e.config.MergeWindow.NextOpen(now); !next.IsZero() {
+		return fmt.Errorf("%w for rig '%s' (opens %s)", ErrMergeWindowClosed, e.rig.Name, next.Format("Mon 15:04"))
+	}
+	return fmt.Errorf("%w for rig '%s'", ErrMergeWindowClosed, e.rig.Name)
+}
+
+// finishClaimed records the result of merging a claimed MR: success or
+// failure handling, and releasing the claim if it didn't merge.
+func (e *Engineer) finishClaimed(mr *mrqueue.MR, result ProcessResult) ProcessedMR {
+	item := ProcessedMR{ID: mr.ID, Branch: mr.Branch, Error: result.Error, Batch: result.Batch}
+	switch {
+	case result.Success:
+		e.handleSuccessFromQueue(mr, result)
+		item.Outcome = OutcomeMerged
+		if result.AlreadyMerged {
+			item.Outcome = OutcomeSkipped
+		}
+		item.MergeCommit = result.MergeCommit
+	case result.Blocked:
+		item.Outcome = OutcomeBlocked
+	default:
+		e.handleFailureFromQueue(mr, result)
+		item.Outcome = OutcomeFailed
+		if result.Conflict {
+			item.Outcome = OutcomeConflict
+		}
+	}
+	if !result.Success {
+		if err := e.mrQueue.Release(mr.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release claim on %s: %v\n", mr.ID, err)
+		}
+	}
+	return item
+}
+
+// without returns mrs minus those in remove, keeping order.
+func without(mrs, remove []*mrqueue.MR) []*mrqueue.MR {
+	drop := make(map[string]bool, len(remove))
+	for _, mr := range remove {
+		drop[mr.ID] = true
+	}
+	var kept []*mrqueue.MR
+	for _, mr := range mrs {
+		if !drop[mr.ID] {
+			kept = append(kept, mr)
+		}
+	}
+	return kept
+}
+
+// publishCurrent records the MRs being merged (a batch, or just one) in the
+// refinery state; nil clears it. Best-effort: it only feeds status displays.
+func (e *Engineer) publishCurrent(mrs []*mrqueue.MR) {
+	var current *MergeRequest
+	var batch []string
+	if len(mrs) > 0 {
+		mr := mrs[0]
+		current = &MergeRequest{
+			ID:           mr.ID,
+			Branch:       mr.Branch,
+			Worker:       mr.Worker,
+			IssueID:      mr.SourceIssue,
+			TargetBranch: mr.Target,
+			Status:       MRInProgress,
+			CreatedAt:    mr.CreatedAt,
+			Priority:     mr.Priority,
+		}
+		if len(mrs) > 1 {
+			for _, m := range mrs {
+				batch = append(batch, m.ID)
+			}
+		}
+	}
+	if err := NewManager(e.rig).SetCurrentMR(current, batch); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to publish current MR: %v\n", err)
+	}
+}
+
+// recordCycleResult persists the cycle outcome in refinery state.
+func (e *Engineer) recordCycleResult(cycleErr error) {
+	if err := NewManager(e.rig).RecordCycleResult(cycleErr); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record cycle result: %v\n", err)
+	}
+}
@@ -0,0 +1,169 @@
+package refinery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// ReadinessCheck is one condition an MR must meet before the refinery will
+// merge it.
+type ReadinessCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Readiness explains whether the refinery would merge an MR, condition by
+// condition.
+type Readiness struct {
+	MRID   string           `json:"mr_id"`
+	Ready  bool             `json:"ready"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// Unmet returns the checks the MR fails.
+func (r *Readiness) Unmet() []ReadinessCheck {
+	var unmet []ReadinessCheck
+	for _, c := range r.Checks {
+		if !c.OK {
+			unmet = append(unmet, c)
+		}
+	}
+	return unmet
+}
+
+// Readiness reports every condition the refinery checks before merging an
+// MR, satisfied or not, so "why isn't my MR merging?" has a full answer
+// rather than just the first blocker.
+func (m *Manager) Readiness(mrID string) (*Readiness, error) {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(mrID)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return nil, ErrMRNotFound
+		}
+		return nil, fmt.Errorf("fetching MR %s: %w", mrID, err)
+	}
+
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	r := m.readiness(issue, ref, m.checkNotPaused())
+	m.addSignatureCheck(r, issue)
+	return r, nil
+}
+
+// addSignatureCheck adds the "signed" check for an open MR if the rig
+// requires signed commits (merge_queue.signature_policy).
+func (m *Manager) addSignatureCheck(r *Readiness, issue *beads.Issue) {
+	fields := beads.ParseMRFields(issue)
+	if issue.Status == "closed" || fields == nil || fields.Branch == "" {
+		return
+	}
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil || !eng.config.signatureEnforced() {
+		return
+	}
+	check := eng.verifySignature(eng.git, fields.Branch)
+	r.Checks = append(r.Checks, ReadinessCheck{Name: "signed", OK: check.Trusted, Detail: check.Detail})
+	r.Ready = r.Ready && check.Trusted
+}
+
+// readiness evaluates the merge conditions for an MR bead against the
+// refinery state and the rig's paused status.
+func (m *Manager) readiness(issue *beads.Issue, ref *Refinery, pausedErr error) *Readiness {
+	r := &Readiness{MRID: issue.ID}
+	add := func(name string, ok bool, detail string) {
+		r.Checks = append(r.Checks, ReadinessCheck{Name: name, OK: ok, Detail: detail})
+	}
+
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		fields = &beads.MRFields{}
+	}
+
+	// Open
+	if issue.Status == "closed" {
+		detail := "closed"
+		if fields.CloseReason != "" {
+			detail += " (" + fields.CloseReason + ")"
+		}
+		add("open", false, detail)
+	} else {
+		add("open", true, "")
+	}
+
+	// Not a draft
+	if fields.Draft {
+		add("not draft", false, "marked draft; run 'gt mq ready'")
+	} else {
+		add("not draft", true, "")
+	}
+
+	// Not held
+	if hold := Holds(ref.Holds).Match(issue.ID, fields.Branch); hold != "" {
+		add("not held", false, fmt.Sprintf("held by %q; run 'gt mq unhold'", hold))
+	} else {
+		add("not held", true, "")
+	}
+
+	// Not already being merged
+	if ref.CurrentMR != nil && ref.CurrentMR.ID == issue.ID {
+		add("not in progress", false, "the refinery is merging it now")
+	} else {
+		add("not in progress", true, "")
+	}
+
+	// No failure waiting on a retry
+	if pending := ref.PendingMRs[issue.ID]; pending != nil && pending.Error != "" {
+		add("no failure pending", false, pending.Error+"; run 'gt mq retry'")
+	} else {
+		add("no failure pending", true, "")
+	}
+
+	// Blockers: every open one, not just the first
+	var blockers []string
+	seen := make(map[string]bool)
+	for _, dep := range issue.Dependencies {
+		if (dep.DependencyType == "" || dep.DependencyType == "blocks") && dep.Status != "closed" {
+			blockers = append(blockers, fmt.Sprintf("%s (%s)", dep.ID, dep.Status))
+			seen[dep.ID] = true
+		}
+	}
+	for _, id := range issue.BlockedBy {
+		if !seen[id] {
+			blockers = append(blockers, id)
+		}
+	}
+	switch {
+	case len(blockers) > 0:
+		add("unblocked", false, "blocked by "+strings.Join(blockers, ", "))
+	case issue.BlockedByCount > 0:
+		add("unblocked", false, fmt.Sprintf("blocked by %d open issue(s)", issue.BlockedByCount))
+	default:
+		add("unblocked", true, "")
+	}
+
+	// Queue running
+	if pausedErr != nil {
+		add("queue running", false, pausedErr.Error())
+	} else {
+		add("queue running", true, "")
+	}
+
+	// Target accepts direct merges
+	if fields.Target != "" {
+		if err := m.rig.CheckBranchUnprotected(fields.Target); err != nil {
+			add("target unprotected", false, err.Error())
+		} else {
+			add("target unprotected", true, fields.Target)
+		}
+	}
+
+	r.Ready = len(r.Unmet()) == 0
+	return r
+}
@@ -0,0 +1,125 @@
+package refinery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// reevaluateAfter re-checks the rest of a cycle's MRs after a batch was
+// processed. A cycle decides what is ready when it starts; each merge moves
+// its target, and with it the base of every pending MR whose target
+// overlaps (see targetsOverlap), so their readiness is recomputed against
+// the queue as it is now. MRs no longer ready are dropped from the cycle
+// (they stay queued for the next one); the rest carry a "re-evaluated after
+// <mr>" note into their merge log.
+func (e *Engineer) reevaluateAfter(pending, batch []*mrqueue.MR, results []ProcessedMR) []*mrqueue.MR {
+	targets := make(map[string]string, len(batch))
+	for _, mr := range batch {
+		targets[mr.ID] = mr.Target
+	}
+	// The last MR merged into each target, in merge order
+	var order []string
+	lastMerged := make(map[string]string)
+	for _, p := range results {
+		target, ok := targets[p.ID]
+		if !ok || p.Outcome != OutcomeMerged {
+			continue
+		}
+		if _, seen := lastMerged[target]; !seen {
+			order = append(order, target)
+		}
+		lastMerged[target] = p.ID
+	}
+	for _, target := range order {
+		pending = e.reevaluate(pending, lastMerged[target], target)
+	}
+	return pending
+}
+
+// reevaluate recomputes readiness for the pending MRs whose target overlaps
+// mergedTarget, after mergedID landed there, returning pending minus the
+// MRs that are no longer ready.
+func (e *Engineer) reevaluate(pending []*mrqueue.MR, mergedID, mergedTarget string) []*mrqueue.MR {
+	repo := e.branchRepo()
+	affected := make(map[string]bool)
+	for _, mr := range pending {
+		if targetsOverlap(repo, e.rig.DefaultBranch(), mergedTarget, mr.Target) {
+			affected[mr.ID] = true
+		}
+	}
+	if len(affected) == 0 {
+		return pending
+	}
+
+	ready, err := e.ListReadyMRs()
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: re-evaluating after %s: %v (continuing)\n", mergedID, err)
+		return pending
+	}
+	stillReady := make(map[string]bool, len(ready))
+	for _, mr := range ready {
+		stillReady[mr.ID] = true
+	}
+
+	if e.reevaluated == nil {
+		e.reevaluated = make(map[string]string)
+	}
+	kept := make([]*mrqueue.MR, 0, len(pending))
+	for _, mr := range pending {
+		if !affected[mr.ID] {
+			kept = append(kept, mr)
+			continue
+		}
+		note := fmt.Sprintf("re-evaluated after %s (merged into %s)", mergedID, mergedTarget)
+		if !stillReady[mr.ID] {
+			reason := note + ": no longer ready"
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Skipping %s: %s\n", mr.ID, reason)
+			if err := e.eventLogger.LogMergeSkipped(mr, reason); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to log merge_skipped event: %v\n", err)
+			}
+			delete(e.reevaluated, mr.ID)
+			continue
+		}
+		// A conflict is left to the merge itself, which fails the MR
+		// properly; the note just says why
+		if conflicts, err := repo.MergeTreeConflicts(mr.Branch, mr.Target); err == nil && len(conflicts) > 0 {
+			note += fmt.Sprintf(": now conflicts with %s in %s", mr.Target, strings.Join(conflicts, ", "))
+		} else {
+			note += ": still ready"
+		}
+		e.reevaluated[mr.ID] = note
+		kept = append(kept, mr)
+	}
+	return kept
+}
+
+// targetsOverlap reports whether a merge into merged moves the base of an
+// MR into target: the same branch, or branches sharing commits that aren't
+// on defaultBranch (e.g. one epic's integration branch cut from
+// another's). Branches whose histories can't be compared are assumed to
+// overlap.
+func targetsOverlap(g *git.Git, defaultBranch, merged, target string) bool {
+	if merged == target {
+		return true
+	}
+	base, err := g.MergeBase(merged, target)
+	if err != nil {
+		return true
+	}
+	onDefault, err := g.IsAncestor(base, defaultBranch)
+	if err != nil {
+		return true
+	}
+	return !onDefault
+}
+
+// takeReevaluation returns and clears the re-evaluation note for an MR, if
+// a merge earlier in the cycle re-checked it.
+func (e *Engineer) takeReevaluation(mrID string) string {
+	note := e.reevaluated[mrID]
+	delete(e.reevaluated, mrID)
+	return note
+}
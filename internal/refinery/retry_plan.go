@@ -0,0 +1,157 @@
+package refinery
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mrqueue"
+)
+
+// RetryPlan previews what retrying a failed MR would do: whether Retry
+// would accept it, where it would merge and how, and what would still hold
+// it back. Building it changes nothing.
+type RetryPlan struct {
+	MRID          string `json:"mr_id"`
+	Branch        string `json:"branch"`
+	Target        string `json:"target"`
+	PreviousError string `json:"previous_error,omitempty"`
+
+	// Refused is why Retry would refuse the MR; empty if it would accept it.
+	Refused string `json:"refused,omitempty"`
+
+	// Priority is the MR's priority after the retry (lowered by one level
+	// with RetryOptions.Deprioritize).
+	Priority int `json:"priority"`
+
+	// BranchMissing is set if the MR's branch no longer exists, so the
+	// merge would fail whatever else is true.
+	BranchMissing bool `json:"branch_missing,omitempty"`
+
+	// Behind is how many target commits the branch lacks.
+	Behind int `json:"behind"`
+
+	// NeedsRebase is set if the branch no longer merges cleanly into the
+	// target, so the retry would conflict until the worker rebases it;
+	// Conflicts lists the files.
+	NeedsRebase bool     `json:"needs_rebase"`
+	Conflicts   []string `json:"conflicts,omitempty"`
+
+	// Blockers are the readiness checks (see Readiness) the MR would still
+	// fail once retried.
+	Blockers []ReadinessCheck `json:"blockers,omitempty"`
+
+	// RetryCount is how many times the MR has failed and been retried, and
+	// RetryPenalty the score it loses for them in queue ordering.
+	RetryCount   int     `json:"retry_count"`
+	RetryPenalty float64 `json:"retry_penalty"`
+
+	// EligibleAt is when the MR could merge if the rig's merge window is
+	// closed; nil if on the next refinery cycle.
+	EligibleAt *time.Time `json:"eligible_at,omitempty"`
+}
+
+// RetryPlan builds the plan for retrying a failed MR with opts, for
+// 'gt mq retry --dry-run'. The branch is compared with the target in the
+// repo the refinery merges from, without checking anything out.
+func (m *Manager) RetryPlan(id string, opts RetryOptions) (*RetryPlan, error) {
+	ref, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	mr := ref.PendingMRs[id]
+	if mr == nil {
+		return nil, ErrMRNotFound
+	}
+
+	plan := &RetryPlan{
+		MRID:          mr.ID,
+		Branch:        mr.Branch,
+		Target:        mr.TargetBranch,
+		PreviousError: mr.Error,
+		Priority:      mr.Priority,
+	}
+	if plan.Target == "" {
+		plan.Target = m.rig.DefaultBranch()
+	}
+	if err := m.checkRetryable(mr); err != nil {
+		plan.Refused = err.Error()
+	}
+	if opts.Deprioritize && plan.Priority < LowestPriority {
+		plan.Priority++
+	}
+	if eligible := m.nextEligible(time.Now()); !eligible.IsZero() {
+		plan.EligibleAt = &eligible
+	}
+
+	if err := m.planRetryMerge(plan); err != nil {
+		return nil, err
+	}
+	if err := m.planRetryBlockers(plan, ref); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planRetryMerge fills in how the branch stands against the target.
+func (m *Manager) planRetryMerge(plan *RetryPlan) error {
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		_, _ = fmt.Fprintf(m.output, "Warning: could not load merge queue config, using defaults: %v\n", err)
+	}
+	g := eng.branchRepo()
+
+	exists, err := g.BranchExists(plan.Branch)
+	if err != nil {
+		return fmt.Errorf("checking branch %s: %w", plan.Branch, err)
+	}
+	if !exists {
+		plan.BranchMissing = true
+		return nil
+	}
+
+	if plan.Behind, err = g.CommitsAhead(plan.Branch, plan.Target); err != nil {
+		return fmt.Errorf("comparing %s with %s: %w", plan.Branch, plan.Target, err)
+	}
+	if plan.Behind == 0 {
+		return nil
+	}
+	if plan.Conflicts, err = g.MergeTreeConflicts(plan.Branch, plan.Target); err != nil {
+		return fmt.Errorf("trial merge of %s into %s: %w", plan.Branch, plan.Target, err)
+	}
+	plan.NeedsRebase = len(plan.Conflicts) > 0
+	return nil
+}
+
+// planRetryBlockers fills in the readiness checks the MR would still fail
+// once retried (the failure itself is what a retry clears), and its retry
+// backoff.
+func (m *Manager) planRetryBlockers(plan *RetryPlan, ref *Refinery) error {
+	b := beads.New(m.rig.BeadsPath())
+	issue, err := b.Show(plan.MRID)
+	if err != nil {
+		if errors.Is(err, beads.ErrNotFound) {
+			return ErrMRNotFound
+		}
+		return fmt.Errorf("fetching MR %s: %w", plan.MRID, err)
+	}
+
+	r := m.readiness(issue, ref, m.checkNotPaused())
+	m.addSignatureCheck(r, issue)
+	for _, c := range r.Unmet() {
+		if c.Name != "no failure pending" {
+			plan.Blockers = append(plan.Blockers, c)
+		}
+	}
+
+	if fields := beads.ParseMRFields(issue); fields != nil {
+		plan.RetryCount = fields.RetryCount
+	}
+	sc := mrqueue.DefaultScoreConfig()
+	plan.RetryPenalty = sc.RetryPenalty * float64(plan.RetryCount)
+	if plan.RetryPenalty > sc.MaxRetryPenalty {
+		plan.RetryPenalty = sc.MaxRetryPenalty
+	}
+	return nil
+}
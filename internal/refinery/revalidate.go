@@ -0,0 +1,105 @@
+package refinery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrGateFailed is returned by Revalidate when a branch fails one of the
+// refinery's pre-merge gates.
+var ErrGateFailed = errors.New("pre-merge gate failed")
+
+// Pre-merge gates checked by Revalidate, in order.
+const (
+	GateBranch    = "branch"
+	GateConflicts = "conflicts"
+	GateTests     = "tests"
+)
+
+// GateResult is the outcome of one pre-merge gate.
+type GateResult struct {
+	Gate   string `json:"gate"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Revalidate runs the gates the refinery applies before merging (the branch
+// exists, merges cleanly into target, and passes the rig's test command if
+// run_tests is on) against the branch's current head, without merging.
+// It stops at the first failing gate and returns the results so far with an
+// error wrapping ErrGateFailed. Gate output (git, tests) goes to log.
+//
+// It holds the rig's processing lock, since it uses the refinery's working
+// tree; if a refinery cycle is running it returns ErrProcessingLocked.
+func (e *Engineer) Revalidate(ctx context.Context, branch, target string, log io.Writer) ([]GateResult, error) {
+	unlock, err := e.LockProcessing()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	g := e.git.WithContext(ctx).WithTranscript(log)
+	var results []GateResult
+	pass := func(gate, detail string) {
+		results = append(results, GateResult{Gate: gate, Passed: true, Detail: detail})
+	}
+	fail := func(gate, detail string) ([]GateResult, error) {
+		results = append(results, GateResult{Gate: gate, Detail: detail})
+		return results, fmt.Errorf("%w: %s: %s", ErrGateFailed, gate, detail)
+	}
+
+	// Branch exists; revalidation is against its current head
+	exists, err := g.BranchExists(branch)
+	if err != nil {
+		return fail(GateBranch, fmt.Sprintf("checking branch %s: %v", branch, err))
+	}
+	if !exists {
+		return fail(GateBranch, fmt.Sprintf("branch %s not found locally", branch))
+	}
+	head, err := g.Rev(branch)
+	if err != nil {
+		return fail(GateBranch, fmt.Sprintf("reading head of %s: %v", branch, err))
+	}
+	pass(GateBranch, branch+" at "+shortSHA(head))
+
+	// Merges cleanly into an up-to-date target
+	if err := g.Checkout(target); err != nil {
+		return fail(GateConflicts, fmt.Sprintf("checking out target %s: %v", target, err))
+	}
+	if err := g.Pull("origin", target); err != nil {
+		_, _ = fmt.Fprintf(log, "warning: pull from origin/%s: %v (continuing)\n", target, err)
+	}
+	conflicts, err := g.CheckConflicts(branch, target)
+	if err != nil {
+		return fail(GateConflicts, fmt.Sprintf("conflict check failed: %v", err))
+	}
+	if len(conflicts) > 0 {
+		return fail(GateConflicts, fmt.Sprintf("merge conflicts in: %v", conflicts))
+	}
+	pass(GateConflicts, "merges cleanly into "+target)
+
+	// Tests, run on the branch head (detached: a worker's worktree may
+	// have the branch checked out)
+	if !e.config.RunTests || e.config.TestCommand == "" {
+		return results, nil
+	}
+	if err := g.Checkout(head); err != nil {
+		return fail(GateTests, fmt.Sprintf("checking out %s: %v", branch, err))
+	}
+	defer func() { _ = e.git.Checkout(target) }() // not ctx-bound: restore even after cancellation
+	if result := e.runTests(ctx, e.workDir, log); !result.Success {
+		return fail(GateTests, result.Error)
+	}
+	pass(GateTests, e.config.TestCommand)
+	return results, nil
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,145 @@
+package refinery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// ErrUntrustedSignature is the failure for an MR whose head commit isn't
+// signed by a trusted key while merge_queue.signature_policy is on.
+var ErrUntrustedSignature = errors.New("head commit not signed by a trusted key")
+
+// SignatureCheck is the result of verifying an MR branch's head commit.
+type SignatureCheck struct {
+	Commit  string `json:"commit"`
+	Signed  bool   `json:"signed"`
+	Trusted bool   `json:"trusted"`
+	Signer  string `json:"signer,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Detail  string `json:"detail"`
+
+	// Policy is the rig's signature_policy the check was made under.
+	Policy string `json:"policy"`
+}
+
+// SignatureTrusted reports whether a verified signature is acceptable, with
+// the reason. With trusted keys configured, the signing key must be one of
+// them (by GPG long key ID or fingerprint, or SSH fingerprint); otherwise
+// git must fully trust the signature (status G), which depends on the gpg
+// trust db or gpg.ssh.allowedSignersFile.
+func SignatureTrusted(sig *git.CommitSignature, trusted []string) (bool, string) {
+	if !sig.Good() {
+		return false, sig.Describe()
+	}
+	desc := sig.Describe()
+	if sig.Key != "" {
+		desc += " from key " + sig.Key
+	}
+	if len(trusted) == 0 {
+		if sig.Code != "G" {
+			return false, desc + "; trust the key in git or list it in trusted_signing_keys"
+		}
+		return true, desc
+	}
+	tooShort := false
+	for _, want := range trusted {
+		if !strings.Contains(want, ":") && len(normalizeKeyID(want)) < minKeyIDLen {
+			tooShort = true
+			continue
+		}
+		if keyMatches(want, sig.Key) || keyMatches(want, sig.Fingerprint) {
+			return true, desc
+		}
+	}
+	if tooShort {
+		return false, desc + ", which is not in trusted_signing_keys (entries shorter than a 16-hex long key ID are ignored)"
+	}
+	return false, desc + ", which is not in trusted_signing_keys"
+}
+
+// minKeyIDLen is the shortest GPG key ID accepted in trusted_signing_keys:
+// a long key ID. Short (8-hex) IDs are easy to collide.
+const minKeyIDLen = 16
+
+// keyMatches reports whether a configured trusted key names got. SSH
+// fingerprints (SHA256:...) are base64 and compared exactly; GPG key IDs
+// and fingerprints are hex, compared ignoring case and spaces. A long key
+// ID (16 hex) may match the end of a fingerprint; anything shorter, or not
+// hex, matches nothing.
+func keyMatches(want, got string) bool {
+	want = strings.TrimSpace(want)
+	if want == "" || got == "" {
+		return false
+	}
+	if strings.Contains(want, ":") {
+		return want == got
+	}
+	want = normalizeKeyID(want)
+	if len(want) < minKeyIDLen || strings.Trim(want, "0123456789ABCDEF") != "" {
+		return false
+	}
+	return strings.HasSuffix(strings.ToUpper(got), want)
+}
+
+// normalizeKeyID upper-cases a GPG key ID or fingerprint and drops spaces.
+func normalizeKeyID(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(key), " ", ""))
+}
+
+// signatureEnforced reports whether the rig's config requires signed MRs.
+func (c *MergeQueueConfig) signatureEnforced() bool {
+	return c.SignaturePolicy == config.SignaturePolicyBlock || c.SignaturePolicy == config.SignaturePolicyReject
+}
+
+// CheckSignature verifies the signature on rev's commit (a branch's head,
+// or a commit SHA) against the rig's trusted keys, whether or not the
+// policy enforces it. The commit checked is the result's Commit.
+func (e *Engineer) CheckSignature(g *git.Git, rev string) (*SignatureCheck, error) {
+	head, err := g.Rev(rev)
+	if err != nil {
+		return nil, fmt.Errorf("reading head of %s: %w", rev, err)
+	}
+	sig, err := g.CommitSignature(head)
+	if err != nil {
+		return nil, fmt.Errorf("verifying signature on %s: %w", shortSHA(head), err)
+	}
+	trusted, detail := SignatureTrusted(sig, e.config.TrustedSigningKeys)
+	return &SignatureCheck{
+		Commit:  head,
+		Signed:  sig.Code != "N" && sig.Code != "",
+		Trusted: trusted,
+		Signer:  sig.Signer,
+		Key:     sig.Key,
+		Detail:  detail,
+		Policy:  e.config.SignaturePolicy,
+	}, nil
+}
+
+// verifySignature runs CheckSignature for a merge if the policy is on, or
+// returns nil if it is off. A verification error counts as untrusted.
+// Merges pass the commit SHA they will merge, not the branch name, so a
+// commit pushed to the branch after the check can't be merged unverified.
+func (e *Engineer) verifySignature(g *git.Git, rev string) *SignatureCheck {
+	if !e.config.signatureEnforced() {
+		return nil
+	}
+	check, err := e.CheckSignature(g, rev)
+	if err != nil {
+		return &SignatureCheck{Detail: err.Error(), Policy: e.config.SignaturePolicy}
+	}
+	return check
+}
+
+// CheckSignature verifies an MR branch's head commit signature under the
+// rig's merge queue config (see Engineer.CheckSignature).
+func (m *Manager) CheckSignature(branch string) (*SignatureCheck, error) {
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return nil, err
+	}
+	return eng.CheckSignature(eng.git, branch)
+}
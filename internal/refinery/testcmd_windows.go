@@ -0,0 +1,9 @@
+//go:build windows
+
+package refinery
+
+import "os/exec"
+
+// killProcessGroupOnCancel is a no-op on Windows: cancelling kills only the
+// test command itself, and cmd.WaitDelay bounds the wait for its children.
+func killProcessGroupOnCancel(cmd *exec.Cmd) {}
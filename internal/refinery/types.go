@@ -4,9 +4,12 @@ package refinery
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/agent"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // State is an alias for agent.State for backwards compatibility.
@@ -36,12 +39,44 @@ type Refinery struct {
 	// CurrentMR is the merge request currently being processed.
 	CurrentMR *MergeRequest `json:"current_mr,omitempty"`
 
+	// CurrentBatch lists every MR being merged together with CurrentMR,
+	// when the refinery is merging a batch.
+	CurrentBatch []string `json:"current_batch,omitempty"`
+
+	// CurrentPID is the process working on CurrentMR, so an MR left
+	// behind by a cycle that died can be told apart from live work.
+	CurrentPID int `json:"current_pid,omitempty"`
+
 	// PendingMRs tracks merge requests that have been submitted.
 	// Key is the MR ID.
 	PendingMRs map[string]*MergeRequest `json:"pending_mrs,omitempty"`
 
 	// LastMergeAt is when the last successful merge happened.
 	LastMergeAt *time.Time `json:"last_merge_at,omitempty"`
+
+	// LastError is the most recent refinery cycle failure (not a single MR's
+	// failure). Cleared by the next successful cycle.
+	LastError string `json:"last_error,omitempty"`
+
+	// LastErrorAt is when LastError was recorded.
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+
+	// Holds are MR IDs and branch patterns the refinery skips until they
+	// are removed (gt mq hold / gt mq unhold).
+	Holds []string `json:"holds,omitempty"`
+}
+
+// Merging returns the IDs of the MRs being merged right now: CurrentMR and
+// the rest of its batch, if the process working on them is still alive.
+// An MR published by a process that has since died is not merging.
+func (r *Refinery) Merging() []string {
+	if r.CurrentMR == nil || (r.CurrentPID != 0 && !util.ProcessExists(r.CurrentPID)) {
+		return nil
+	}
+	if len(r.CurrentBatch) > 0 {
+		return r.CurrentBatch
+	}
+	return []string{r.CurrentMR.ID}
 }
 
 // MergeRequest represents a branch waiting to be merged.
@@ -64,6 +99,9 @@ type MergeRequest struct {
 	// TargetBranch is where this should merge (usually integration or main).
 	TargetBranch string `json:"target_branch"`
 
+	// Priority is the MR's priority, 0 (highest) to 4 (lowest).
+	Priority int `json:"priority,omitempty"`
+
 	// CreatedAt is when the MR was queued.
 	CreatedAt time.Time `json:"created_at"`
 
@@ -109,6 +147,26 @@ const (
 	CloseReasonSuperseded CloseReason = "superseded"
 )
 
+// RejectCategories are the accepted categories for a manual rejection, so
+// rejections can be broken down by cause. "other" covers anything else.
+var RejectCategories = []string{"duplicate", "quality", "superseded", "obsolete", "other"}
+
+// ValidateRejectCategory returns the normalized (lowercase) category, or an
+// error if it isn't one of RejectCategories. An empty category is allowed.
+func ValidateRejectCategory(category string) (string, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return "", nil
+	}
+	for _, c := range RejectCategories {
+		if c == category {
+			return category, nil
+		}
+	}
+	return "", fmt.Errorf("unknown reject category %q (valid: %s; use 'other' otherwise)",
+		category, strings.Join(RejectCategories, ", "))
+}
+
 
 // MergeConfig contains configuration for the merge process.
 type MergeConfig struct {
@@ -150,6 +208,10 @@ type QueueItem struct {
 	Position  int       `json:"position"`
 	MR        *MergeRequest `json:"mr"`
 	Age       string    `json:"age"`
+
+	// Issue is the MR bead, for labels, draft status and the like; nil for
+	// the MR being merged, which comes from the refinery's state.
+	Issue *beads.Issue `json:"-"`
 }
 
 // State transition errors.
@@ -305,3 +305,23 @@ func TestFailureType_ShouldAssignToWorker(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRejectCategory(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"duplicate", "duplicate", false},
+		{" Quality ", "quality", false},
+		{"other", "other", false},
+		{"spam", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ValidateRejectCategory(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ValidateRejectCategory(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// mergeWorkspace is where a merge happens: the rig's own checkout of the
+// target branch, or with isolate_merges a throwaway worktree detached at
+// the target's tip.
+type mergeWorkspace struct {
+	git   *git.Git // bound to the merge's context and transcript
+	plain *git.Git // not ctx-bound, for cleanup after cancellation
+	dir   string   // where tests run
+	base  string   // the target as checked out (the branch, or HEAD if detached)
+	push  string   // refspec pushing the merge to the target
+
+	// close discards the workspace; a no-op for the rig's checkout.
+	close func()
+}
+
+// openMergeWorkspace checks out target for a merge, up to date with origin.
+// The caller must call close when done.
+func (e *Engineer) openMergeWorkspace(ctx context.Context, target, name string, transcript io.Writer) (*mergeWorkspace, error) {
+	if e.config.IsolateMerges {
+		return e.openMergeWorktree(ctx, target, name, transcript)
+	}
+
+	g := e.git.WithContext(ctx).WithTranscript(transcript)
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking out target branch %s...\n", target)
+	if err := g.Checkout(target); err != nil {
+		return nil, fmt.Errorf("failed to checkout target %s: %w", target, err)
+	}
+	// Make sure target is up to date with origin
+	if err := g.Pull("origin", target); err != nil {
+		// Pull might fail if nothing to pull, that's ok
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+	}
+	return &mergeWorkspace{
+		git:   g,
+		plain: e.git,
+		dir:   e.workDir,
+		base:  target,
+		push:  target,
+		close: func() {},
+	}, nil
+}
+
+// openMergeWorktree creates a temporary worktree of the rig's repo (the
+// shared .repo.git if there is one), detached at origin's tip of target.
+// Closing it removes the worktree and its registration, so a failed or
+// cancelled merge leaves nothing behind in the rig.
+func (e *Engineer) openMergeWorktree(ctx context.Context, target, name string, transcript io.Writer) (*mergeWorkspace, error) {
+	repo := e.mergeRepo()
+	rg := repo.WithContext(ctx).WithTranscript(transcript)
+
+	start := target
+	if err := rg.FetchBranch("origin", target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: fetch origin/%s: %v (using local %s)\n", target, err, target)
+	} else {
+		start = "FETCH_HEAD"
+	}
+	tip, err := rg.Rev(start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target %s: %w", target, err)
+	}
+
+	dir, err := os.MkdirTemp("", "gt-merge-"+name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("creating merge worktree: %w", err)
+	}
+	remove := func() {
+		// Not ctx-bound: the worktree must go even after cancellation
+		if err := repo.WorktreeRemove(dir, true); err != nil {
+			_ = repo.WorktreePrune()
+		}
+		_ = os.RemoveAll(dir)
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Creating merge worktree for %s at %s...\n", target, tip[:8])
+	if err := rg.WorktreeAddDetached(dir, tip); err != nil {
+		remove()
+		return nil, fmt.Errorf("creating merge worktree: %w", err)
+	}
+
+	wt := git.NewGit(dir)
+	wt.SetEnv(e.rig.GitEnv())
+	return &mergeWorkspace{
+		git:   wt.WithContext(ctx).WithTranscript(transcript),
+		plain: wt,
+		dir:   dir,
+		base:  "HEAD",
+		push:  "HEAD:refs/heads/" + target,
+		close: remove,
+	}, nil
+}
+
+// branchRepo is the repository the engineer reads MR branches from: the
+// one merge worktrees are created from with isolate_merges, else its own.
+func (e *Engineer) branchRepo() *git.Git {
+	if e.config.IsolateMerges {
+		return e.mergeRepo()
+	}
+	return e.git
+}
+
+// mergeRepo is the repository merge worktrees are created from: the rig's
+// shared bare repo if it has one, else the repo the engineer works in.
+func (e *Engineer) mergeRepo() *git.Git {
+	bareRepo := filepath.Join(e.rig.Path, ".repo.git")
+	if info, err := os.Stat(bareRepo); err == nil && info.IsDir() {
+		g := git.NewGitWithDir(bareRepo, "")
+		g.SetEnv(e.rig.GitEnv())
+		return g
+	}
+	return e.git
+}
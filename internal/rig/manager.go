@@ -4,37 +4,66 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/hosting"
 	"github.com/steveyegge/gastown/internal/templates"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // Common errors
 var (
 	ErrRigNotFound = errors.New("rig not found")
 	ErrRigExists   = errors.New("rig already exists")
+
+	// ErrRigNameAmbiguous is returned by GetRig when a name matches several
+	// rigs differing only in case.
+	ErrRigNameAmbiguous = errors.New("ambiguous rig name")
+
+	// ErrProtectedBranch is returned for a direct merge or push to a branch
+	// matching the rig's protected_branches.
+	ErrProtectedBranch = errors.New("branch is protected")
+
+	// ErrNotARig is returned by LoadRigFromPath for a directory without a
+	// rig config.json.
+	ErrNotARig = errors.New("not a rig")
+
+	// ErrHooksDir means the rig config's hooks_dir is not an existing directory.
+	ErrHooksDir = errors.New("hooks_dir is not a directory")
 )
 
 // RigConfig represents the rig-level configuration (config.json at rig root).
 type RigConfig struct {
-	Type          string       `json:"type"`                     // "rig"
-	Version       int          `json:"version"`                  // schema version
-	Name          string       `json:"name"`                     // rig name
-	GitURL        string       `json:"git_url"`                  // repository URL
-	LocalRepo     string       `json:"local_repo,omitempty"`     // optional local reference repo
-	DefaultBranch string       `json:"default_branch,omitempty"` // main, master, etc.
-	CreatedAt     time.Time    `json:"created_at"`               // when rig was created
-	Beads         *BeadsConfig `json:"beads,omitempty"`
+	Type              string            `json:"type"`                         // "rig"
+	Version           int               `json:"version"`                      // schema version
+	Name              string            `json:"name"`                         // rig name
+	GitURL            string            `json:"git_url"`                      // repository URL
+	LocalRepo         string            `json:"local_repo,omitempty"`         // optional local reference repo
+	DefaultBranch     string            `json:"default_branch,omitempty"`     // main, master, etc.
+	WorkerRoot        string            `json:"worker_root,omitempty"`        // absolute path for polecat worktrees (default: <rig>/polecats)
+	WorkerRoots       []string          `json:"worker_roots,omitempty"`       // dirs holding worker worktrees, in lookup order (default: polecats, crew)
+	Env               map[string]string `json:"env,omitempty"`                // extra environment for git invocations (augments, never replaces)
+	Operators         map[string]string `json:"operators,omitempty"`          // OS user -> worker name (for --me)
+	BuildRoot         string            `json:"build_root,omitempty"`         // build artifact dir, relative to the worker (or absolute)
+	CloneArgs         []string          `json:"clone_args,omitempty"`         // raw extra git clone flags (e.g. --filter=blob:none)
+	ProtectedBranches []string          `json:"protected_branches,omitempty"` // branch globs (e.g. release/*) only changed via the host's PR flow
+	HooksDir          string            `json:"hooks_dir,omitempty"`          // shared git hooks for worker worktrees (core.hooksPath), relative to the rig or absolute
+	CreatedAt         time.Time         `json:"created_at"`                   // when rig was created
+	Beads             *BeadsConfig      `json:"beads,omitempty"`
+	Hosting           *hosting.Config   `json:"hosting,omitempty"` // optional GitHub/GitLab integration for live PR data
 }
 
 // BeadsConfig represents beads configuration for the rig.
@@ -79,22 +108,85 @@ func (m *Manager) DiscoverRigs() ([]*Rig, error) {
 	return rigs, nil
 }
 
-// GetRig returns a specific rig by name.
+// GetRig returns a specific rig by name. The name is matched exactly, or
+// else case-insensitively, so "Gastown" finds the rig "gastown"; the rig
+// returned always carries its registered name.
 func (m *Manager) GetRig(name string) (*Rig, error) {
 	entry, ok := m.config.Rigs[name]
 	if !ok {
-		return nil, ErrRigNotFound
+		matches := m.foldMatches(name)
+		switch len(matches) {
+		case 0:
+			return nil, ErrRigNotFound
+		case 1:
+			name = matches[0]
+			entry = m.config.Rigs[name]
+		default:
+			return nil, fmt.Errorf("%w: %q could be %s; use the exact name", ErrRigNameAmbiguous, name, strings.Join(quoteAll(matches), " or "))
+		}
 	}
 
 	return m.loadRig(name, entry)
 }
 
+// foldMatches returns the registered rig names equal to name ignoring
+// case, sorted.
+func (m *Manager) foldMatches(name string) []string {
+	var matches []string
+	for registered := range m.config.Rigs {
+		if strings.EqualFold(registered, name) {
+			matches = append(matches, registered)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// quoteAll quotes each string for an error message.
+func quoteAll(ss []string) []string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = strconv.Quote(s)
+	}
+	return quoted
+}
+
 // RigExists checks if a rig is registered.
 func (m *Manager) RigExists(name string) bool {
 	_, ok := m.config.Rigs[name]
 	return ok
 }
 
+// LoadRigFromPath loads the rig in the directory at path, which need not
+// be registered in a town: its config.json stands in for the rigs.json
+// entry, and its parent directory for the town root.
+func LoadRigFromPath(path string) (*Rig, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadRigConfig(absPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: no config.json in %s", ErrNotARig, absPath)
+		}
+		return nil, fmt.Errorf("reading rig config in %s: %w", absPath, err)
+	}
+	if cfg.Type != "rig" {
+		return nil, fmt.Errorf("%w: %s has config type %q", ErrNotARig, absPath, cfg.Type)
+	}
+
+	entry := config.RigEntry{
+		GitURL:    cfg.GitURL,
+		LocalRepo: cfg.LocalRepo,
+	}
+	if cfg.Beads != nil {
+		entry.BeadsConfig = &config.BeadsConfig{Prefix: cfg.Beads.Prefix}
+	}
+	m := &Manager{townRoot: filepath.Dir(absPath)}
+	return m.loadRig(filepath.Base(absPath), entry)
+}
+
 // loadRig loads rig details from the filesystem.
 func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 	rigPath := filepath.Join(m.townRoot, name)
@@ -108,6 +200,12 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 		return nil, fmt.Errorf("not a directory: %s", rigPath)
 	}
 
+	// Check the rig config here, so every command that loads the rig
+	// reports a bad one (and warns about a bad hooks_dir) up front.
+	if _, err := LoadRigConfig(rigPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("rig %s: %w", name, err)
+	}
+
 	rig := &Rig{
 		Name:      name,
 		Path:      rigPath,
@@ -117,7 +215,7 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 	}
 
 	// Scan for polecats
-	polecatsDir := filepath.Join(rigPath, "polecats")
+	polecatsDir := rig.PolecatsDir()
 	if entries, err := os.ReadDir(polecatsDir); err == nil {
 		for _, e := range entries {
 			if !e.IsDir() {
@@ -141,6 +239,19 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 		}
 	}
 
+	// Every worker, across the configured worker roots
+	for _, root := range rig.WorkerRoots() {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() && !strings.HasPrefix(e.Name(), ".") && !slices.Contains(rig.Workers, e.Name()) {
+				rig.Workers = append(rig.Workers, e.Name())
+			}
+		}
+	}
+
 	// Check for witness (witnesses don't have clones, just the witness directory)
 	witnessPath := filepath.Join(rigPath, "witness")
 	if info, err := os.Stat(witnessPath); err == nil && info.IsDir() {
@@ -164,11 +275,13 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 
 // AddRigOptions configures rig creation.
 type AddRigOptions struct {
-	Name          string // Rig name (directory name)
-	GitURL        string // Repository URL
-	BeadsPrefix   string // Beads issue prefix (defaults to derived from name)
-	LocalRepo     string // Optional local repo for reference clones
-	DefaultBranch string // Default branch (defaults to auto-detected from remote)
+	Name          string    // Rig name (directory name)
+	GitURL        string    // Repository URL
+	BeadsPrefix   string    // Beads issue prefix (defaults to derived from name)
+	LocalRepo     string    // Optional local repo for reference clones
+	DefaultBranch string    // Default branch (defaults to auto-detected from remote)
+	CloneArgs     []string  // Extra raw git clone flags, saved as clone_args
+	Progress      io.Writer // Optional: receives git clone progress (nil discards it)
 }
 
 func resolveLocalRepo(path, gitURL string) (string, string) {
@@ -217,6 +330,11 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	if m.RigExists(opts.Name) {
 		return nil, ErrRigExists
 	}
+	// Names differing only in case would collide on case-insensitive
+	// filesystems and make lookups ambiguous
+	if matches := m.foldMatches(opts.Name); len(matches) > 0 {
+		return nil, fmt.Errorf("%w: %q differs only in case from %q", ErrRigExists, opts.Name, matches[0])
+	}
 
 	// Validate rig name: reject characters that break agent ID parsing
 	// Agent IDs use format <prefix>-<rig>-<role>[-<name>] with hyphens as delimiters
@@ -238,6 +356,11 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		opts.BeadsPrefix = deriveBeadsPrefix(opts.Name)
 	}
 
+	if err := git.ValidateCloneArgs(opts.CloneArgs); err != nil {
+		return nil, err
+	}
+	cloneGit := m.git.WithCloneArgs(opts.CloneArgs).WithProgress(opts.Progress)
+
 	localRepo, warn := resolveLocalRepo(opts.LocalRepo, opts.GitURL)
 	if warn != "" {
 		fmt.Printf("  Warning: %s\n", warn)
@@ -264,6 +387,7 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		Name:      opts.Name,
 		GitURL:    opts.GitURL,
 		LocalRepo: localRepo,
+		CloneArgs: opts.CloneArgs,
 		CreatedAt: time.Now(),
 		Beads: &BeadsConfig{
 			Prefix: opts.BeadsPrefix,
@@ -279,15 +403,15 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	fmt.Printf("  Cloning repository (this may take a moment)...\n")
 	bareRepoPath := filepath.Join(rigPath, ".repo.git")
 	if localRepo != "" {
-		if err := m.git.CloneBareWithReference(opts.GitURL, bareRepoPath, localRepo); err != nil {
+		if err := cloneGit.CloneBareWithReference(opts.GitURL, bareRepoPath, localRepo); err != nil {
 			fmt.Printf("  Warning: could not use local repo reference: %v\n", err)
 			_ = os.RemoveAll(bareRepoPath)
-			if err := m.git.CloneBare(opts.GitURL, bareRepoPath); err != nil {
+			if err := cloneGit.CloneBare(opts.GitURL, bareRepoPath); err != nil {
 				return nil, fmt.Errorf("creating bare repo: %w", err)
 			}
 		}
 	} else {
-		if err := m.git.CloneBare(opts.GitURL, bareRepoPath); err != nil {
+		if err := cloneGit.CloneBare(opts.GitURL, bareRepoPath); err != nil {
 			return nil, fmt.Errorf("creating bare repo: %w", err)
 		}
 	}
@@ -320,15 +444,15 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		return nil, fmt.Errorf("creating mayor dir: %w", err)
 	}
 	if localRepo != "" {
-		if err := m.git.CloneWithReference(opts.GitURL, mayorRigPath, localRepo); err != nil {
+		if err := cloneGit.CloneWithReference(opts.GitURL, mayorRigPath, localRepo); err != nil {
 			fmt.Printf("  Warning: could not use local repo reference: %v\n", err)
 			_ = os.RemoveAll(mayorRigPath)
-			if err := m.git.Clone(opts.GitURL, mayorRigPath); err != nil {
+			if err := cloneGit.Clone(opts.GitURL, mayorRigPath); err != nil {
 				return nil, fmt.Errorf("cloning for mayor: %w", err)
 			}
 		}
 	} else {
-		if err := m.git.Clone(opts.GitURL, mayorRigPath); err != nil {
+		if err := cloneGit.Clone(opts.GitURL, mayorRigPath); err != nil {
 			return nil, fmt.Errorf("cloning for mayor: %w", err)
 		}
 	}
@@ -516,10 +640,11 @@ func (m *Manager) saveRigConfig(rigPath string, cfg *RigConfig) error {
 	return os.WriteFile(configPath, data, 0644)
 }
 
-// LoadRigConfig reads the rig configuration from config.json.
+// LoadRigConfig reads the rig configuration from config.json, with the
+// active environment's overlay (config.<env>.json) merged in.
 func LoadRigConfig(rigPath string) (*RigConfig, error) {
 	configPath := filepath.Join(rigPath, "config.json")
-	data, err := os.ReadFile(configPath)
+	data, overlayPath, err := config.ReadWithOverlay(configPath)
 	if err != nil {
 		return nil, err
 	}
@@ -527,9 +652,57 @@ func LoadRigConfig(rigPath string) (*RigConfig, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	if cfg.Type == "rig" || cfg.Type == "" {
+		source := configPath
+		if overlayPath != "" {
+			source += " + " + filepath.Base(overlayPath)
+		}
+		// merge_queue is read by the refinery, which checks its keys itself.
+		config.WarnUnknownKeys(source, "", data, RigConfig{}, "merge_queue")
+
+		// A relative worker_root has no sensible base (the rig, the town,
+		// the cwd?), so it is refused rather than quietly ignored.
+		if cfg.WorkerRoot != "" && !filepath.IsAbs(cfg.WorkerRoot) {
+			return nil, fmt.Errorf("%s: worker_root %q must be an absolute path", source, cfg.WorkerRoot)
+		}
+
+		// Only worker creation needs hooks_dir, and fails on a bad one; the
+		// rest of the config is still usable, so warn rather than fail here.
+		if cfg.HooksDir != "" {
+			if _, err := ResolveHooksDir(rigPath, cfg.HooksDir); err != nil {
+				if _, seen := warnedHooksDirs.LoadOrStore(source+"\x00"+cfg.HooksDir, true); !seen {
+					fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", source, err)
+				}
+			}
+		}
+	}
 	return &cfg, nil
 }
 
+// warnedHooksDirs dedups the bad hooks_dir warning: the rig config is
+// loaded many times per command.
+var warnedHooksDirs sync.Map
+
+// ResolveHooksDir resolves a rig config hooks_dir (relative to rigPath, or
+// absolute) and checks it is an existing directory. Returns an error
+// wrapping ErrHooksDir if not.
+func ResolveHooksDir(rigPath, dir string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(rigPath, dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s does not exist", ErrHooksDir, dir)
+		}
+		return "", fmt.Errorf("%w: %v", ErrHooksDir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%w: %s is a file", ErrHooksDir, dir)
+	}
+	return dir, nil
+}
+
 // initBeads initializes the beads database at rig level.
 // The project's .beads/config.yaml determines sync-branch settings.
 // Use `bd doctor --fix` in the project to configure sync-branch if needed.
@@ -889,8 +1062,12 @@ func (m *Manager) createRoleCLAUDEmd(workspacePath string, role string, rigName
 		return err
 	}
 
-	// Get town name for session names
-	townName, _ := workspace.GetTownName(m.townRoot)
+	// Get town name for session names. Read town.json directly: the
+	// workspace package imports rig to find worker roots.
+	var townName string
+	if townCfg, err := config.LoadTownConfig(filepath.Join(m.townRoot, "mayor", "town.json")); err == nil {
+		townName = townCfg.Name
+	}
 
 	// Get default branch from rig config (default to "main" if not set)
 	defaultBranch := "main"
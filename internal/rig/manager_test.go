@@ -1,8 +1,11 @@
 package rig
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
 	"strings"
 	"testing"
@@ -130,6 +133,94 @@ func TestGetRigNotFound(t *testing.T) {
 	}
 }
 
+func TestGetRig_ChecksRigConfig(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	createTestRig(t, root, "test-rig")
+	rigsConfig.Rigs["test-rig"] = config.RigEntry{}
+	rigPath := filepath.Join(root, "test-rig")
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	// A bad hooks_dir is reported once when the rig loads, but doesn't block it
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test-rig","hooks_dir":"no-such-hooks"}`)
+	stderr := captureStderr(t, func() {
+		for i := 0; i < 2; i++ {
+			if _, err := manager.GetRig("test-rig"); err != nil {
+				t.Fatalf("GetRig with bad hooks_dir: %v", err)
+			}
+		}
+	})
+	if n := strings.Count(stderr, "hooks_dir"); n != 1 {
+		t.Errorf("hooks_dir warnings = %d, want 1; stderr:\n%s", n, stderr)
+	}
+
+	// An invalid config fails the load
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test-rig","worker_root":"fast/polecats"}`)
+	if _, err := manager.GetRig("test-rig"); err == nil || !strings.Contains(err.Error(), "worker_root") {
+		t.Errorf("GetRig with relative worker_root error = %v, want it rejected", err)
+	}
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	old := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = old }()
+
+	fn()
+
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read stderr: %v", err)
+	}
+	return string(out)
+}
+
+func TestGetRig_CaseInsensitive(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	createTestRig(t, root, "gastown")
+	rigsConfig.Rigs["gastown"] = config.RigEntry{}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	rig, err := manager.GetRig("Gastown")
+	if err != nil {
+		t.Fatalf("GetRig(Gastown): %v", err)
+	}
+	if rig.Name != "gastown" || rig.Path != filepath.Join(root, "gastown") {
+		t.Errorf("GetRig(Gastown) = %q at %s, want the registered rig gastown", rig.Name, rig.Path)
+	}
+}
+
+func TestGetRig_AmbiguousCase(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	rigsConfig.Rigs["gastown"] = config.RigEntry{}
+	rigsConfig.Rigs["GasTown"] = config.RigEntry{}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	_, err := manager.GetRig("GASTOWN")
+	if !errors.Is(err, ErrRigNameAmbiguous) {
+		t.Fatalf("GetRig(GASTOWN) = %v, want ErrRigNameAmbiguous", err)
+	}
+	if !strings.Contains(err.Error(), `"GasTown" or "gastown"`) {
+		t.Errorf("error %q should suggest both names", err)
+	}
+}
+
+func TestAddRig_RejectsCaseCollision(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	rigsConfig.Rigs["gastown"] = config.RigEntry{}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	_, err := manager.AddRig(AddRigOptions{Name: "Gastown", GitURL: "git@github.com:test/test.git"})
+	if !errors.Is(err, ErrRigExists) {
+		t.Errorf("AddRig(Gastown) = %v, want ErrRigExists", err)
+	}
+}
+
 func TestRigExists(t *testing.T) {
 	root, rigsConfig := setupTestTown(t)
 	rigsConfig.Rigs["exists"] = config.RigEntry{}
@@ -237,6 +328,152 @@ func TestRigSummary(t *testing.T) {
 	}
 }
 
+func TestRigPolecatsDir(t *testing.T) {
+	rigPath := t.TempDir()
+	r := &Rig{Name: "test", Path: rigPath}
+
+	// No config: defaults to <rig>/polecats
+	if got, want := r.PolecatsDir(), filepath.Join(rigPath, "polecats"); got != want {
+		t.Errorf("PolecatsDir() = %q, want %q", got, want)
+	}
+
+	// Relative worker_root is rejected at config load
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test","worker_root":"fast/polecats"}`)
+	if _, err := LoadRigConfig(rigPath); err == nil || !strings.Contains(err.Error(), "worker_root") {
+		t.Errorf("LoadRigConfig() with relative worker_root error = %v, want it rejected", err)
+	}
+
+	// Absolute worker_root is used as-is
+	external := t.TempDir()
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test","worker_root":"`+external+`"}`)
+	if got := r.PolecatsDir(); got != external {
+		t.Errorf("PolecatsDir() with worker_root = %q, want %q", got, external)
+	}
+}
+
+func TestRigWorkerRoots(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	createTestRig(t, root, "test")
+	rigPath := filepath.Join(root, "test")
+	for _, dir := range []string{"crew/max", "bots/reviewer"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, dir), 0755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	rigsConfig.Rigs["test"] = config.RigEntry{}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	// No config: polecats, then crew
+	r, err := manager.GetRig("test")
+	if err != nil {
+		t.Fatalf("GetRig: %v", err)
+	}
+	want := []string{filepath.Join(rigPath, "polecats"), filepath.Join(rigPath, "crew")}
+	if got := r.WorkerRoots(); !slices.Equal(got, want) {
+		t.Errorf("WorkerRoots() = %v, want %v", got, want)
+	}
+	if got := r.WorkerDir("reviewer"); got != "" {
+		t.Errorf("WorkerDir(reviewer) = %q, want none outside the default roots", got)
+	}
+
+	// Custom roots, in order, relative or absolute
+	external := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(external, "max"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test","worker_roots":["bots","`+external+`","crew"]}`)
+	r, err = manager.GetRig("test")
+	if err != nil {
+		t.Fatalf("GetRig: %v", err)
+	}
+	if got := r.WorkerDir("reviewer"); got != filepath.Join(rigPath, "bots", "reviewer") {
+		t.Errorf("WorkerDir(reviewer) = %q, want it under bots/", got)
+	}
+	if got := r.WorkerDir("max"); got != filepath.Join(external, "max") {
+		t.Errorf("WorkerDir(max) = %q, want the first root that has it", got)
+	}
+	if got := r.WorkerDir("Toast"); got != "" {
+		t.Errorf("WorkerDir(Toast) = %q, want none: polecats is not a configured root", got)
+	}
+	slices.Sort(r.Workers)
+	if want := []string{"max", "reviewer"}; !slices.Equal(r.Workers, want) {
+		t.Errorf("Workers = %v, want %v", r.Workers, want)
+	}
+}
+
+func TestRigBuildRoot(t *testing.T) {
+	rigPath := t.TempDir()
+	r := &Rig{Name: "test", Path: rigPath}
+	worker := filepath.Join(rigPath, "polecats", "Toast")
+
+	// No config: the worker path itself
+	got, err := r.BuildRoot(worker)
+	if err != nil || got != worker {
+		t.Errorf("BuildRoot() = %q, %v; want %q", got, err, worker)
+	}
+
+	// Relative build_root resolves against the worker and is created
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test","build_root":"../Toast-build"}`)
+	want := filepath.Join(rigPath, "polecats", "Toast-build")
+	got, err = r.BuildRoot(worker)
+	if err != nil || got != want {
+		t.Fatalf("BuildRoot() = %q, %v; want %q", got, err, want)
+	}
+	if info, err := os.Stat(want); err != nil || !info.IsDir() {
+		t.Errorf("expected build root %s to be created: %v", want, err)
+	}
+}
+
+func TestRigCheckBranchUnprotected(t *testing.T) {
+	rigPath := t.TempDir()
+	r := &Rig{Name: "test", Path: rigPath}
+
+	// No config: nothing is protected
+	if err := r.CheckBranchUnprotected("main"); err != nil {
+		t.Errorf("CheckBranchUnprotected(main) without config = %v", err)
+	}
+
+	writeRigConfig(t, rigPath, `{"type":"rig","name":"test","protected_branches":["main","release/*"]}`)
+	for _, branch := range []string{"main", "release/1.2"} {
+		err := r.CheckBranchUnprotected(branch)
+		if !errors.Is(err, ErrProtectedBranch) {
+			t.Errorf("CheckBranchUnprotected(%s) = %v, want ErrProtectedBranch", branch, err)
+		}
+	}
+	for _, branch := range []string{"integration/gt-epic", "release/1.2/hotfix"} {
+		if err := r.CheckBranchUnprotected(branch); err != nil {
+			t.Errorf("CheckBranchUnprotected(%s) = %v, want nil", branch, err)
+		}
+	}
+}
+
+func writeRigConfig(t *testing.T, rigPath, data string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(data), 0644); err != nil {
+		t.Fatalf("write config.json: %v", err)
+	}
+}
+
+func TestLoadRigConfig_EnvOverlay(t *testing.T) {
+	rigPath := t.TempDir()
+	writeRigConfig(t, rigPath, `{"name":"gastown","git_url":"git@dev:gastown.git","env":{"A":"1","B":"2"}}`)
+	if err := os.WriteFile(filepath.Join(rigPath, "config.prod.json"), []byte(`{"git_url":"git@prod:gastown.git","env":{"B":"prod"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(config.EnvVar, "prod")
+	cfg, err := LoadRigConfig(rigPath)
+	if err != nil {
+		t.Fatalf("LoadRigConfig: %v", err)
+	}
+	if cfg.GitURL != "git@prod:gastown.git" {
+		t.Errorf("GitURL = %q, want the prod overlay's", cfg.GitURL)
+	}
+	if want := map[string]string{"A": "1", "B": "prod"}; !reflect.DeepEqual(cfg.Env, want) {
+		t.Errorf("Env = %v, want %v", cfg.Env, want)
+	}
+}
+
 func TestEnsureGitignoreEntry_AddsEntry(t *testing.T) {
 	root, rigsConfig := setupTestTown(t)
 	manager := NewManager(root, rigsConfig, git.NewGit(root))
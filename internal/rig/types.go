@@ -2,7 +2,13 @@
 package rig
 
 import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
 )
 
 // Rig represents a managed repository in the workspace.
@@ -37,6 +43,10 @@ type Rig struct {
 
 	// HasMayor indicates if the rig has a mayor clone.
 	HasMayor bool `json:"has_mayor"`
+
+	// Workers lists every worker in the rig's worker roots (polecats, crew
+	// and any custom roots; see WorkerRoots).
+	Workers []string `json:"workers,omitempty"`
 }
 
 // AgentDirs are the standard agent directories in a rig.
@@ -89,3 +99,150 @@ func (r *Rig) DefaultBranch() string {
 	}
 	return cfg.DefaultBranch
 }
+
+// GitEnv returns the extra environment variables configured for git
+// invocations in this rig (e.g., GIT_CONFIG_* overrides, credential helpers).
+// Returns nil if none are configured or the config cannot be loaded.
+func (r *Rig) GitEnv() map[string]string {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil {
+		return nil
+	}
+	return cfg.Env
+}
+
+// CloneArgs returns the extra git clone flags configured for this rig
+// (clone_args). Returns an error if they fail ValidateCloneArgs, so a bad
+// config fails the clone instead of being passed to git.
+func (r *Rig) CloneArgs() ([]string, error) {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil {
+		return nil, nil
+	}
+	if err := git.ValidateCloneArgs(cfg.CloneArgs); err != nil {
+		return nil, fmt.Errorf("rig %s config: %w", r.Name, err)
+	}
+	return cfg.CloneArgs, nil
+}
+
+// HooksDir returns the shared git hooks directory worker worktrees are
+// pointed at (hooks_dir), resolved to an absolute path, or "" if none is
+// configured. Returns an error if it is not an existing directory, so a bad
+// config fails worker creation instead of silently skipping policy hooks.
+func (r *Rig) HooksDir() (string, error) {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil || cfg.HooksDir == "" {
+		return "", nil
+	}
+	dir, err := ResolveHooksDir(r.Path, cfg.HooksDir)
+	if err != nil {
+		return "", fmt.Errorf("rig %s config: %w", r.Name, err)
+	}
+	return dir, nil
+}
+
+// ProtectedBranchError reports a branch matching a protected_branches pattern.
+// It unwraps to ErrProtectedBranch.
+type ProtectedBranchError struct {
+	Branch  string
+	Pattern string
+}
+
+func (e *ProtectedBranchError) Error() string {
+	return fmt.Sprintf("%s: %s matches protected_branches pattern %q; merge it through the host's pull request flow",
+		ErrProtectedBranch, e.Branch, e.Pattern)
+}
+
+func (e *ProtectedBranchError) Unwrap() error {
+	return ErrProtectedBranch
+}
+
+// CheckBranchUnprotected returns a *ProtectedBranchError if branch matches
+// one of the rig's protected_branches patterns (globs like "release/*", as in
+// path.Match). The host rejects direct pushes to such branches, so the merge
+// queue refuses them up front.
+func (r *Rig) CheckBranchUnprotected(branch string) error {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil {
+		return nil
+	}
+	for _, pattern := range cfg.ProtectedBranches {
+		if matched, _ := path.Match(pattern, branch); matched || pattern == branch {
+			return &ProtectedBranchError{Branch: branch, Pattern: pattern}
+		}
+	}
+	return nil
+}
+
+// BuildRoot returns the directory where builds for a worker should put their
+// artifacts, creating it if missing. A configured build_root is resolved
+// relative to workerPath (e.g., "../build" for a sibling, "out" for a subdir),
+// or used as-is if absolute. Without one, the worker path itself is returned.
+func (r *Rig) BuildRoot(workerPath string) (string, error) {
+	cfg, err := LoadRigConfig(r.Path)
+	if err != nil || cfg.BuildRoot == "" {
+		return workerPath, nil
+	}
+
+	root := cfg.BuildRoot
+	if !filepath.IsAbs(root) {
+		root = filepath.Join(workerPath, root)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", fmt.Errorf("creating build root: %w", err)
+	}
+	return root, nil
+}
+
+// PolecatsDir returns the directory holding this rig's polecat worktrees.
+// Uses the configured worker_root (always absolute; LoadRigConfig rejects a
+// relative one), so worktrees can live outside the rig container (e.g., on
+// a faster disk). Falls back to <rig>/polecats.
+func (r *Rig) PolecatsDir() string {
+	if cfg, err := LoadRigConfig(r.Path); err == nil && filepath.IsAbs(cfg.WorkerRoot) {
+		return cfg.WorkerRoot
+	}
+	return filepath.Join(r.Path, "polecats")
+}
+
+// DefaultWorkerRoots are the directories, relative to the rig, that hold
+// worker worktrees unless the rig config sets worker_roots.
+var DefaultWorkerRoots = []string{"polecats", "crew"}
+
+// WorkerRoots returns the directories holding this rig's worker worktrees,
+// in lookup order: the rig config's worker_roots (each relative to the rig,
+// or absolute), else polecats and crew. The polecats root honors
+// worker_root (see PolecatsDir).
+func (r *Rig) WorkerRoots() []string {
+	roots := DefaultWorkerRoots
+	if cfg, err := LoadRigConfig(r.Path); err == nil && len(cfg.WorkerRoots) > 0 {
+		roots = cfg.WorkerRoots
+	}
+	dirs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		switch {
+		case root == "polecats":
+			dirs = append(dirs, r.PolecatsDir())
+		case filepath.IsAbs(root):
+			dirs = append(dirs, root)
+		default:
+			dirs = append(dirs, filepath.Join(r.Path, root))
+		}
+	}
+	return dirs
+}
+
+// WorkerDir returns the worktree directory of the named worker, from the
+// first worker root that has it, or "" if none does.
+func (r *Rig) WorkerDir(worker string) string {
+	if worker == "" {
+		return ""
+	}
+	for _, root := range r.WorkerRoots() {
+		path := filepath.Join(root, worker)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Column defines a table column with name and width.
+// Column defines a table column with name and width. A Width of 0 fits the
+// column to its widest value (and header), so nothing is truncated.
 type Column struct {
 	Name  string
 	Width int
@@ -30,6 +31,8 @@ type Table struct {
 	columns    []Column
 	rows       [][]string
 	headerSep  bool
+	noHeader   bool
+	border     bool
 	indent     string
 	headerStyle lipgloss.Style
 }
@@ -56,6 +59,20 @@ func (t *Table) SetHeaderSeparator(enabled bool) *Table {
 	return t
 }
 
+// SetHeader enables/disables the header row (and its separator), leaving
+// only data rows. Useful for output meant for awk/cut.
+func (t *Table) SetHeader(enabled bool) *Table {
+	t.noHeader = !enabled
+	return t
+}
+
+// SetBorder enables/disables box-drawing borders around the table and
+// between its cells.
+func (t *Table) SetBorder(enabled bool) *Table {
+	t.border = enabled
+	return t
+}
+
 // AddRow adds a row of values to the table.
 func (t *Table) AddRow(values ...string) *Table {
 	// Pad with empty strings if needed
@@ -71,26 +88,26 @@ func (t *Table) Render() string {
 	if len(t.columns) == 0 {
 		return ""
 	}
+	if t.border {
+		return t.renderBoxed()
+	}
 
 	var sb strings.Builder
+	widths := t.widths()
 
 	// Render header
-	sb.WriteString(t.indent)
-	for i, col := range t.columns {
-		text := t.headerStyle.Render(col.Name)
-		sb.WriteString(t.pad(text, col.Name, col.Width, col.Align))
-		if i < len(t.columns)-1 {
-			sb.WriteString(" ")
-		}
+	if !t.noHeader {
+		sb.WriteString(t.indent)
+		sb.WriteString(strings.Join(t.headerCells(widths), " "))
+		sb.WriteString("\n")
 	}
-	sb.WriteString("\n")
 
 	// Render separator
-	if t.headerSep {
+	if t.headerSep && !t.noHeader {
 		sb.WriteString(t.indent)
 		totalWidth := 0
-		for i, col := range t.columns {
-			totalWidth += col.Width
+		for i := range t.columns {
+			totalWidth += widths[i]
 			if i < len(t.columns)-1 {
 				totalWidth++ // space between columns
 			}
@@ -102,53 +119,145 @@ func (t *Table) Render() string {
 	// Render rows
 	for _, row := range t.rows {
 		sb.WriteString(t.indent)
-		for i, col := range t.columns {
-			val := ""
-			if i < len(row) {
-				val = row[i]
-			}
-			// Truncate if too long
-			plainVal := stripAnsi(val)
-			if len(plainVal) > col.Width {
-				val = plainVal[:col.Width-3] + "..."
-			}
-			// Apply column style if set
-			if col.Style.Value() != "" {
-				val = col.Style.Render(val)
-			}
-			sb.WriteString(t.pad(val, plainVal, col.Width, col.Align))
-			if i < len(t.columns)-1 {
-				sb.WriteString(" ")
-			}
+		sb.WriteString(strings.Join(t.rowCells(row, widths), " "))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// renderBoxed renders the table with box-drawing borders.
+func (t *Table) renderBoxed() string {
+	var sb strings.Builder
+	widths := t.widths()
+
+	rule := func(left, mid, right string) {
+		segments := make([]string, len(widths))
+		for i, w := range widths {
+			segments[i] = strings.Repeat("─", w+2)
 		}
+		sb.WriteString(t.indent)
+		sb.WriteString(Dim.Render(left + strings.Join(segments, mid) + right))
 		sb.WriteString("\n")
 	}
+	line := func(cells []string) {
+		bar := Dim.Render("│")
+		sb.WriteString(t.indent)
+		sb.WriteString(bar + " " + strings.Join(cells, " "+bar+" ") + " " + bar)
+		sb.WriteString("\n")
+	}
+
+	rule("┌", "┬", "┐")
+	if !t.noHeader {
+		line(t.headerCells(widths))
+		if len(t.rows) > 0 {
+			rule("├", "┼", "┤")
+		}
+	}
+	for _, row := range t.rows {
+		line(t.rowCells(row, widths))
+	}
+	rule("└", "┴", "┘")
 
 	return sb.String()
 }
 
-// pad pads text to width, accounting for ANSI escape sequences.
-// styledText is the text with ANSI codes, plainText is without.
-func (t *Table) pad(styledText, plainText string, width int, align Alignment) string {
-	plainLen := len(plainText)
-	if plainLen >= width {
-		return styledText
+// headerCells returns the styled, padded header cells.
+func (t *Table) headerCells(widths []int) []string {
+	cells := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		cells[i] = padWidth(t.headerStyle.Render(col.Name), widths[i], col.Align)
+	}
+	return cells
+}
+
+// rowCells returns a row's cells, truncated to their column's width,
+// styled and padded.
+func (t *Table) rowCells(row []string, widths []int) []string {
+	cells := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		val := ""
+		if i < len(row) {
+			val = row[i]
+		}
+		// Truncate if too long (dropping any styling)
+		if VisibleWidth(val) > widths[i] {
+			if widths[i] > 3 {
+				val = truncateWidth(stripAnsi(val), widths[i]-3) + "..."
+			} else {
+				val = truncateWidth(stripAnsi(val), widths[i])
+			}
+		}
+		// Apply column style if set
+		if col.Style.Value() != "" {
+			val = col.Style.Render(val)
+		}
+		cells[i] = padWidth(val, widths[i], col.Align)
+	}
+	return cells
+}
+
+// widths returns each column's rendered width: its Width, or for Width 0
+// the widest of its header and values.
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.columns))
+	for i, col := range t.columns {
+		if col.Width > 0 {
+			widths[i] = col.Width
+			continue
+		}
+		widths[i] = VisibleWidth(col.Name)
+		for _, row := range t.rows {
+			if i < len(row) && VisibleWidth(row[i]) > widths[i] {
+				widths[i] = VisibleWidth(row[i])
+			}
+		}
 	}
+	return widths
+}
 
-	padding := width - plainLen
+// VisibleWidth returns how many terminal columns s takes up: ANSI escape
+// sequences take none, and wide characters take two.
+func VisibleWidth(s string) int {
+	return lipgloss.Width(s)
+}
+
+// padWidth pads s with spaces to width terminal columns, going by its
+// visible width so styled text lines up with plain text.
+func padWidth(s string, width int, align Alignment) string {
+	padding := width - VisibleWidth(s)
+	if padding <= 0 {
+		return s
+	}
 
 	switch align {
 	case AlignRight:
-		return strings.Repeat(" ", padding) + styledText
+		return strings.Repeat(" ", padding) + s
 	case AlignCenter:
 		left := padding / 2
 		right := padding - left
-		return strings.Repeat(" ", left) + styledText + strings.Repeat(" ", right)
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", right)
 	default: // AlignLeft
-		return styledText + strings.Repeat(" ", padding)
+		return s + strings.Repeat(" ", padding)
 	}
 }
 
+// truncateWidth cuts plain text to at most width terminal columns, without
+// splitting a character.
+func truncateWidth(s string, width int) string {
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		w := VisibleWidth(string(r))
+		if used+w > width {
+			break
+		}
+		sb.WriteRune(r)
+		used += w
+	}
+	return sb.String()
+}
+
 // stripAnsi removes ANSI escape sequences from a string.
 func stripAnsi(s string) string {
 	var result strings.Builder
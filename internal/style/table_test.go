@@ -0,0 +1,53 @@
+package style
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestTable_AlignsStyledAndWideCells(t *testing.T) {
+	// Render with colors, as on a terminal, so styled cells carry escapes
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	defer lipgloss.SetColorProfile(prev)
+
+	for _, border := range []bool{false, true} {
+		table := NewTable(
+			Column{Name: "ID", Width: 6},
+			Column{Name: "STATUS"},
+			Column{Name: "AGE", Align: AlignRight},
+		).SetBorder(border)
+		table.AddRow("gt-1", Bold.Render("▶ merging"), Dim.Render("5m"))
+		table.AddRow("gt-2-long-id", "ready", "12h")
+
+		var widths []int
+		for _, line := range strings.Split(strings.TrimRight(table.Render(), "\n"), "\n") {
+			widths = append(widths, VisibleWidth(line))
+		}
+		for i, w := range widths {
+			if w != widths[0] {
+				t.Errorf("border=%v: line %d is %d columns wide, want %d like the first", border, i, w, widths[0])
+			}
+		}
+	}
+}
+
+func TestTable_TruncatesByWidth(t *testing.T) {
+	out := NewTable(Column{Name: "BRANCH", Width: 8}).SetIndent("").SetHeader(false).
+		AddRow("polecat/→/gt-abc").Render()
+	if got := strings.TrimRight(out, "\n"); got != "polec..." {
+		t.Errorf("truncated cell = %q, want %q", got, "polec...")
+	}
+}
+
+func TestTable_Border(t *testing.T) {
+	out := NewTable(Column{Name: "ID"}).SetIndent("").SetBorder(true).AddRow("gt-1").Render()
+	lines := strings.Split(strings.TrimRight(stripAnsi(out), "\n"), "\n")
+	want := []string{"┌──────┐", "│ ID   │", "├──────┤", "│ gt-1 │", "└──────┘"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("boxed table:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,67 @@
+package triage
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyMap defines the key bindings for the triage TUI.
+type KeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding // toggle the MR under the cursor
+	All    key.Binding // select all / none
+	Retry  key.Binding
+	Reject key.Binding
+	Help   key.Binding
+	Quit   key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys(" ", "x"),
+			key.WithHelp("space/x", "select"),
+		),
+		All: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "select all/none"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry selected"),
+		),
+		Reject: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "reject selected"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// ShortHelp returns keybindings to show in the help view.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Select, k.Retry, k.Reject, k.Quit, k.Help}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Select, k.All},
+		{k.Retry, k.Reject},
+		{k.Help, k.Quit},
+	}
+}
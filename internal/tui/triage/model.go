@@ -0,0 +1,190 @@
+// Package triage is an interactive picker for bulk merge queue cleanup:
+// select MRs, choose to retry or reject them, and give one reason for all.
+package triage
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Actions the operator can apply to the selected MRs.
+const (
+	ActionRetry  = "retry"
+	ActionReject = "reject"
+)
+
+// Item is one MR in the triage list.
+type Item struct {
+	ID     string
+	Branch string
+	Worker string
+	Status string // e.g. "failed", "ready"
+	Age    string
+	Error  string // last merge error, if it failed
+}
+
+// Decision is what the operator chose: an action, the MRs to apply it to
+// (in list order) and the reason. The TUI only collects it; the caller
+// applies it once the program exits.
+type Decision struct {
+	Action string
+	IDs    []string
+	Reason string
+}
+
+// Model is the bubbletea model for the triage TUI.
+type Model struct {
+	items    []Item
+	cursor   int
+	selected map[string]bool
+
+	// action is set while the reason is being entered
+	action string
+	reason textinput.Model
+	notice string
+
+	decision *Decision
+
+	// UI state
+	keys     KeyMap
+	help     help.Model
+	showHelp bool
+	width    int
+}
+
+// New creates a triage model over items.
+func New(items []Item) Model {
+	reason := textinput.New()
+	reason.Placeholder = "reason"
+	reason.CharLimit = 500
+	return Model{
+		items:    items,
+		selected: make(map[string]bool),
+		reason:   reason,
+		keys:     DefaultKeyMap(),
+		help:     help.New(),
+	}
+}
+
+// Init initializes the model.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Decision returns what the operator chose, or false if they quit
+// without choosing.
+func (m Model) Decision() (Decision, bool) {
+	if m.decision == nil {
+		return Decision{}, false
+	}
+	return *m.decision, true
+}
+
+// Update handles messages.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.help.Width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.action != "" {
+			return m.updateReason(msg)
+		}
+		m.notice = ""
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = !m.showHelp
+
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.Select):
+			if len(m.items) > 0 {
+				id := m.items[m.cursor].ID
+				m.selected[id] = !m.selected[id]
+			}
+
+		case key.Matches(msg, m.keys.All):
+			all := len(m.selectedIDs()) < len(m.items)
+			for _, item := range m.items {
+				m.selected[item.ID] = all
+			}
+
+		case key.Matches(msg, m.keys.Retry):
+			return m.startAction(ActionRetry)
+
+		case key.Matches(msg, m.keys.Reject):
+			return m.startAction(ActionReject)
+		}
+	}
+	return m, nil
+}
+
+// startAction switches to entering the reason for action. With nothing
+// selected, it applies to the MR under the cursor.
+func (m Model) startAction(action string) (tea.Model, tea.Cmd) {
+	if len(m.items) == 0 {
+		return m, nil
+	}
+	if len(m.selectedIDs()) == 0 {
+		m.selected[m.items[m.cursor].ID] = true
+	}
+	m.action = action
+	m.reason.SetValue("")
+	return m, m.reason.Focus()
+}
+
+// updateReason handles keys while the reason is being entered: enter
+// confirms, esc goes back to the list.
+func (m Model) updateReason(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.action = ""
+		m.notice = ""
+		m.reason.Blur()
+		return m, nil
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		reason := m.reason.Value()
+		if m.action == ActionReject && reason == "" {
+			m.notice = "a reason is required to reject"
+			return m, nil
+		}
+		m.decision = &Decision{Action: m.action, IDs: m.selectedIDs(), Reason: reason}
+		return m, tea.Quit
+	}
+	var cmd tea.Cmd
+	m.reason, cmd = m.reason.Update(msg)
+	return m, cmd
+}
+
+// selectedIDs returns the selected MR IDs in list order.
+func (m Model) selectedIDs() []string {
+	var ids []string
+	for _, item := range m.items {
+		if m.selected[item.ID] {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// View renders the model.
+func (m Model) View() string {
+	return m.renderView()
+}
@@ -0,0 +1,82 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func press(m Model, msgs ...tea.KeyMsg) Model {
+	for _, msg := range msgs {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func testItems() []Item {
+	return []Item{
+		{ID: "gt-mr-1", Status: "failed", Error: "tests failed"},
+		{ID: "gt-mr-2", Status: "ready"},
+		{ID: "gt-mr-3", Status: "failed", Error: "conflict"},
+	}
+}
+
+func TestModel_RetrySelected(t *testing.T) {
+	m := press(New(testItems()),
+		runes(" "), runes("j"), runes("j"), runes(" "),
+		runes("r"), runes("flaky"), tea.KeyMsg{Type: tea.KeyEnter})
+
+	got, ok := m.Decision()
+	if !ok {
+		t.Fatal("expected a decision")
+	}
+	want := Decision{Action: ActionRetry, IDs: []string{"gt-mr-1", "gt-mr-3"}, Reason: "flaky"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decision() = %+v, want %+v", got, want)
+	}
+}
+
+func TestModel_RejectNeedsReason(t *testing.T) {
+	m := press(New(testItems()), runes("a"), runes("D"), tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.Decision(); ok {
+		t.Fatal("reject without a reason should not produce a decision")
+	}
+	if m.notice == "" {
+		t.Error("expected a notice asking for a reason")
+	}
+
+	m = press(m, runes("obsolete"), tea.KeyMsg{Type: tea.KeyEnter})
+	got, ok := m.Decision()
+	if !ok {
+		t.Fatal("expected a decision")
+	}
+	if got.Action != ActionReject || len(got.IDs) != 3 || got.Reason != "obsolete" {
+		t.Errorf("Decision() = %+v", got)
+	}
+}
+
+func TestModel_CursorWithoutSelection(t *testing.T) {
+	m := press(New(testItems()), runes("j"), runes("r"), tea.KeyMsg{Type: tea.KeyEnter})
+	got, ok := m.Decision()
+	if !ok || !reflect.DeepEqual(got.IDs, []string{"gt-mr-2"}) {
+		t.Errorf("Decision() = %+v, %v; want the MR under the cursor", got, ok)
+	}
+}
+
+func TestModel_EscReturnsToList(t *testing.T) {
+	m := press(New(testItems()), runes("r"), tea.KeyMsg{Type: tea.KeyEsc})
+	if m.action != "" {
+		t.Errorf("action = %q after esc, want none", m.action)
+	}
+	// Back in the list, q quits without a decision.
+	m = press(m, runes("q"))
+	if _, ok := m.Decision(); ok {
+		t.Error("quitting should not produce a decision")
+	}
+}
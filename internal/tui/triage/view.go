@@ -0,0 +1,88 @@
+package triage
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// selectedStyle highlights the row under the cursor.
+var selectedStyle = lipgloss.NewStyle().
+	Background(lipgloss.Color("236")).
+	Foreground(lipgloss.Color("15"))
+
+// renderView renders the entire view.
+func (m Model) renderView() string {
+	var b strings.Builder
+
+	b.WriteString(style.Bold.Render("Merge queue triage"))
+	if n := len(m.selectedIDs()); n > 0 {
+		b.WriteString(style.Dim.Render(fmt.Sprintf("  (%d selected)", n)))
+	}
+	b.WriteString("\n\n")
+
+	if len(m.items) == 0 {
+		b.WriteString("No open merge requests.\n\n")
+		b.WriteString(style.Dim.Render("q:quit"))
+		return b.String()
+	}
+
+	for i, item := range m.items {
+		mark := "[ ]"
+		if m.selected[item.ID] {
+			mark = "[x]"
+		}
+		status := item.Status
+		switch status {
+		case "failed":
+			status = style.Error.Render(fmt.Sprintf("%-8s", status))
+		default:
+			status = fmt.Sprintf("%-8s", status)
+		}
+		line := fmt.Sprintf("%s %-14s %s %-28s %-10s %4s", mark, truncate(item.ID, 14), status,
+			truncate(item.Branch, 28), truncate(item.Worker, 10), item.Age)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+		if item.Error != "" && (i == m.cursor || m.selected[item.ID]) {
+			b.WriteString("      " + style.Dim.Render(truncate(item.Error, 72)) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.action != "" {
+		prompt := fmt.Sprintf("%s %d MR(s) - reason", m.action, len(m.selectedIDs()))
+		if m.action == ActionRetry {
+			prompt += " (optional, added as a note)"
+		}
+		b.WriteString(style.Bold.Render(prompt) + ": " + m.reason.View() + "\n")
+		if m.notice != "" {
+			b.WriteString(style.Warning.Render(m.notice) + "\n")
+		}
+		b.WriteString(style.Dim.Render("enter:apply  esc:back"))
+		return b.String()
+	}
+
+	if m.showHelp {
+		b.WriteString(m.help.View(m.keys))
+	} else {
+		b.WriteString(style.Dim.Render("j/k:navigate  space:select  a:all  r:retry  D:reject  q:quit  ?:help"))
+	}
+	return b.String()
+}
+
+// truncate shortens a string to the given rune length, preserving UTF-8.
+func truncate(s string, maxLen int) string {
+	if utf8.RuneCountInString(s) <= maxLen {
+		return s
+	}
+	runes := []rune(s)
+	if maxLen <= 3 {
+		return "..."
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
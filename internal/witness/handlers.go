@@ -760,8 +760,9 @@ func verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
 		defaultBranch = rigCfg.DefaultBranch
 	}
 
-	// Construct polecat path: <townRoot>/<rigName>/polecats/<polecatName>
-	polecatPath := filepath.Join(townRoot, rigName, "polecats", polecatName)
+	// Construct polecat path: <polecats dir>/<polecatName> (see rig.PolecatsDir)
+	r := &rig.Rig{Name: rigName, Path: filepath.Join(townRoot, rigName)}
+	polecatPath := filepath.Join(r.PolecatsDir(), polecatName)
 
 	// Get git for the polecat worktree
 	g := git.NewGit(polecatPath)
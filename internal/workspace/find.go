@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
 )
 
 // ErrNotFound indicates no workspace was found.
@@ -28,7 +29,7 @@ const (
 
 // Find locates the town root by walking up from the given directory.
 // It prefers mayor/town.json over mayor/ directory as workspace marker.
-// When in a worktree path (polecats/ or crew/), continues to outermost workspace.
+// When in a worktree path (under a rig's worker roots), continues to outermost workspace.
 // Does not resolve symlinks to stay consistent with os.Getwd().
 func Find(startDir string) (string, error) {
 	absDir, err := filepath.Abs(startDir)
@@ -65,9 +66,21 @@ func Find(startDir string) (string, error) {
 	}
 }
 
+// isInWorktreePath reports whether path lies inside a worker worktree: below
+// one of the worker roots (polecats/ and crew/ unless worker_roots says
+// otherwise) of any of its ancestors taken as a rig.
 func isInWorktreePath(path string) bool {
-	sep := string(filepath.Separator)
-	return strings.Contains(path, sep+"polecats"+sep) || strings.Contains(path, sep+"crew"+sep)
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		r := &rig.Rig{Name: filepath.Base(dir), Path: dir}
+		for _, root := range r.WorkerRoots() {
+			if rel, err := filepath.Rel(root, path); err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return true
+			}
+		}
+		if filepath.Dir(dir) == dir {
+			return false
+		}
+	}
 }
 
 // FindOrError is like Find but returns a user-friendly error if not found.
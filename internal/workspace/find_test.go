@@ -278,3 +278,38 @@ func TestFindSkipsNestedWorkspaceInCrew(t *testing.T) {
 		t.Errorf("Find = %q, want %q (should skip nested workspace in crew/)", found, root)
 	}
 }
+
+func TestFindSkipsNestedWorkspaceInCustomWorkerRoot(t *testing.T) {
+	root := realPath(t, t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join(root, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "mayor", "town.json"), []byte(`{"name":"outer"}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	rigDir := filepath.Join(root, "myrig")
+	if err := os.MkdirAll(rigDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rigDir, "config.json"), []byte(`{"type":"rig","name":"myrig","worker_roots":["bots"]}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	botDir := filepath.Join(rigDir, "bots", "worker")
+	if err := os.MkdirAll(filepath.Join(botDir, "mayor"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(botDir, "mayor", "town.json"), []byte(`{"name":"inner"}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	found, err := Find(botDir)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	if found != root {
+		t.Errorf("Find = %q, want %q (should skip nested workspace in bots/)", found, root)
+	}
+}
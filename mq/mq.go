@@ -0,0 +1,268 @@
+// Package mq is a Go API for a rig's merge queue, for programs that drive
+// the queue directly instead of shelling out to 'gt mq'.
+//
+// It covers what the gt mq commands do to MRs: list the queue, look up an
+// MR, retry a failed one, reject, approve a draft, retarget and add notes.
+// The merge itself stays with the rig's refinery.
+//
+//	q, err := mq.Open(townRoot, "greenplace")
+//	if err != nil { ... }
+//	items, err := q.List()
+//	...
+//	_, err = q.Retry(items[0].ID, mq.RetryOptions{})
+//
+// Errors can be matched with errors.Is against the Err* values below.
+//
+// A Queue reads and writes the rig's MR beads and refinery state on disk,
+// like the gt commands do; use one from a single goroutine at a time.
+package mq
+
+import (
+	"io"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// Errors returned by Queue methods, wrapped with details.
+var (
+	ErrRigNotFound   = rig.ErrRigNotFound
+	ErrNotARig       = rig.ErrNotARig
+	ErrNotFound      = refinery.ErrMRNotFound      // no such MR
+	ErrNotFailed     = refinery.ErrMRNotFailed     // Retry of an MR that hasn't failed
+	ErrClosed        = refinery.ErrMRClosed        // Retry of a closed MR
+	ErrAlreadyClosed = refinery.ErrMRAlreadyClosed // Reject of a merged or closed MR (see RejectOptions.Force)
+	ErrPaused        = refinery.ErrRigPaused       // the rig's queue is paused
+	ErrTargetMissing = refinery.ErrTargetNotFound  // Retarget to a branch not on the remote
+)
+
+// Status is an MR's status.
+type Status string
+
+// MR statuses.
+const (
+	StatusOpen       Status = Status(refinery.MROpen)
+	StatusInProgress Status = Status(refinery.MRInProgress)
+	StatusClosed     Status = Status(refinery.MRClosed)
+)
+
+// MR is a merge request.
+type MR struct {
+	ID          string    `json:"id"`
+	Branch      string    `json:"branch"`
+	Worker      string    `json:"worker,omitempty"`
+	SourceIssue string    `json:"source_issue,omitempty"`
+	Target      string    `json:"target"`
+	Priority    int       `json:"priority"`
+	Status      Status    `json:"status"`
+	CloseReason string    `json:"close_reason,omitempty"` // e.g. merged, rejected; set once closed
+	Error       string    `json:"error,omitempty"`        // last merge failure, while waiting for a retry
+	Draft       bool      `json:"draft,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Failed reports whether the MR failed to merge and is waiting for a retry.
+func (mr *MR) Failed() bool {
+	return mr.Status == StatusOpen && mr.Error != ""
+}
+
+// Item is an MR in the queue, at its position: 0 for the MR being merged,
+// then 1, 2, ... in the order the refinery will take them.
+type Item struct {
+	MR
+	Position int `json:"position"`
+}
+
+// RetryOptions configures Retry.
+type RetryOptions struct {
+	// Deprioritize drops the MR one priority level (down to P4).
+	Deprioritize bool
+}
+
+// RejectOptions configures Reject.
+type RejectOptions struct {
+	Reason       string // required
+	Category     string // duplicate, quality, superseded, obsolete or other (optional)
+	Notify       bool   // mail the worker the reason
+	SupersededBy string // the MR replacing this one (optional; must exist)
+
+	// Force rejects an MR that has already merged or closed, overwriting
+	// its record; Actor is recorded as who forced it.
+	Force bool
+	Actor string
+}
+
+// Rejection is the outcome of Reject.
+type Rejection struct {
+	MR         MR     `json:"mr"`
+	ThreadID   string `json:"thread_id,omitempty"`  // mail thread of the worker notification
+	Overridden string `json:"overridden,omitempty"` // the state check Force bypassed, if any
+}
+
+// Queue is a rig's merge queue.
+type Queue struct {
+	rig *rig.Rig
+	mgr *refinery.Manager
+}
+
+// Open opens the merge queue of the rig registered as rigName in the town
+// at townRoot.
+func Open(townRoot, rigName string) (*Queue, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	r, err := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot)).GetRig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	return newQueue(r), nil
+}
+
+// OpenPath opens the merge queue of the rig in the directory rigPath,
+// whether or not it is registered in a town.
+func OpenPath(rigPath string) (*Queue, error) {
+	r, err := rig.LoadRigFromPath(rigPath)
+	if err != nil {
+		return nil, err
+	}
+	return newQueue(r), nil
+}
+
+func newQueue(r *rig.Rig) *Queue {
+	mgr := refinery.NewManager(r)
+	mgr.SetOutput(io.Discard)
+	return &Queue{rig: r, mgr: mgr}
+}
+
+// SetLog sends the warnings of best-effort steps (e.g. a failed worker
+// notification) to w. They are discarded by default.
+func (q *Queue) SetLog(w io.Writer) {
+	q.mgr.SetOutput(w)
+}
+
+// Rig returns the name of the queue's rig.
+func (q *Queue) Rig() string {
+	return q.rig.Name
+}
+
+// List returns the open MRs in queue order.
+func (q *Queue) List() ([]Item, error) {
+	queue, err := q.mgr.Queue()
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[string]string)
+	if mrs, err := q.mgr.FailedMRs(); err == nil {
+		for _, mr := range mrs {
+			failed[mr.ID] = mr.Error
+		}
+	}
+
+	items := make([]Item, 0, len(queue))
+	for _, qi := range queue {
+		mr := toMR(qi.MR, qi.Issue)
+		if mr.Error == "" {
+			mr.Error = failed[mr.ID]
+		}
+		items = append(items, Item{MR: mr, Position: qi.Position})
+	}
+	return items, nil
+}
+
+// Get returns the MR with the given ID, whatever its status.
+func (q *Queue) Get(id string) (*MR, error) {
+	mr, issue, err := q.mgr.ShowMR(id)
+	if err != nil {
+		return nil, err
+	}
+	out := toMR(mr, issue)
+	return &out, nil
+}
+
+// Failed returns the MRs waiting for a retry, oldest first.
+func (q *Queue) Failed() ([]MR, error) {
+	mrs, err := q.mgr.FailedMRs()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MR, 0, len(mrs))
+	for _, mr := range mrs {
+		out = append(out, toMR(mr, nil))
+	}
+	return out, nil
+}
+
+// Retry clears a failed MR's error so the refinery takes it again. It
+// returns when the MR is next eligible to merge: the zero time if on the
+// refinery's next cycle, else when the rig's merge window opens.
+func (q *Queue) Retry(id string, opts RetryOptions) (time.Time, error) {
+	return q.mgr.Retry(id, refinery.RetryOptions{Deprioritize: opts.Deprioritize})
+}
+
+// Reject closes an MR without merging it, recording the reason. The MR is
+// found by ID or branch.
+func (q *Queue) Reject(idOrBranch string, opts RejectOptions) (*Rejection, error) {
+	result, err := q.mgr.RejectMR(idOrBranch, refinery.RejectOptions{
+		Reason:       opts.Reason,
+		Category:     opts.Category,
+		Notify:       opts.Notify,
+		SupersededBy: opts.SupersededBy,
+		Force:        opts.Force,
+		Actor:        opts.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Rejection{
+		MR:         toMR(result.MR, nil),
+		ThreadID:   result.ThreadID,
+		Overridden: result.Overridden,
+	}, nil
+}
+
+// SetDraft marks an open MR as a draft, which the refinery skips, or as
+// ready again. It returns false if the MR was already in that state.
+func (q *Queue) SetDraft(id string, draft bool) (bool, error) {
+	return q.mgr.SetDraft(id, draft)
+}
+
+// Retarget changes the branch an open MR merges into, returning the old
+// target. The branch must exist on the rig's remote.
+func (q *Queue) Retarget(id, target string) (string, error) {
+	return q.mgr.SetTarget(id, target)
+}
+
+// Note adds a note to an MR, leaving the MR itself unchanged.
+func (q *Queue) Note(id, text string) error {
+	return q.mgr.AddNote(id, text)
+}
+
+// toMR converts a refinery MR, and its bead if known, to an MR.
+func toMR(mr *refinery.MergeRequest, issue *beads.Issue) MR {
+	out := MR{
+		ID:          mr.ID,
+		Branch:      mr.Branch,
+		Worker:      mr.Worker,
+		SourceIssue: mr.IssueID,
+		Target:      mr.TargetBranch,
+		Priority:    mr.Priority,
+		Status:      Status(mr.Status),
+		CloseReason: string(mr.CloseReason),
+		Error:       mr.Error,
+		CreatedAt:   mr.CreatedAt,
+	}
+	if issue != nil {
+		out.Labels = issue.Labels
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			out.Draft = fields.Draft
+		}
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package mq
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// setupQueue creates a rig with a fake bd on PATH: "bd --no-daemon list"
+// returns two open MRs (one a labeled draft) and "show <id>" knows those
+// plus a merged one.
+func setupQueue(t *testing.T) (*Queue, string) {
+	t.Helper()
+	rigPath := filepath.Join(t.TempDir(), "greenplace")
+	if err := os.MkdirAll(filepath.Join(rigPath, ".runtime"), 0755); err != nil {
+		t.Fatalf("mkdir rig: %v", err)
+	}
+	cfg := `{"type": "rig", "version": 1, "name": "greenplace", "git_url": "https://example.com/greenplace.git"}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatalf("write config.json: %v", err)
+	}
+
+	open1 := `{"id":"gp-mr-1","issue_type":"merge-request","status":"open","priority":1,"description":"branch: polecat/nux/gp-a\ntarget: main\nworker: nux"}`
+	open2 := `{"id":"gp-mr-2","issue_type":"merge-request","status":"open","priority":2,"labels":["docs"],"description":"branch: polecat/max/gp-b\ntarget: main\nworker: max\ndraft: true"}`
+	merged := `{"id":"gp-mr-0","issue_type":"merge-request","status":"closed","description":"branch: polecat/nux/gp-z\nworker: nux\nclose_reason: merged"}`
+	script := `#!/bin/sh
+case "$2" in
+list) printf '%s\n' '[` + open1 + `,` + open2 + `]' ;;
+show)
+  case "$3" in
+  gp-mr-1) printf '%s\n' '[` + open1 + `]' ;;
+  gp-mr-2) printf '%s\n' '[` + open2 + `]' ;;
+  gp-mr-0) printf '%s\n' '[` + merged + `]' ;;
+  *) echo '[]' ;;
+  esac ;;
+esac
+`
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	q, err := OpenPath(rigPath)
+	if err != nil {
+		t.Fatalf("OpenPath: %v", err)
+	}
+	return q, rigPath
+}
+
+func TestQueue_ListAndGet(t *testing.T) {
+	q, rigPath := setupQueue(t)
+	if q.Rig() != "greenplace" {
+		t.Errorf("Rig() = %q, want greenplace", q.Rig())
+	}
+
+	// gp-mr-1 failed its last merge
+	r := &rig.Rig{Name: "greenplace", Path: rigPath}
+	if err := refinery.NewManager(r).RegisterMR(&refinery.MergeRequest{ID: "gp-mr-1", Status: refinery.MROpen, Error: "tests failed"}); err != nil {
+		t.Fatalf("RegisterMR: %v", err)
+	}
+
+	items, err := q.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != "gp-mr-1" || items[1].ID != "gp-mr-2" {
+		t.Fatalf("List() = %+v, want gp-mr-1 then gp-mr-2", items)
+	}
+	if items[0].Position != 1 || !items[0].Failed() || items[0].Worker != "nux" || items[0].Target != "main" {
+		t.Errorf("items[0] = %+v, want a failed MR by nux at position 1", items[0])
+	}
+	if !items[1].Draft || !slices.Equal(items[1].Labels, []string{"docs"}) {
+		t.Errorf("items[1] = %+v, want a draft labeled docs", items[1])
+	}
+
+	mr, err := q.Get("gp-mr-0")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if mr.Status != StatusClosed || mr.CloseReason != "merged" {
+		t.Errorf("Get(gp-mr-0) = %s/%s, want closed/merged", mr.Status, mr.CloseReason)
+	}
+
+	if _, err := q.Get("gp-mr-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := q.Retry("gp-mr-2", RetryOptions{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Retry(unfailed) error = %v, want ErrNotFound", err)
+	}
+	if _, err := q.Reject("gp-mr-0", RejectOptions{Reason: "late"}); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("Reject(merged) error = %v, want ErrAlreadyClosed", err)
+	}
+}
+
+func TestOpenPath_NotARig(t *testing.T) {
+	if _, err := OpenPath(t.TempDir()); !errors.Is(err, ErrNotARig) {
+		t.Errorf("OpenPath(empty dir) error = %v, want ErrNotARig", err)
+	}
+}